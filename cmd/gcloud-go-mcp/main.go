@@ -10,18 +10,28 @@ import (
 
 	"gcloud-go-mcp/internal/config"
 	"gcloud-go-mcp/internal/services"
+	"gcloud-go-mcp/internal/services/apigateway"
+	"gcloud-go-mcp/internal/services/asset"
 	"gcloud-go-mcp/internal/services/billing"
+	"gcloud-go-mcp/internal/services/certmanager"
+	"gcloud-go-mcp/internal/services/composer"
 	"gcloud-go-mcp/internal/services/compute"
+	"gcloud-go-mcp/internal/services/eventarc"
 	"gcloud-go-mcp/internal/services/firestore"
 	"gcloud-go-mcp/internal/services/functions"
 	"gcloud-go-mcp/internal/services/gke"
 	"gcloud-go-mcp/internal/services/iam"
+	"gcloud-go-mcp/internal/services/kms"
 	"gcloud-go-mcp/internal/services/logging"
+	"gcloud-go-mcp/internal/services/monitoring"
 	"gcloud-go-mcp/internal/services/projects"
 	"gcloud-go-mcp/internal/services/pubsub"
 	"gcloud-go-mcp/internal/services/run"
 	"gcloud-go-mcp/internal/services/secrets"
+	"gcloud-go-mcp/internal/services/serviceusage"
+	"gcloud-go-mcp/internal/services/sql"
 	"gcloud-go-mcp/internal/services/storage"
+	"gcloud-go-mcp/internal/services/vertex"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -61,6 +71,18 @@ Example usage:
 	// Create base service with shared executor
 	base := services.NewBaseService(cfg)
 
+	// Check for the gcloud binary up front; every tool fails without it
+	if gcloudPath, err := config.LookupGCloud(cfg.GCloudPath); err != nil {
+		log.Printf("WARNING: %v. Tools will fail until gcloud is available.", err)
+	} else {
+		log.Printf("Using gcloud at %s", gcloudPath)
+
+		// Resolve the default project and account once instead of per command
+		if err := base.ResolveDefaults(context.Background()); err != nil {
+			log.Printf("Using gcloud's configuration per command: %v", err)
+		}
+	}
+
 	// Register all service tools
 	run.RegisterTools(server, base)
 	secrets.RegisterTools(server, base)
@@ -74,6 +96,18 @@ Example usage:
 	billing.RegisterTools(server, base)
 	pubsub.RegisterTools(server, base)
 	projects.RegisterTools(server, base)
+	serviceusage.RegisterTools(server, base)
+	kms.RegisterTools(server, base)
+	vertex.RegisterTools(server, base)
+	composer.RegisterTools(server, base)
+	monitoring.RegisterTools(server, base)
+	asset.RegisterTools(server, base)
+	apigateway.RegisterTools(server, base)
+	sql.RegisterTools(server, base)
+	eventarc.RegisterTools(server, base)
+	certmanager.RegisterTools(server, base)
+	services.RegisterCapabilitiesTool(server, base)
+	services.RegisterDiagnosticsTool(server, base)
 
 	// Setup signal handling for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
@@ -2,7 +2,12 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -17,22 +22,90 @@ type Config struct {
 	// Zone is the default zone for zonal resources.
 	Zone string
 
+	// RunRegion, FunctionsRegion and ComputeZone override Region or Zone
+	// within the Cloud Run, Cloud Functions and Compute Engine tools. The
+	// generic default is used when they are empty.
+	RunRegion       string
+	FunctionsRegion string
+	ComputeZone     string
+
 	// GCloudPath is the path to the gcloud binary.
 	GCloudPath string
 
 	// CommandTimeout is the maximum duration for command execution.
 	CommandTimeout time.Duration
+
+	// AuditLogPath is the file that tool invocations are appended to as JSON
+	// lines. Auditing is disabled when empty.
+	AuditLogPath string
+
+	// DefaultLabels are merged into the labels of every resource created
+	// through a tool that supports labels.
+	DefaultLabels map[string]string
+
+	// IncludeMetadata wraps tool results in an envelope describing the
+	// gcloud command that produced them.
+	IncludeMetadata bool
+
+	// AllowSensitiveOutput enables tools whose output contains credentials,
+	// such as generated passwords.
+	AllowSensitiveOutput bool
+
+	// RequireConfirmation makes destructive tools return a confirmation
+	// request instead of running unless they are called with confirm: true.
+	RequireConfirmation bool
+
+	// Configuration is the named gcloud configuration passed to every
+	// command with --configuration. gcloud's active configuration is used
+	// when empty.
+	Configuration string
+
+	// Account is the authenticated account passed to every command with
+	// --account. gcloud's active account is used when empty.
+	Account string
+
+	// MaxOutputBytes caps the size of a tool result. Longer output is
+	// truncated with a notice. Zero disables the limit.
+	MaxOutputBytes int
 }
 
+// DefaultMaxOutputBytes is the default MaxOutputBytes.
+const DefaultMaxOutputBytes = 100 * 1024
+
 // LoadConfig loads configuration from environment variables.
 func LoadConfig() *Config {
 	return &Config{
-		Project:        getEnv("GCLOUD_PROJECT", ""),
-		Region:         getEnv("GCLOUD_REGION", ""),
-		Zone:           getEnv("GCLOUD_ZONE", ""),
-		GCloudPath:     getEnv("GCLOUD_PATH", "gcloud"),
-		CommandTimeout: getDurationEnv("GCLOUD_TIMEOUT", 5*time.Minute),
+		Project:              getEnv("GCLOUD_PROJECT", ""),
+		Region:               getEnv("GCLOUD_REGION", ""),
+		Zone:                 getEnv("GCLOUD_ZONE", ""),
+		RunRegion:            getEnv("GCLOUD_RUN_REGION", ""),
+		FunctionsRegion:      getEnv("GCLOUD_FUNCTIONS_REGION", ""),
+		ComputeZone:          getEnv("GCLOUD_COMPUTE_ZONE", ""),
+		GCloudPath:           getEnv("GCLOUD_PATH", "gcloud"),
+		CommandTimeout:       getDurationEnv("GCLOUD_TIMEOUT", 5*time.Minute),
+		AuditLogPath:         getEnv("GCLOUD_AUDIT_LOG", ""),
+		DefaultLabels:        getMapEnv("GCLOUD_DEFAULT_LABELS"),
+		IncludeMetadata:      getBoolEnv("GCLOUD_INCLUDE_METADATA", false),
+		AllowSensitiveOutput: getBoolEnv("GCLOUD_ALLOW_SENSITIVE_OUTPUT", false),
+		RequireConfirmation:  getBoolEnv("GCLOUD_REQUIRE_CONFIRMATION", false),
+		Configuration:        getEnv("GCLOUD_CONFIGURATION", getEnv("CLOUDSDK_ACTIVE_CONFIG_NAME", "")),
+		Account:              getEnv("GCLOUD_ACCOUNT", ""),
+		MaxOutputBytes:       getIntEnv("GCLOUD_MAX_OUTPUT_BYTES", DefaultMaxOutputBytes),
+	}
+}
+
+// LookupGCloud returns the absolute path of the gcloud binary at path, which
+// is either a file path or a command name looked up in PATH.
+func LookupGCloud(path string) (string, error) {
+	resolved, err := exec.LookPath(path)
+	if err != nil {
+		return "", fmt.Errorf("gcloud binary %q not found (install the Google Cloud SDK or set GCLOUD_PATH): %w", path, err)
+	}
+	abs, err := filepath.Abs(resolved)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve gcloud binary %q: %w", resolved, err)
 	}
+	return abs, nil
 }
 
 // getEnv returns the value of an environment variable or a default value.
@@ -52,3 +125,43 @@ func getDurationEnv(key string, defaultVal time.Duration) time.Duration {
 	}
 	return defaultVal
 }
+
+// getBoolEnv returns the value of an environment variable as a bool or a default value.
+func getBoolEnv(key string, defaultVal bool) bool {
+	if val := os.Getenv(key); val != "" {
+		if b, err := strconv.ParseBool(val); err == nil {
+			return b
+		}
+	}
+	return defaultVal
+}
+
+// getIntEnv returns the value of an environment variable as a non-negative int
+// or a default value.
+func getIntEnv(key string, defaultVal int) int {
+	if val := os.Getenv(key); val != "" {
+		if n, err := strconv.Atoi(val); err == nil && n >= 0 {
+			return n
+		}
+	}
+	return defaultVal
+}
+
+// getMapEnv parses an environment variable of comma-separated key=value pairs.
+// Entries without a key are ignored.
+func getMapEnv(key string) map[string]string {
+	val := os.Getenv(key)
+	if val == "" {
+		return nil
+	}
+	result := make(map[string]string)
+	for _, pair := range strings.Split(val, ",") {
+		k, v, _ := strings.Cut(pair, "=")
+		k = strings.TrimSpace(k)
+		if k == "" {
+			continue
+		}
+		result[k] = strings.TrimSpace(v)
+	}
+	return result
+}
@@ -2,6 +2,8 @@ package config
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -13,6 +15,14 @@ func TestLoadConfig_Defaults(t *testing.T) {
 	os.Unsetenv("GCLOUD_ZONE")
 	os.Unsetenv("GCLOUD_PATH")
 	os.Unsetenv("GCLOUD_TIMEOUT")
+	os.Unsetenv("GCLOUD_AUDIT_LOG")
+	os.Unsetenv("GCLOUD_DEFAULT_LABELS")
+	os.Unsetenv("GCLOUD_INCLUDE_METADATA")
+	os.Unsetenv("GCLOUD_ALLOW_SENSITIVE_OUTPUT")
+	os.Unsetenv("GCLOUD_REQUIRE_CONFIRMATION")
+	os.Unsetenv("GCLOUD_MAX_OUTPUT_BYTES")
+	os.Unsetenv("GCLOUD_CONFIGURATION")
+	os.Unsetenv("CLOUDSDK_ACTIVE_CONFIG_NAME")
 
 	cfg := LoadConfig()
 
@@ -31,6 +41,30 @@ func TestLoadConfig_Defaults(t *testing.T) {
 	if cfg.CommandTimeout != 5*time.Minute {
 		t.Errorf("expected CommandTimeout 5m, got %v", cfg.CommandTimeout)
 	}
+	if cfg.AuditLogPath != "" {
+		t.Errorf("expected empty AuditLogPath, got %q", cfg.AuditLogPath)
+	}
+	if cfg.DefaultLabels != nil {
+		t.Errorf("expected nil DefaultLabels, got %v", cfg.DefaultLabels)
+	}
+	if cfg.IncludeMetadata {
+		t.Error("expected IncludeMetadata to be false")
+	}
+	if cfg.AllowSensitiveOutput {
+		t.Error("expected AllowSensitiveOutput to be false")
+	}
+	if cfg.RequireConfirmation {
+		t.Error("expected RequireConfirmation to be false")
+	}
+	if cfg.Configuration != "" {
+		t.Errorf("expected empty Configuration, got %q", cfg.Configuration)
+	}
+	if cfg.Account != "" {
+		t.Errorf("expected empty Account, got %q", cfg.Account)
+	}
+	if cfg.MaxOutputBytes != DefaultMaxOutputBytes {
+		t.Errorf("expected MaxOutputBytes %d, got %d", DefaultMaxOutputBytes, cfg.MaxOutputBytes)
+	}
 }
 
 func TestLoadConfig_WithEnvVars(t *testing.T) {
@@ -40,6 +74,12 @@ func TestLoadConfig_WithEnvVars(t *testing.T) {
 	os.Setenv("GCLOUD_ZONE", "us-west1-a")
 	os.Setenv("GCLOUD_PATH", "/custom/path/gcloud")
 	os.Setenv("GCLOUD_TIMEOUT", "10m")
+	os.Setenv("GCLOUD_AUDIT_LOG", "/var/log/gcloud-mcp-audit.log")
+	os.Setenv("GCLOUD_DEFAULT_LABELS", "created-by=mcp,team=platform")
+	os.Setenv("GCLOUD_INCLUDE_METADATA", "true")
+	os.Setenv("GCLOUD_ALLOW_SENSITIVE_OUTPUT", "1")
+	os.Setenv("GCLOUD_REQUIRE_CONFIRMATION", "true")
+	os.Setenv("GCLOUD_MAX_OUTPUT_BYTES", "2048")
 
 	defer func() {
 		os.Unsetenv("GCLOUD_PROJECT")
@@ -47,6 +87,12 @@ func TestLoadConfig_WithEnvVars(t *testing.T) {
 		os.Unsetenv("GCLOUD_ZONE")
 		os.Unsetenv("GCLOUD_PATH")
 		os.Unsetenv("GCLOUD_TIMEOUT")
+		os.Unsetenv("GCLOUD_AUDIT_LOG")
+		os.Unsetenv("GCLOUD_DEFAULT_LABELS")
+		os.Unsetenv("GCLOUD_INCLUDE_METADATA")
+		os.Unsetenv("GCLOUD_ALLOW_SENSITIVE_OUTPUT")
+		os.Unsetenv("GCLOUD_REQUIRE_CONFIRMATION")
+		os.Unsetenv("GCLOUD_MAX_OUTPUT_BYTES")
 	}()
 
 	cfg := LoadConfig()
@@ -66,6 +112,24 @@ func TestLoadConfig_WithEnvVars(t *testing.T) {
 	if cfg.CommandTimeout != 10*time.Minute {
 		t.Errorf("expected CommandTimeout 10m, got %v", cfg.CommandTimeout)
 	}
+	if cfg.AuditLogPath != "/var/log/gcloud-mcp-audit.log" {
+		t.Errorf("expected AuditLogPath '/var/log/gcloud-mcp-audit.log', got %q", cfg.AuditLogPath)
+	}
+	if cfg.DefaultLabels["created-by"] != "mcp" || cfg.DefaultLabels["team"] != "platform" {
+		t.Errorf("expected DefaultLabels to be parsed, got %v", cfg.DefaultLabels)
+	}
+	if !cfg.IncludeMetadata {
+		t.Error("expected IncludeMetadata to be true")
+	}
+	if !cfg.AllowSensitiveOutput {
+		t.Error("expected AllowSensitiveOutput to be true")
+	}
+	if !cfg.RequireConfirmation {
+		t.Error("expected RequireConfirmation to be true")
+	}
+	if cfg.MaxOutputBytes != 2048 {
+		t.Errorf("expected MaxOutputBytes 2048, got %d", cfg.MaxOutputBytes)
+	}
 }
 
 func TestGetEnv(t *testing.T) {
@@ -170,3 +234,140 @@ func TestGetDurationEnv(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadConfig_Account(t *testing.T) {
+	os.Setenv("GCLOUD_ACCOUNT", "ops@example.com")
+	defer os.Unsetenv("GCLOUD_ACCOUNT")
+
+	if got := LoadConfig().Account; got != "ops@example.com" {
+		t.Errorf("expected Account from GCLOUD_ACCOUNT, got %q", got)
+	}
+}
+
+func TestLoadConfig_Configuration(t *testing.T) {
+	defer func() {
+		os.Unsetenv("GCLOUD_CONFIGURATION")
+		os.Unsetenv("CLOUDSDK_ACTIVE_CONFIG_NAME")
+	}()
+
+	os.Setenv("CLOUDSDK_ACTIVE_CONFIG_NAME", "dev")
+	if got := LoadConfig().Configuration; got != "dev" {
+		t.Errorf("expected Configuration from CLOUDSDK_ACTIVE_CONFIG_NAME, got %q", got)
+	}
+
+	os.Setenv("GCLOUD_CONFIGURATION", "prod")
+	if got := LoadConfig().Configuration; got != "prod" {
+		t.Errorf("expected GCLOUD_CONFIGURATION to take precedence, got %q", got)
+	}
+}
+
+func TestLoadConfig_ServiceLocations(t *testing.T) {
+	cfg := LoadConfig()
+	if cfg.RunRegion != "" || cfg.FunctionsRegion != "" || cfg.ComputeZone != "" {
+		t.Errorf("expected no service locations by default, got %q %q %q", cfg.RunRegion, cfg.FunctionsRegion, cfg.ComputeZone)
+	}
+
+	t.Setenv("GCLOUD_RUN_REGION", "europe-west1")
+	t.Setenv("GCLOUD_FUNCTIONS_REGION", "us-east1")
+	t.Setenv("GCLOUD_COMPUTE_ZONE", "asia-northeast1-b")
+	cfg = LoadConfig()
+	if cfg.RunRegion != "europe-west1" {
+		t.Errorf("expected RunRegion 'europe-west1', got %q", cfg.RunRegion)
+	}
+	if cfg.FunctionsRegion != "us-east1" {
+		t.Errorf("expected FunctionsRegion 'us-east1', got %q", cfg.FunctionsRegion)
+	}
+	if cfg.ComputeZone != "asia-northeast1-b" {
+		t.Errorf("expected ComputeZone 'asia-northeast1-b', got %q", cfg.ComputeZone)
+	}
+}
+
+func TestGetIntEnv(t *testing.T) {
+	tests := []struct {
+		name     string
+		envValue string
+		want     int
+	}{
+		{name: "parses value", envValue: "4096", want: 4096},
+		{name: "zero disables", envValue: "0", want: 0},
+		{name: "negative uses default", envValue: "-1", want: 100},
+		{name: "invalid uses default", envValue: "lots", want: 100},
+		{name: "unset uses default", envValue: "", want: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.envValue != "" {
+				os.Setenv("TEST_INT", tt.envValue)
+				defer os.Unsetenv("TEST_INT")
+			} else {
+				os.Unsetenv("TEST_INT")
+			}
+
+			if got := getIntEnv("TEST_INT", 100); got != tt.want {
+				t.Errorf("getIntEnv(%q) = %d, want %d", tt.envValue, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetMapEnv(t *testing.T) {
+	os.Setenv("TEST_MAP_1", " created-by = mcp ,,=orphan,env=prod")
+	defer os.Unsetenv("TEST_MAP_1")
+
+	got := getMapEnv("TEST_MAP_1")
+	if len(got) != 2 {
+		t.Fatalf("expected 2 entries, got %v", got)
+	}
+	if got["created-by"] != "mcp" {
+		t.Errorf("expected created-by=mcp, got %q", got["created-by"])
+	}
+	if got["env"] != "prod" {
+		t.Errorf("expected env=prod, got %q", got["env"])
+	}
+
+	os.Unsetenv("TEST_MAP_2")
+	if got := getMapEnv("TEST_MAP_2"); got != nil {
+		t.Errorf("expected nil for unset variable, got %v", got)
+	}
+}
+
+func TestLookupGCloud(t *testing.T) {
+	dir := t.TempDir()
+	gcloud := filepath.Join(dir, "gcloud")
+	if err := os.WriteFile(gcloud, []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatalf("failed to write fake gcloud: %v", err)
+	}
+
+	t.Run("path", func(t *testing.T) {
+		got, err := LookupGCloud(gcloud)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != gcloud {
+			t.Errorf("LookupGCloud() = %q, want %q", got, gcloud)
+		}
+	})
+
+	t.Run("command in PATH", func(t *testing.T) {
+		t.Setenv("PATH", dir)
+		got, err := LookupGCloud("gcloud")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != gcloud {
+			t.Errorf("LookupGCloud() = %q, want %q", got, gcloud)
+		}
+	})
+
+	t.Run("absent", func(t *testing.T) {
+		missing := filepath.Join(dir, "missing", "gcloud")
+		_, err := LookupGCloud(missing)
+		if err == nil {
+			t.Fatal("expected an error for a missing binary")
+		}
+		if !strings.Contains(err.Error(), missing) || !strings.Contains(err.Error(), "GCLOUD_PATH") {
+			t.Errorf("expected the path and GCLOUD_PATH in the error, got %v", err)
+		}
+	})
+}
@@ -2,12 +2,13 @@
 package executor
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"os/exec"
+	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"gcloud-go-mcp/internal/config"
 )
@@ -23,24 +24,77 @@ type Result struct {
 	// Stderr contains the raw standard error.
 	Stderr string
 
-	// ExitCode contains the command exit code.
+	// ExitCode contains the command exit code, or -1 when the command failed
+	// without exiting normally.
 	ExitCode int
+
+	// Command is the argv that was executed, starting with the gcloud binary.
+	Command []string
+
+	// Project is the project the command ran against, if any.
+	Project string
+
+	// Region is the region the command ran against, if any.
+	Region string
+
+	// Duration is how long the command took to run.
+	Duration time.Duration
 }
 
 // Executor handles gcloud command execution.
 type Executor struct {
 	config *config.Config
+	runner CommandRunner
+
+	mu       sync.RWMutex
+	defaults Defaults
+}
+
+// Defaults are the project and account of gcloud's configuration, resolved
+// once so that commands can pass them explicitly instead of having gcloud
+// read its configuration on every call.
+type Defaults struct {
+	Project string
+	Account string
+}
+
+// SetDefaults makes commands use d when no project or account is configured.
+// Commands built before the call keep the previous defaults.
+func (e *Executor) SetDefaults(d Defaults) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.defaults = d
+}
+
+// SetDefaultProject replaces the default project, keeping the default account.
+func (e *Executor) SetDefaultProject(project string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.defaults.Project = project
+}
+
+// Defaults returns the defaults set by SetDefaults.
+func (e *Executor) Defaults() Defaults {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.defaults
 }
 
-// New creates a new gcloud executor.
+// New creates a new gcloud executor that runs commands as child processes.
 func New(cfg *config.Config) *Executor {
-	return &Executor{config: cfg}
+	return NewWithRunner(cfg, ProcessRunner{})
+}
+
+// NewWithRunner creates a new gcloud executor that runs commands with runner.
+func NewWithRunner(cfg *config.Config, runner CommandRunner) *Executor {
+	return &Executor{config: cfg, runner: runner}
 }
 
 // CommandBuilder provides a fluent interface for building gcloud commands.
 type CommandBuilder struct {
 	executor   *Executor
 	components []string
+	positional []string
 	flags      map[string]string
 	arrayFlags map[string][]string
 	boolFlags  []string
@@ -48,11 +102,79 @@ type CommandBuilder struct {
 	region     string
 	zone       string
 	format     string
+	stdin      []byte
+	env        []string
+	jsonInputs []jsonInput
+	secrets    []string
+
+	configuration string
+	account       string
+
+	// defaultProject and defaultAccount are the resolved Defaults the
+	// builder was started with, if any.
+	defaultProject string
+	defaultAccount string
+}
+
+// jsonInput is a value passed to a command as a JSON file.
+type jsonInput struct {
+	flag  string
+	value any
+}
+
+type configurationKey struct{}
+
+// WithConfiguration returns a context that makes commands executed with it
+// use the named gcloud configuration instead of the configured default.
+func WithConfiguration(ctx context.Context, name string) context.Context {
+	if name == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, configurationKey{}, name)
+}
+
+// ConfigurationFromContext returns the configuration set by WithConfiguration,
+// or "" when the command uses the server's configuration.
+func ConfigurationFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(configurationKey{}).(string)
+	return name
+}
+
+type accountKey struct{}
+
+// WithAccount returns a context that makes commands executed with it run as
+// the given account instead of the configured default.
+func WithAccount(ctx context.Context, account string) context.Context {
+	if account == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, accountKey{}, account)
+}
+
+// accountFromContext returns the account set by WithAccount.
+func accountFromContext(ctx context.Context) string {
+	account, _ := ctx.Value(accountKey{}).(string)
+	return account
+}
+
+type observerKey struct{}
+
+// WithObserver returns a context that makes commands executed with it call
+// observe with their result once they finish, whether or not they failed.
+// observe may be called concurrently by commands run in parallel.
+func WithObserver(ctx context.Context, observe func(*Result)) context.Context {
+	return context.WithValue(ctx, observerKey{}, observe)
+}
+
+// observerFromContext returns the function set by WithObserver.
+func observerFromContext(ctx context.Context) func(*Result) {
+	observe, _ := ctx.Value(observerKey{}).(func(*Result))
+	return observe
 }
 
 // Command starts building a new gcloud command.
 func (e *Executor) Command(components ...string) *CommandBuilder {
-	return &CommandBuilder{
+	b := &CommandBuilder{
 		executor:   e,
 		components: components,
 		flags:      make(map[string]string),
@@ -61,17 +183,37 @@ func (e *Executor) Command(components ...string) *CommandBuilder {
 		region:     e.config.Region,
 		zone:       e.config.Zone,
 		format:     "json",
+
+		configuration: e.config.Configuration,
+		account:       e.config.Account,
 	}
+	defaults := e.Defaults()
+	if b.project == "" {
+		b.project = defaults.Project
+		b.defaultProject = defaults.Project
+	}
+	if b.account == "" {
+		b.account = defaults.Account
+		b.defaultAccount = defaults.Account
+	}
+	return b
 }
 
 // WithProject sets the project for this command.
 func (b *CommandBuilder) WithProject(project string) *CommandBuilder {
 	if project != "" {
 		b.project = project
+		b.defaultProject = ""
 	}
 	return b
 }
 
+// WithoutProject omits the --project flag, even when a default project is configured.
+func (b *CommandBuilder) WithoutProject() *CommandBuilder {
+	b.project = ""
+	return b
+}
+
 // WithRegion sets the region for this command.
 func (b *CommandBuilder) WithRegion(region string) *CommandBuilder {
 	if region != "" {
@@ -88,6 +230,15 @@ func (b *CommandBuilder) WithZone(zone string) *CommandBuilder {
 	return b
 }
 
+// WithPositional appends a positional argument after the command components,
+// for arguments that are only known once the command has been started.
+func (b *CommandBuilder) WithPositional(arg string) *CommandBuilder {
+	if arg != "" {
+		b.positional = append(b.positional, arg)
+	}
+	return b
+}
+
 // WithFlag adds a flag with a value.
 func (b *CommandBuilder) WithFlag(name, value string) *CommandBuilder {
 	if value != "" {
@@ -96,6 +247,24 @@ func (b *CommandBuilder) WithFlag(name, value string) *CommandBuilder {
 	return b
 }
 
+// WithFlagAllowEmpty adds a flag even when value is empty, for flags where an
+// empty value clears a setting (e.g., --push-endpoint=).
+func (b *CommandBuilder) WithFlagAllowEmpty(name, value string) *CommandBuilder {
+	b.flags[name] = value
+	return b
+}
+
+// WithSecretFlag adds a flag whose value must not be reported, such as a
+// password. The value is passed to gcloud but replaced with [REDACTED] in the
+// command recorded on the Result and in errors.
+func (b *CommandBuilder) WithSecretFlag(name, value string) *CommandBuilder {
+	if value != "" {
+		b.flags[name] = value
+		b.secrets = append(b.secrets, name)
+	}
+	return b
+}
+
 // WithArrayFlag adds a flag that can be specified multiple times.
 func (b *CommandBuilder) WithArrayFlag(name, value string) *CommandBuilder {
 	if value != "" {
@@ -116,6 +285,28 @@ func (b *CommandBuilder) WithFormat(format string) *CommandBuilder {
 	return b
 }
 
+// WithStdin sets data to write to the command's standard input, for flags
+// such as --data-file=- that read from it.
+func (b *CommandBuilder) WithStdin(data []byte) *CommandBuilder {
+	b.stdin = data
+	return b
+}
+
+// WithEnv sets an environment variable for the gcloud process, on top of the
+// environment inherited from the server (e.g., CLOUDSDK_CORE_PROJECT).
+func (b *CommandBuilder) WithEnv(key, value string) *CommandBuilder {
+	b.env = append(b.env, key+"="+value)
+	return b
+}
+
+// WithJSONInput passes value, marshaled to JSON, in a temporary file named by
+// --flag (e.g., --policy-from-file or --flags-file). The file is written when
+// the command is executed and removed once it finishes.
+func (b *CommandBuilder) WithJSONInput(flag string, value any) *CommandBuilder {
+	b.jsonInputs = append(b.jsonInputs, jsonInput{flag: flag, value: value})
+	return b
+}
+
 // WithTextFormat sets text output format (disables JSON parsing).
 func (b *CommandBuilder) WithTextFormat() *CommandBuilder {
 	b.format = ""
@@ -124,8 +315,9 @@ func (b *CommandBuilder) WithTextFormat() *CommandBuilder {
 
 // Build constructs the full command arguments.
 func (b *CommandBuilder) Build() []string {
-	args := make([]string, 0, len(b.components)+len(b.flags)*2+len(b.boolFlags)+4)
+	args := make([]string, 0, len(b.components)+len(b.positional)+len(b.flags)*2+len(b.boolFlags)+4)
 	args = append(args, b.components...)
+	args = append(args, b.positional...)
 
 	// Add flags
 	for name, value := range b.flags {
@@ -154,39 +346,68 @@ func (b *CommandBuilder) Build() []string {
 		args = append(args, fmt.Sprintf("--format=%s", b.format))
 	}
 
+	// Add named configuration if set
+	if b.configuration != "" {
+		args = append(args, fmt.Sprintf("--configuration=%s", b.configuration))
+	}
+
+	// Add account if set
+	if b.account != "" {
+		args = append(args, fmt.Sprintf("--account=%s", b.account))
+	}
+
 	return args
 }
 
 // Execute runs the command and returns the result.
 func (b *CommandBuilder) Execute(ctx context.Context) (*Result, error) {
+	if name := ConfigurationFromContext(ctx); name != "" && name != b.configuration {
+		b.configuration = name
+		// The resolved defaults belong to the server's configuration, so
+		// let gcloud read the named configuration's own settings instead.
+		if b.defaultProject != "" && b.project == b.defaultProject {
+			b.project = ""
+		}
+		if b.defaultAccount != "" && b.account == b.defaultAccount {
+			b.account = ""
+		}
+	}
+	if account := accountFromContext(ctx); account != "" {
+		b.account = account
+	}
+	cleanup, err := b.writeJSONInputs()
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
 	args := b.Build()
 
 	ctx, cancel := context.WithTimeout(ctx, b.executor.config.CommandTimeout)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, b.executor.config.GCloudPath, args...)
-
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	err := cmd.Run()
-
-	result := &Result{
-		Stdout: stdout.String(),
-		Stderr: stderr.String(),
+	start := time.Now()
+	result, err := b.executor.runner.Run(ctx, b.executor.config.GCloudPath, args, b.stdin, b.environ())
+	if result == nil {
+		result = &Result{}
+	}
+	result.Command = append([]string{b.executor.config.GCloudPath}, b.redact(args)...)
+	result.Project = b.project
+	result.Region = b.flags["region"]
+	result.Duration = time.Since(start)
+	if err != nil && result.ExitCode == 0 {
+		result.ExitCode = -1
+	}
+	if observe := observerFromContext(ctx); observe != nil {
+		observe(result)
 	}
 
 	if err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			result.ExitCode = exitErr.ExitCode()
-		}
-		return result, fmt.Errorf("gcloud command failed: %w\nstderr: %s", err, stderr.String())
+		return result, &CommandError{Err: err, Command: result.Command, ExitCode: result.ExitCode}
 	}
 
 	// Parse JSON if format was JSON and output is not empty
-	if b.format == "json" && stdout.Len() > 0 {
-		trimmed := strings.TrimSpace(stdout.String())
+	if b.format == "json" && result.Stdout != "" {
+		trimmed := strings.TrimSpace(result.Stdout)
 		if trimmed != "" {
 			result.JSON = json.RawMessage(trimmed)
 		}
@@ -195,6 +416,71 @@ func (b *CommandBuilder) Execute(ctx context.Context) (*Result, error) {
 	return result, nil
 }
 
+// environ returns the environment entries for the gcloud process. Prompts are
+// always disabled, since no one can answer them.
+func (b *CommandBuilder) environ() []string {
+	for _, entry := range b.env {
+		if strings.HasPrefix(entry, "CLOUDSDK_CORE_DISABLE_PROMPTS=") {
+			return b.env
+		}
+	}
+	return append([]string{"CLOUDSDK_CORE_DISABLE_PROMPTS=1"}, b.env...)
+}
+
+// redact returns a copy of args with the values of secret flags replaced.
+func (b *CommandBuilder) redact(args []string) []string {
+	if len(b.secrets) == 0 {
+		return args
+	}
+	redacted := make([]string, len(args))
+	for i, arg := range args {
+		redacted[i] = arg
+		for _, name := range b.secrets {
+			if strings.HasPrefix(arg, "--"+name+"=") {
+				redacted[i] = "--" + name + "=[REDACTED]"
+			}
+		}
+	}
+	return redacted
+}
+
+// writeJSONInputs writes each value given to WithJSONInput to a temporary
+// file, sets its flag to the file's path, and returns a function that
+// removes the files.
+func (b *CommandBuilder) writeJSONInputs() (func(), error) {
+	var paths []string
+	cleanup := func() {
+		for _, path := range paths {
+			os.Remove(path)
+		}
+	}
+
+	for _, input := range b.jsonInputs {
+		data, err := json.Marshal(input.value)
+		if err != nil {
+			cleanup()
+			return nil, fmt.Errorf("failed to encode --%s: %w", input.flag, err)
+		}
+		f, err := os.CreateTemp("", "gcloud-mcp-*.json")
+		if err != nil {
+			cleanup()
+			return nil, fmt.Errorf("failed to create --%s file: %w", input.flag, err)
+		}
+		paths = append(paths, f.Name())
+
+		_, err = f.Write(data)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			cleanup()
+			return nil, fmt.Errorf("failed to write --%s file: %w", input.flag, err)
+		}
+		b.flags[input.flag] = f.Name()
+	}
+	return cleanup, nil
+}
+
 // ExecuteWithRegion runs the command with a region flag (for regional resources).
 func (b *CommandBuilder) ExecuteWithRegion(ctx context.Context) (*Result, error) {
 	if b.region != "" {
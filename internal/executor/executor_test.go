@@ -1,8 +1,12 @@
 package executor
 
 import (
+	"context"
+	"errors"
 	"reflect"
+	"slices"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -149,6 +153,17 @@ func TestWithFlag_Empty(t *testing.T) {
 	}
 }
 
+func TestWithFlagAllowEmpty(t *testing.T) {
+	exec := New(newTestConfig())
+	args := exec.Command("pubsub", "subscriptions", "update", "orders-sub").
+		WithFlagAllowEmpty("push-endpoint", "").
+		Build()
+
+	if !slices.Contains(args, "--push-endpoint=") {
+		t.Errorf("expected --push-endpoint= in %v", args)
+	}
+}
+
 func TestWithArrayFlag(t *testing.T) {
 	exec := New(newTestConfig())
 	builder := exec.Command("run", "deploy").
@@ -232,6 +247,16 @@ func TestGetZone(t *testing.T) {
 	}
 }
 
+func TestWithPositional_Empty(t *testing.T) {
+	exec := New(newTestConfig())
+	builder := exec.Command("logging", "read").
+		WithPositional("")
+
+	if len(builder.positional) != 0 {
+		t.Error("expected empty positional to not be added")
+	}
+}
+
 func TestBuild_Basic(t *testing.T) {
 	cfg := &config.Config{
 		Project:        "",
@@ -350,6 +375,26 @@ func TestBuild_WithArrayFlags(t *testing.T) {
 	}
 }
 
+func TestBuild_WithPositional(t *testing.T) {
+	exec := New(newTestConfig())
+	args := exec.Command("logging", "read").
+		WithFlag("limit", "10").
+		WithPositional("severity>=ERROR").
+		WithBoolFlag("quiet").
+		WithPositional("second").
+		Build()
+
+	want := []string{"logging", "read", "severity>=ERROR", "second"}
+	if !reflect.DeepEqual(args[:4], want) {
+		t.Errorf("expected positionals after components and before flags, got %v", args)
+	}
+	for _, arg := range args[4:] {
+		if arg == "severity>=ERROR" || arg == "second" {
+			t.Errorf("positional repeated among flags: %v", args)
+		}
+	}
+}
+
 func TestBuild_WithFormat(t *testing.T) {
 	cfg := &config.Config{
 		Project:        "",
@@ -475,3 +520,256 @@ func TestBuild_FlagsOrder(t *testing.T) {
 		}
 	}
 }
+
+func TestExecute_RecordsMetadata(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.GCloudPath = "echo"
+	exec := New(cfg)
+
+	result, err := exec.Command("compute", "regions", "list").
+		WithFlag("region", "europe-west1").
+		WithTextFormat().
+		Execute(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"echo", "compute", "regions", "list", "--region=europe-west1", "--project=default-project"}
+	if !reflect.DeepEqual(result.Command, want) {
+		t.Errorf("expected command %v, got %v", want, result.Command)
+	}
+	if result.Project != "default-project" {
+		t.Errorf("expected project 'default-project', got %q", result.Project)
+	}
+	if result.Region != "europe-west1" {
+		t.Errorf("expected region 'europe-west1', got %q", result.Region)
+	}
+	if result.Duration <= 0 {
+		t.Errorf("expected positive duration, got %v", result.Duration)
+	}
+}
+
+func TestExecute_WithStdin(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.GCloudPath = "sh"
+	exec := New(cfg)
+
+	// sh -c cat echoes stdin; the remaining flags only set positional parameters
+	result, err := exec.Command("-c", "cat").
+		WithStdin([]byte("secret-value")).
+		WithTextFormat().
+		Execute(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Stdout != "secret-value" {
+		t.Errorf("expected stdin to be echoed, got %q", result.Stdout)
+	}
+}
+
+func TestExecute_TimeoutIsDeadlineExceeded(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.GCloudPath = "sleep"
+	cfg.Project = ""
+	cfg.CommandTimeout = 100 * time.Millisecond
+	exec := New(cfg)
+
+	_, err := exec.Command("5").WithTextFormat().Execute(context.Background())
+	if err == nil {
+		t.Fatal("expected timeout error")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestWithoutProject(t *testing.T) {
+	exec := New(newTestConfig())
+	args := exec.Command("config", "set", "project", "p1").
+		WithoutProject().
+		WithTextFormat().
+		Build()
+
+	want := []string{"config", "set", "project", "p1"}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("expected %v, got %v", want, args)
+	}
+}
+
+func TestBuild_WithConfiguration(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Configuration = "dev"
+	args := New(cfg).Command("run", "services", "list").Build()
+
+	if args[len(args)-1] != "--configuration=dev" {
+		t.Errorf("expected --configuration=dev, got %v", args)
+	}
+
+	args = New(newTestConfig()).Command("run", "services", "list").Build()
+	for _, arg := range args {
+		if arg == "--configuration=" || arg == "--configuration=dev" {
+			t.Errorf("expected no --configuration flag, got %v", args)
+		}
+	}
+}
+
+func TestExecute_ConfigurationPrecedence(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured string
+		perCall    string
+		want       string
+	}{
+		{name: "configured default", configured: "dev", want: "--configuration=dev"},
+		{name: "per-call override", configured: "dev", perCall: "prod", want: "--configuration=prod"},
+		{name: "per-call without default", perCall: "prod", want: "--configuration=prod"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newTestConfig()
+			cfg.GCloudPath = "echo"
+			cfg.Configuration = tt.configured
+
+			ctx := WithConfiguration(context.Background(), tt.perCall)
+			result, err := New(cfg).Command("config", "list").WithTextFormat().Execute(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := result.Command[len(result.Command)-1]; got != tt.want {
+				t.Errorf("expected %s, got %v", tt.want, result.Command)
+			}
+		})
+	}
+}
+
+func TestBuild_WithAccount(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Account = "ops@example.com"
+	args := New(cfg).Command("run", "services", "list").Build()
+
+	if args[len(args)-1] != "--account=ops@example.com" {
+		t.Errorf("expected --account=ops@example.com, got %v", args)
+	}
+
+	args = New(newTestConfig()).Command("run", "services", "list").Build()
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "--account") {
+			t.Errorf("expected no --account flag, got %v", args)
+		}
+	}
+}
+
+func TestExecute_AccountPrecedence(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured string
+		perCall    string
+		want       string
+	}{
+		{name: "configured default", configured: "ops@example.com", want: "--account=ops@example.com"},
+		{name: "per-call override", configured: "ops@example.com", perCall: "deploy@example.com", want: "--account=deploy@example.com"},
+		{name: "per-call without default", perCall: "deploy@example.com", want: "--account=deploy@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newTestConfig()
+			cfg.GCloudPath = "echo"
+			cfg.Account = tt.configured
+
+			ctx := WithAccount(context.Background(), tt.perCall)
+			result, err := New(cfg).Command("config", "list").WithTextFormat().Execute(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := result.Command[len(result.Command)-1]; got != tt.want {
+				t.Errorf("expected %s, got %v", tt.want, result.Command)
+			}
+		})
+	}
+}
+
+func TestExecute_FailureIsCommandError(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.GCloudPath = "false"
+
+	_, err := New(cfg).Command("run", "services", "list").Execute(context.Background())
+	var cmdErr *CommandError
+	if !errors.As(err, &cmdErr) {
+		t.Fatalf("expected a *CommandError, got %T: %v", err, err)
+	}
+	want := []string{"false", "run", "services", "list", "--project=default-project", "--format=json"}
+	if !reflect.DeepEqual(cmdErr.Command, want) {
+		t.Errorf("expected command %v, got %v", want, cmdErr.Command)
+	}
+}
+
+func TestExecute_RedactsSecretFlags(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.GCloudPath = "echo"
+
+	result, err := New(cfg).Command("sql", "users", "create", "app").
+		WithSecretFlag("password", "hunter2").
+		WithTextFormat().
+		Execute(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(result.Stdout, "--password=hunter2") {
+		t.Errorf("expected the password to be passed to the command, got %q", result.Stdout)
+	}
+	want := []string{"echo", "sql", "users", "create", "app", "--password=[REDACTED]", "--project=default-project"}
+	if !reflect.DeepEqual(result.Command, want) {
+		t.Errorf("expected command %v, got %v", want, result.Command)
+	}
+
+	cfg.GCloudPath = "false"
+	_, err = New(cfg).Command("sql", "users", "create", "app").
+		WithSecretFlag("password", "hunter2").
+		Execute(context.Background())
+	var cmdErr *CommandError
+	if !errors.As(err, &cmdErr) {
+		t.Fatalf("expected a *CommandError, got %T: %v", err, err)
+	}
+	if strings.Contains(cmdErr.CommandLine(), "hunter2") {
+		t.Errorf("expected the password to be redacted, got %q", cmdErr.CommandLine())
+	}
+}
+
+func TestExecute_DisablesPrompts(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.GCloudPath = "sh"
+
+	result, err := New(cfg).Command("-c", "echo $CLOUDSDK_CORE_DISABLE_PROMPTS").
+		WithTextFormat().
+		Execute(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.TrimSpace(result.Stdout) != "1" {
+		t.Errorf("expected prompts to be disabled, got %q", result.Stdout)
+	}
+}
+
+func TestCommand_Defaults(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.Project = ""
+	exec := New(cfg)
+	exec.SetDefaults(Defaults{Project: "resolved-project", Account: "dev@example.com"})
+
+	cmd := exec.Command("run", "services", "list")
+	if cmd.GetProject() != "resolved-project" {
+		t.Errorf("expected the resolved project, got %q", cmd.GetProject())
+	}
+	args := cmd.Build()
+	for _, want := range []string{"--project=resolved-project", "--account=dev@example.com"} {
+		if !slices.Contains(args, want) {
+			t.Errorf("expected %s in %v", want, args)
+		}
+	}
+
+	if args := exec.Command("run", "services", "list").WithoutProject().Build(); slices.Contains(args, "--project=resolved-project") {
+		t.Errorf("expected WithoutProject to drop the resolved project, got %v", args)
+	}
+}
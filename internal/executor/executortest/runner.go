@@ -0,0 +1,102 @@
+// Package executortest provides a fake executor.CommandRunner for testing
+// tools without a gcloud binary.
+package executortest
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"gcloud-go-mcp/internal/executor"
+)
+
+// Runner is a fake executor.CommandRunner that records every invocation and
+// returns canned output.
+type Runner struct {
+	// Handler, if set, produces the result of each call.
+	Handler func(args []string) (*executor.Result, error)
+
+	// Stdout and Stderr are returned when Handler is nil.
+	Stdout string
+	Stderr string
+
+	// Err, when set and Handler is nil, makes every call fail the way a
+	// non-zero gcloud exit does.
+	Err error
+
+	mu     sync.Mutex
+	calls  [][]string
+	stdins [][]byte
+	envs   [][]string
+}
+
+// Run records args, stdin and env and returns the configured result.
+func (r *Runner) Run(ctx context.Context, name string, args []string, stdin []byte, env []string) (*executor.Result, error) {
+	r.mu.Lock()
+	r.calls = append(r.calls, slices.Clone(args))
+	r.stdins = append(r.stdins, slices.Clone(stdin))
+	r.envs = append(r.envs, slices.Clone(env))
+	r.mu.Unlock()
+
+	if r.Handler != nil {
+		return r.Handler(args)
+	}
+	result := &executor.Result{Stdout: r.Stdout, Stderr: r.Stderr}
+	if r.Err != nil {
+		result.ExitCode = 1
+		return result, fmt.Errorf("gcloud command failed: %w\nstderr: %s", r.Err, executor.CleanStderr(r.Stderr))
+	}
+	return result, nil
+}
+
+// Calls returns the arguments of every invocation, in order.
+func (r *Runner) Calls() [][]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.calls)
+}
+
+// LastArgs returns the arguments of the most recent invocation, or nil if
+// there was none.
+func (r *Runner) LastArgs() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.calls) == 0 {
+		return nil
+	}
+	return r.calls[len(r.calls)-1]
+}
+
+// LastStdin returns the standard input of the most recent invocation, or nil
+// if it had none.
+func (r *Runner) LastStdin() []byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.stdins) == 0 {
+		return nil
+	}
+	return r.stdins[len(r.stdins)-1]
+}
+
+// LastEnv returns the environment entries added to the most recent
+// invocation, or nil if there were none.
+func (r *Runner) LastEnv() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.envs) == 0 {
+		return nil
+	}
+	return r.envs[len(r.envs)-1]
+}
+
+// Missing returns the entries of want that are not present in args.
+func Missing(args []string, want ...string) []string {
+	var missing []string
+	for _, w := range want {
+		if !slices.Contains(args, w) {
+			missing = append(missing, w)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,109 @@
+package executortest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"gcloud-go-mcp/internal/config"
+	"gcloud-go-mcp/internal/executor"
+)
+
+func TestRunner_RecordsArgs(t *testing.T) {
+	runner := &Runner{Stdout: `[{"name": "a"}]`}
+	exec := executor.NewWithRunner(&config.Config{
+		Project:        "p1",
+		GCloudPath:     "gcloud",
+		CommandTimeout: time.Minute,
+	}, runner)
+
+	result, err := exec.Command("run", "services", "list").Execute(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"run", "services", "list", "--project=p1", "--format=json"}
+	if !slices.Equal(runner.LastArgs(), want) {
+		t.Errorf("expected args %v, got %v", want, runner.LastArgs())
+	}
+	if string(result.JSON) != `[{"name": "a"}]` {
+		t.Errorf("expected canned JSON to be parsed, got %s", result.JSON)
+	}
+	if len(runner.Calls()) != 1 {
+		t.Errorf("expected 1 call, got %d", len(runner.Calls()))
+	}
+}
+
+func TestRunner_RecordsStdin(t *testing.T) {
+	runner := &Runner{}
+	exec := executor.NewWithRunner(&config.Config{GCloudPath: "gcloud", CommandTimeout: time.Minute}, runner)
+
+	if _, err := exec.Command("secrets", "versions", "add", "s").WithStdin([]byte("data")).Execute(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := string(runner.LastStdin()); got != "data" {
+		t.Errorf("expected stdin %q, got %q", "data", got)
+	}
+
+	if _, err := exec.Command("secrets", "list").Execute(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if runner.LastStdin() != nil {
+		t.Errorf("expected no stdin, got %q", runner.LastStdin())
+	}
+}
+
+func TestRunner_Err(t *testing.T) {
+	runner := &Runner{Err: errors.New("exit status 1"), Stderr: "ERROR: NOT_FOUND"}
+	exec := executor.NewWithRunner(&config.Config{GCloudPath: "gcloud", CommandTimeout: time.Minute}, runner)
+
+	result, err := exec.Command("projects", "describe", "missing").Execute(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "NOT_FOUND") {
+		t.Fatalf("expected injected error, got %v", err)
+	}
+	if result.ExitCode != 1 || result.Stderr != "ERROR: NOT_FOUND" {
+		t.Errorf("unexpected result %+v", result)
+	}
+}
+
+func TestRunner_Handler(t *testing.T) {
+	runner := &Runner{
+		Handler: func(args []string) (*executor.Result, error) {
+			return &executor.Result{Stdout: strings.Join(args[:2], " ")}, nil
+		},
+	}
+	exec := executor.NewWithRunner(&config.Config{GCloudPath: "gcloud", CommandTimeout: time.Minute}, runner)
+
+	result, err := exec.Command("config", "get-value").WithTextFormat().Execute(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Stdout != "config get-value" {
+		t.Errorf("expected handler output, got %q", result.Stdout)
+	}
+}
+
+func TestMissing(t *testing.T) {
+	got := Missing([]string{"a", "b"}, "a", "c")
+	if !slices.Equal(got, []string{"c"}) {
+		t.Errorf("expected [c], got %v", got)
+	}
+}
+
+func TestRunner_RecordsEnv(t *testing.T) {
+	runner := &Runner{}
+	exec := executor.NewWithRunner(&config.Config{
+		GCloudPath:     "gcloud",
+		CommandTimeout: time.Minute,
+	}, runner)
+
+	if _, err := exec.Command("info").WithEnv("CLOUDSDK_CORE_DISABLE_PROMPTS", "1").Execute(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"CLOUDSDK_CORE_DISABLE_PROMPTS=1"}; !slices.Equal(runner.LastEnv(), want) {
+		t.Errorf("expected env %v, got %v", want, runner.LastEnv())
+	}
+}
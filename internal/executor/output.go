@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"strings"
 )
 
 // ParseJSON parses the JSON result into a target struct.
@@ -27,11 +29,64 @@ func (r *Result) ToJSONString() string {
 	return r.Stdout
 }
 
+// AsStructured returns the output as a json.RawMessage when it is valid JSON,
+// including text-format output that happens to be JSON, and otherwise returns
+// the raw stdout string.
+func (r *Result) AsStructured() any {
+	if r.JSON != nil && json.Valid(r.JSON) {
+		return r.JSON
+	}
+	trimmed := strings.TrimSpace(r.Stdout)
+	if trimmed != "" && (trimmed[0] == '{' || trimmed[0] == '[') && json.Valid([]byte(trimmed)) {
+		return json.RawMessage(trimmed)
+	}
+	return r.Stdout
+}
+
 // IsEmpty returns true if the result has no meaningful output.
 func (r *Result) IsEmpty() bool {
 	return r.JSON == nil && r.Stdout == ""
 }
 
+// CommandError is the error returned by Execute when a command fails. It
+// records the command that was run so that it can be reported alongside the
+// error, and unwraps to the runner's error.
+type CommandError struct {
+	Err     error
+	Command []string
+
+	// ExitCode is gcloud's exit code, or -1 when it did not exit normally
+	// (e.g., it could not be started or was killed after a timeout).
+	ExitCode int
+}
+
+func (e *CommandError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// CommandLine returns the command as a line that can be pasted into a shell,
+// quoting arguments that contain spaces or shell metacharacters.
+func (e *CommandError) CommandLine() string {
+	quoted := make([]string, len(e.Command))
+	for i, arg := range e.Command {
+		quoted[i] = shellQuote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// shellQuote single-quotes arg when a POSIX shell would otherwise split or
+// expand it.
+func shellQuote(arg string) string {
+	if arg != "" && !strings.ContainsAny(arg, " \t\n'\"\\$`!*?[]{}()<>|&;#~") {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
 // ErrorResponse creates a standardized error response.
 type ErrorResponse struct {
 	Error   string `json:"error"`
@@ -49,3 +104,59 @@ func FormatError(err error, command string, stderr string) string {
 	b, _ := json.MarshalIndent(resp, "", "  ")
 	return string(b)
 }
+
+var (
+	// ansiPattern matches ANSI escape sequences: CSI sequences such as
+	// colors and cursor movement, OSC sequences, and two-byte escapes.
+	ansiPattern = regexp.MustCompile(`\x1b(?:\[[0-?]*[ -/]*[@-~]|\][^\x07\x1b]*(?:\x07|\x1b\\)|[@-Z\\-_])`)
+
+	// progressPattern matches gcloud progress tracker lines, such as
+	// "⠛ Creating Revision...", "Waiting for operation [x] to
+	// complete....done." and a bare "Done.", optionally led by a spinner or
+	// check mark.
+	progressPattern = regexp.MustCompile(`(?i)^[^\w\[]*(?:.*?\.{3,}[.\s]*(?:done\.?)?|done\.?)$`)
+
+	// wordPattern matches lines with any letters or digits, as opposed to
+	// lines of only spinner glyphs or dots.
+	wordPattern = regexp.MustCompile(`[\p{L}\p{N}]`)
+)
+
+// CleanStderr strips ANSI escape codes and progress tracker output (spinners,
+// "...done." lines and their carriage-return redraws) from gcloud's stderr,
+// leaving the lines worth showing, such as "Created [...]" messages,
+// warnings and errors.
+func CleanStderr(stderr string) string {
+	stderr = ansiPattern.ReplaceAllString(stderr, "")
+
+	var lines []string
+	for _, line := range strings.Split(stderr, "\n") {
+		line = lastRedraw(line)
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || isProgressLine(trimmed) {
+			continue
+		}
+		lines = append(lines, strings.TrimRight(line, " \t"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// lastRedraw returns what a terminal would show for line: the text after the
+// last carriage return that is followed by any text.
+func lastRedraw(line string) string {
+	parts := strings.Split(line, "\r")
+	for i := len(parts) - 1; i >= 0; i-- {
+		if strings.TrimSpace(parts[i]) != "" {
+			return parts[i]
+		}
+	}
+	return ""
+}
+
+// isProgressLine reports whether a trimmed stderr line is progress tracker
+// output. Errors and warnings are never progress.
+func isProgressLine(line string) bool {
+	if strings.HasPrefix(line, "ERROR:") || strings.HasPrefix(line, "WARNING:") {
+		return false
+	}
+	return !wordPattern.MatchString(line) || progressPattern.MatchString(line)
+}
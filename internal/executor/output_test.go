@@ -2,6 +2,7 @@ package executor
 
 import (
 	"encoding/json"
+	"errors"
 	"strings"
 	"testing"
 )
@@ -174,6 +175,23 @@ func TestFormatError(t *testing.T) {
 	}
 }
 
+func TestCommandError_CommandLine(t *testing.T) {
+	err := &CommandError{
+		Err: errors.New("exit status 1"),
+		Command: []string{
+			"gcloud", "logging", "read", `severity>=ERROR AND resource.type="cloud_run_revision"`,
+			"--format=json", "--message=it's done", "--empty=",
+		},
+	}
+	want := `gcloud logging read 'severity>=ERROR AND resource.type="cloud_run_revision"' --format=json '--message=it'\''s done' --empty=`
+	if got := err.CommandLine(); got != want {
+		t.Errorf("CommandLine() = %s, want %s", got, want)
+	}
+	if got := shellQuote(""); got != "''" {
+		t.Errorf("shellQuote(\"\") = %s, want ''", got)
+	}
+}
+
 func TestFormatError_EmptyFields(t *testing.T) {
 	err := &testError{msg: "error"}
 	output := FormatError(err, "", "")
@@ -229,3 +247,114 @@ func TestToJSONString_NestedJSON(t *testing.T) {
 		t.Error("expected 'svc1' in output")
 	}
 }
+
+func TestAsStructured(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   *Result
+		wantJSON string
+		wantText string
+	}{
+		{
+			name:     "parsed JSON output",
+			result:   &Result{JSON: json.RawMessage(`{"name":"a"}`), Stdout: `{"name":"a"}`},
+			wantJSON: `{"name":"a"}`,
+		},
+		{
+			name:     "text output that is JSON",
+			result:   &Result{Stdout: "\n[{\"id\": 12345678901234567890}]\n"},
+			wantJSON: `[{"id": 12345678901234567890}]`,
+		},
+		{
+			name:     "plain text",
+			result:   &Result{Stdout: "NAME     STATUS\nvm-1     RUNNING\n"},
+			wantText: "NAME     STATUS\nvm-1     RUNNING\n",
+		},
+		{
+			name:     "truncated JSON",
+			result:   &Result{Stdout: `{"name": "a"`},
+			wantText: `{"name": "a"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.result.AsStructured()
+			if tt.wantJSON != "" {
+				raw, ok := got.(json.RawMessage)
+				if !ok {
+					t.Fatalf("expected json.RawMessage, got %T", got)
+				}
+				if string(raw) != tt.wantJSON {
+					t.Errorf("expected %s, got %s", tt.wantJSON, raw)
+				}
+				return
+			}
+			text, ok := got.(string)
+			if !ok {
+				t.Fatalf("expected string, got %T", got)
+			}
+			if text != tt.wantText {
+				t.Errorf("expected %q, got %q", tt.wantText, text)
+			}
+		})
+	}
+}
+
+func TestCleanStderr(t *testing.T) {
+	tests := []struct {
+		name   string
+		stderr string
+		want   string
+	}{
+		{
+			name:   "empty",
+			stderr: "",
+			want:   "",
+		},
+		{
+			name: "cloud run deploy",
+			stderr: "Deploying container to Cloud Run service [hello] in project [p] region [us-central1]\n" +
+				"\x1b[1;33m⠛\x1b[0m Deploying...\r\x1b[1;32m✓\x1b[0m Deploying... Done.\n" +
+				"  \x1b[32m✓\x1b[0m Creating Revision...\n" +
+				"  ✓ Routing traffic...\n" +
+				"Done.\n" +
+				"Service [\x1b[1mhello\x1b[m] revision [\x1b[1mhello-00002-abc\x1b[m] has been deployed and is serving \x1b[1m100\x1b[m percent of traffic.\n" +
+				"Service URL: \x1b[1mhttps://hello-abc-uc.a.run.app\x1b[m\n",
+			want: "Deploying container to Cloud Run service [hello] in project [p] region [us-central1]\n" +
+				"Service [hello] revision [hello-00002-abc] has been deployed and is serving 100 percent of traffic.\n" +
+				"Service URL: https://hello-abc-uc.a.run.app",
+		},
+		{
+			name: "operation wait with dots",
+			stderr: "Waiting for operation [projects/p/locations/us-central1/operations/abc] to complete...\r" +
+				"Waiting for operation [projects/p/locations/us-central1/operations/abc] to complete......done.\n" +
+				".....\n" +
+				"Created [https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a/instances/web-1].\n",
+			want: "Created [https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a/instances/web-1].",
+		},
+		{
+			name: "errors and warnings are kept",
+			stderr: "⠹ Updating service...\n" +
+				"\x1b[1;31mERROR:\x1b[0m (gcloud.run.deploy) Revision 'hello-00003' is not ready...\n" +
+				"WARNING: Retrying...\n" +
+				"  Creating Revision...failed\n",
+			want: "ERROR: (gcloud.run.deploy) Revision 'hello-00003' is not ready...\n" +
+				"WARNING: Retrying...\n" +
+				"  Creating Revision...failed",
+		},
+		{
+			name:   "spinner only",
+			stderr: "⠋\r⠙\r⠹\r",
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CleanStderr(tt.stderr); got != tt.want {
+				t.Errorf("CleanStderr() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package executor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+	"time"
+)
+
+// DefaultGracePeriod is how long a cancelled gcloud process has to exit after
+// SIGTERM before it is killed.
+const DefaultGracePeriod = 5 * time.Second
+
+// CommandRunner runs a fully built gcloud invocation, writing stdin (which may
+// be nil) to its standard input and adding env (KEY=value entries, which may be
+// nil) to the environment inherited from the server. Implementations fill in Stdout, Stderr and
+// ExitCode of the returned Result; the CommandBuilder adds the remaining
+// metadata and parses JSON output.
+type CommandRunner interface {
+	Run(ctx context.Context, name string, args []string, stdin []byte, env []string) (*Result, error)
+}
+
+// ProcessRunner runs commands as child processes.
+type ProcessRunner struct {
+	// GracePeriod overrides DefaultGracePeriod when positive.
+	GracePeriod time.Duration
+}
+
+// Run executes name with args and waits for it to finish. When ctx is done the
+// process is sent SIGTERM so gcloud can clean up, and is killed if it is
+// still running after the grace period.
+func (r ProcessRunner) Run(ctx context.Context, name string, args []string, stdin []byte, env []string) (*Result, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(syscall.SIGTERM)
+	}
+	cmd.WaitDelay = DefaultGracePeriod
+	if r.GracePeriod > 0 {
+		cmd.WaitDelay = r.GracePeriod
+	}
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+
+	result := &Result{
+		Stdout: stdout.String(),
+		Stderr: stderr.String(),
+	}
+
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			result.ExitCode = exitErr.ExitCode()
+		}
+		if ctx.Err() == context.DeadlineExceeded {
+			return result, fmt.Errorf("gcloud command timed out: %w\nstderr: %s", context.DeadlineExceeded, CleanStderr(stderr.String()))
+		}
+		return result, fmt.Errorf("gcloud command failed: %w\nstderr: %s", err, CleanStderr(stderr.String()))
+	}
+
+	return result, nil
+}
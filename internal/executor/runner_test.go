@@ -0,0 +1,184 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeScript writes an executable shell script to a temporary directory.
+func writeScript(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "fake-gcloud")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+body), 0o755); err != nil {
+		t.Fatalf("failed to write script: %v", err)
+	}
+	return path
+}
+
+func TestProcessRunner_SendsSIGTERMOnCancel(t *testing.T) {
+	marker := filepath.Join(t.TempDir(), "terminated")
+	script := writeScript(t, `trap 'echo cleaned-up > "`+marker+`"; exit 143' TERM
+sleep 10 >/dev/null 2>&1 &
+wait
+`)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := ProcessRunner{GracePeriod: 5 * time.Second}.Run(ctx, script, nil, nil, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("expected the process to exit on SIGTERM, took %v", elapsed)
+	}
+	if _, err := os.Stat(marker); err != nil {
+		t.Errorf("expected the SIGTERM trap to run: %v", err)
+	}
+}
+
+func TestProcessRunner_KillsAfterGracePeriod(t *testing.T) {
+	script := writeScript(t, `trap '' TERM
+sleep 10 >/dev/null 2>&1 &
+while :; do wait; done
+`)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := ProcessRunner{GracePeriod: 300 * time.Millisecond}.Run(ctx, script, nil, nil, nil)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("expected the process to be killed after the grace period, took %v", elapsed)
+	}
+}
+
+func TestExecute_WithEnvReachesProcess(t *testing.T) {
+	t.Setenv("GCLOUD_MCP_INHERITED", "from-parent")
+	script := writeScript(t, `echo "$CLOUDSDK_CORE_PROJECT $GCLOUD_MCP_INHERITED"
+`)
+
+	cfg := newTestConfig()
+	cfg.GCloudPath = script
+	result, err := New(cfg).Command("info").
+		WithTextFormat().
+		WithEnv("CLOUDSDK_CORE_PROJECT", "env-project").
+		Execute(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := strings.TrimSpace(result.Stdout); got != "env-project from-parent" {
+		t.Errorf("expected the added and inherited variables, got %q", got)
+	}
+}
+
+func TestExecute_WithJSONInput(t *testing.T) {
+	// The script prints the file named by --spec, which only exists while
+	// the command runs.
+	script := writeScript(t, `for arg in "$@"; do
+  case "$arg" in --spec=*) cat "${arg#--spec=}" ;; esac
+done
+`)
+	cfg := newTestConfig()
+	cfg.GCloudPath = script
+
+	spec := map[string]any{
+		"displayName": "prod, \"critical\"",
+		"thresholds":  []any{0.5, 1.0},
+		"labels":      map[string]any{"env": "prod"},
+	}
+	result, err := New(cfg).Command("alpha", "create").
+		WithJSONInput("spec", spec).
+		Execute(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got map[string]any
+	if err := result.ParseJSON(&got); err != nil {
+		t.Fatalf("file does not contain valid JSON: %v (%q)", err, result.Stdout)
+	}
+	if !reflect.DeepEqual(got, spec) {
+		t.Errorf("file contains %v, want %v", got, spec)
+	}
+
+	var path string
+	for _, arg := range result.Command {
+		if p, ok := strings.CutPrefix(arg, "--spec="); ok {
+			path = p
+		}
+	}
+	if path == "" {
+		t.Fatalf("expected --spec in %v", result.Command)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected %s to be removed after the command, got %v", path, err)
+	}
+}
+
+func TestExecute_WithJSONInputUnencodable(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.GCloudPath = "true"
+
+	_, err := New(cfg).Command("alpha", "create").
+		WithJSONInput("spec", map[string]any{"bad": make(chan int)}).
+		Execute(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "--spec") {
+		t.Errorf("expected an encoding error naming the flag, got %v", err)
+	}
+}
+
+func TestProcessRunner_ErrorCleansStderr(t *testing.T) {
+	script := writeScript(t, `printf '\033[1;33m⠛\033[0m Deploying...\r' >&2
+printf '\033[1;31mERROR:\033[0m (gcloud.run.deploy) permission denied\n' >&2
+exit 1
+`)
+
+	result, err := ProcessRunner{}.Run(context.Background(), script, nil, nil, nil)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if want := "stderr: ERROR: (gcloud.run.deploy) permission denied"; !strings.HasSuffix(err.Error(), want) {
+		t.Errorf("expected error ending in %q, got %q", want, err.Error())
+	}
+	if !strings.Contains(result.Stderr, "\x1b[1;31m") {
+		t.Errorf("expected Result.Stderr to keep the raw output, got %q", result.Stderr)
+	}
+}
+
+func TestExecute_ObserverAndExitCode(t *testing.T) {
+	cfg := newTestConfig()
+	cfg.GCloudPath = writeScript(t, "exit 3\n")
+
+	var observed []int
+	ctx := WithObserver(context.Background(), func(result *Result) {
+		observed = append(observed, result.ExitCode)
+	})
+	_, err := New(cfg).Command("info").Execute(ctx)
+
+	var cmdErr *CommandError
+	if !errors.As(err, &cmdErr) || cmdErr.ExitCode != 3 {
+		t.Fatalf("expected a CommandError with exit code 3, got %v", err)
+	}
+
+	cfg.GCloudPath = filepath.Join(t.TempDir(), "missing-gcloud")
+	_, err = New(cfg).Command("info").Execute(ctx)
+	if !errors.As(err, &cmdErr) || cmdErr.ExitCode != -1 {
+		t.Fatalf("expected a CommandError with exit code -1, got %v", err)
+	}
+
+	if !reflect.DeepEqual(observed, []int{3, -1}) {
+		t.Errorf("expected the observer to see exit codes [3 -1], got %v", observed)
+	}
+}
@@ -0,0 +1,171 @@
+// Package apigateway provides MCP tools for API Gateway.
+package apigateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"gcloud-go-mcp/internal/executor"
+	"gcloud-go-mcp/internal/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RegisterTools registers all API Gateway tools with the MCP server.
+func RegisterTools(server *mcp.Server, base *services.BaseService) {
+	// List gateways
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_apigateway_gateways_list",
+			Description: "List API gateways",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"location": map[string]any{
+						"type":        "string",
+						"description": "Region of the gateways (e.g., us-central1); all locations when empty",
+					},
+					"filter": map[string]any{
+						"type":        "string",
+						"description": "Filter expression",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd := base.Executor.Command("api-gateway", "gateways", "list").
+				WithFlag("location", services.GetOptionalString(args, "location", "")).
+				WithFlag("filter", services.GetOptionalString(args, "filter", "")).
+				WithProject(services.GetOptionalString(args, "project", ""))
+			if err := services.ApplyListOutput(cmd, args, csvColumns["gateways"]); err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Describe gateway
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_apigateway_gateways_describe",
+			Description: "Get details of an API gateway, including its API config and default hostname",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"gateway"},
+				"properties": map[string]any{
+					"gateway": map[string]any{
+						"type":        "string",
+						"description": "Gateway ID",
+					},
+					"location": map[string]any{
+						"type":        "string",
+						"description": "Region of the gateway (defaults to GCLOUD_REGION)",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"soft_not_found": services.SoftNotFoundProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := gatewayDescribeCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.DescribeError(args, result, err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// List APIs
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_apigateway_apis_list",
+			Description: "List APIs managed by API Gateway. APIs are global, so no location is needed",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"filter": map[string]any{
+						"type":        "string",
+						"description": "Filter expression",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd := base.Executor.Command("api-gateway", "apis", "list").
+				WithFlag("filter", services.GetOptionalString(args, "filter", "")).
+				WithProject(services.GetOptionalString(args, "project", ""))
+			if err := services.ApplyListOutput(cmd, args, csvColumns["apis"]); err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+}
+
+func parseArgs(req *mcp.CallToolRequest) map[string]any {
+	var args map[string]any
+	if req.Params.Arguments != nil {
+		_ = json.Unmarshal(req.Params.Arguments, &args)
+	}
+	if args == nil {
+		args = make(map[string]any)
+	}
+	return args
+}
+
+// csvColumns are the default column projections used when a list tool is
+// called with output=csv, keyed by resource.
+var csvColumns = map[string]string{
+	"gateways": "name.basename(),displayName,state,defaultHostname,apiConfig.basename()",
+	"apis":     "name.basename(),displayName,state,managedService",
+}
+
+// gatewayDescribeCommand builds the `api-gateway gateways describe` command.
+// The location falls back to the default region.
+func gatewayDescribeCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	gateway, err := services.GetRequiredString(args, "gateway")
+	if err != nil {
+		return nil, err
+	}
+	location := services.GetOptionalString(args, "location", base.Config.Region)
+	if location == "" {
+		return nil, fmt.Errorf("location is required (pass location or set GCLOUD_REGION)")
+	}
+
+	return base.Executor.Command("api-gateway", "gateways", "describe", gateway).
+		WithFlag("location", location).
+		WithProject(services.GetOptionalString(args, "project", "")), nil
+}
@@ -0,0 +1,108 @@
+package apigateway
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"gcloud-go-mcp/internal/executor/executortest"
+	"gcloud-go-mcp/internal/services"
+	"gcloud-go-mcp/internal/services/servicetest"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func newTestBase() *services.BaseService {
+	return services.NewBaseService(servicetest.NewConfig())
+}
+
+func TestRegisterTools(t *testing.T) {
+	server := mcp.NewServer(
+		&mcp.Implementation{
+			Name:    "test-server",
+			Version: "0.0.1",
+		},
+		&mcp.ServerOptions{},
+	)
+
+	// Should not panic
+	RegisterTools(server, newTestBase())
+}
+
+func TestGatewayDescribeCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		region  string
+		want    []string
+		wantErr string
+	}{
+		{
+			name:   "explicit location",
+			args:   map[string]any{"gateway": "orders-gw", "location": "europe-west1"},
+			region: "us-central1",
+			want:   []string{"--location=europe-west1", "--project=test-project"},
+		},
+		{
+			name:   "location defaults to region",
+			args:   map[string]any{"gateway": "orders-gw"},
+			region: "us-central1",
+			want:   []string{"--location=us-central1"},
+		},
+		{
+			name:    "no location",
+			args:    map[string]any{"gateway": "orders-gw"},
+			wantErr: "location is required",
+		},
+		{
+			name:    "missing gateway",
+			args:    map[string]any{"location": "us-central1"},
+			region:  "us-central1",
+			wantErr: "gateway",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := newTestBase()
+			base.Config.Region = tt.region
+			cmd, err := gatewayDescribeCommand(base, tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if !slices.Equal(args[:4], []string{"api-gateway", "gateways", "describe", "orders-gw"}) {
+				t.Errorf("unexpected command %v", args)
+			}
+			if missing := executortest.Missing(args, tt.want...); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, args)
+			}
+		})
+	}
+}
+
+func TestToolCall_GatewaysList(t *testing.T) {
+	runner := &executortest.Runner{Stdout: "[]"}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_apigateway_gateways_list", map[string]any{
+		"location": "us-central1",
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+	if missing := executortest.Missing(runner.LastArgs(), "--location=us-central1"); len(missing) > 0 {
+		t.Errorf("missing %v in %v", missing, runner.LastArgs())
+	}
+
+	servicetest.CallTool(t, RegisterTools, runner, "gcp_apigateway_apis_list", map[string]any{})
+	for _, arg := range runner.LastArgs() {
+		if strings.HasPrefix(arg, "--location") {
+			t.Errorf("apis are global, got %s", arg)
+		}
+	}
+}
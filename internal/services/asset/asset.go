@@ -0,0 +1,119 @@
+// Package asset provides MCP tools for Cloud Asset Inventory.
+package asset
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gcloud-go-mcp/internal/executor"
+	"gcloud-go-mcp/internal/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RegisterTools registers all Cloud Asset Inventory tools with the MCP server.
+func RegisterTools(server *mcp.Server, base *services.BaseService) {
+	// Search all resources
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_asset_search_all_resources",
+			Description: "Search for resources of any type across a project, folder, or organization by asset type and query",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"scope": map[string]any{
+						"type":        "string",
+						"description": "Scope to search: projects/{PROJECT_ID}, projects/{PROJECT_NUMBER}, folders/{FOLDER_NUMBER}, or organizations/{ORGANIZATION_NUMBER} (defaults to the project)",
+					},
+					"query": map[string]any{
+						"type":        "string",
+						"description": "Search query (e.g., 'name:prod', 'location:us-central1', 'labels.env:prod', 'state:RUNNING')",
+					},
+					"asset_types": map[string]any{
+						"type":        "array",
+						"items":       map[string]any{"type": "string"},
+						"description": "Asset types to search (e.g., ['compute.googleapis.com/Instance', 'storage.googleapis.com/Bucket']); all types when empty",
+					},
+					"order_by": map[string]any{
+						"type":        "string",
+						"description": "Comma-separated fields to sort by (e.g., 'location, displayName desc')",
+					},
+					"limit": map[string]any{
+						"type":        "integer",
+						"description": "Maximum number of results",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID, used as the scope when scope is not set",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := searchAllResourcesCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			if err := services.ApplyListOutput(cmd, args, csvColumns["resources"]); err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+}
+
+func parseArgs(req *mcp.CallToolRequest) map[string]any {
+	var args map[string]any
+	if req.Params.Arguments != nil {
+		_ = json.Unmarshal(req.Params.Arguments, &args)
+	}
+	if args == nil {
+		args = make(map[string]any)
+	}
+	return args
+}
+
+// csvColumns are the default column projections used when a list tool is
+// called with output=csv, keyed by resource.
+var csvColumns = map[string]string{
+	"resources": "name,assetType,location,displayName,state",
+}
+
+// searchAllResourcesCommand builds the `asset search-all-resources` command.
+// Without a scope, the project argument (or the default project) is searched.
+func searchAllResourcesCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	project := services.GetOptionalString(args, "project", "")
+	scope := services.GetOptionalString(args, "scope", "")
+	if scope == "" {
+		p := project
+		if p == "" {
+			p = base.Config.Project
+		}
+		if p == "" {
+			return nil, fmt.Errorf("scope is required when no project is given (pass scope or project, or set GCLOUD_PROJECT)")
+		}
+		scope = "projects/" + p
+	}
+
+	cmd := base.Executor.Command("asset", "search-all-resources").
+		WithFlag("scope", scope).
+		WithFlag("query", services.GetOptionalString(args, "query", "")).
+		WithFlag("asset-types", strings.Join(services.GetOptionalStringArray(args, "asset_types"), ",")).
+		WithFlag("order-by", services.GetOptionalString(args, "order_by", "")).
+		WithProject(project)
+
+	if limit := services.GetOptionalInt(args, "limit", 0); limit > 0 {
+		cmd.WithFlag("limit", strconv.Itoa(limit))
+	}
+	return cmd, nil
+}
@@ -0,0 +1,131 @@
+package asset
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"gcloud-go-mcp/internal/executor/executortest"
+	"gcloud-go-mcp/internal/services"
+	"gcloud-go-mcp/internal/services/servicetest"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func newTestBase() *services.BaseService {
+	return services.NewBaseService(servicetest.NewConfig())
+}
+
+func TestRegisterTools(t *testing.T) {
+	server := mcp.NewServer(
+		&mcp.Implementation{
+			Name:    "test-server",
+			Version: "0.0.1",
+		},
+		&mcp.ServerOptions{},
+	)
+
+	// Should not panic
+	RegisterTools(server, newTestBase())
+}
+
+func TestSearchAllResourcesCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		project string
+		want    []string
+		notWant []string
+		wantErr string
+	}{
+		{
+			name:    "defaults to project scope",
+			args:    map[string]any{},
+			project: "test-project",
+			want:    []string{"--scope=projects/test-project"},
+			notWant: []string{"--query=", "--asset-types=", "--order-by=", "--limit="},
+		},
+		{
+			name:    "project argument sets scope",
+			args:    map[string]any{"project": "other-project"},
+			project: "test-project",
+			want:    []string{"--scope=projects/other-project", "--project=other-project"},
+		},
+		{
+			name: "explicit scope with query and asset types",
+			args: map[string]any{
+				"scope":       "organizations/123",
+				"query":       "labels.env:prod AND location:us-central1",
+				"asset_types": []any{"compute.googleapis.com/Instance", "storage.googleapis.com/Bucket"},
+				"order_by":    "location",
+				"limit":       float64(50),
+			},
+			project: "test-project",
+			want: []string{
+				"--scope=organizations/123",
+				"--query=labels.env:prod AND location:us-central1",
+				"--asset-types=compute.googleapis.com/Instance,storage.googleapis.com/Bucket",
+				"--order-by=location",
+				"--limit=50",
+			},
+		},
+		{
+			name:    "empty asset types",
+			args:    map[string]any{"asset_types": []any{}},
+			project: "test-project",
+			notWant: []string{"--asset-types="},
+		},
+		{
+			name:    "no scope or project",
+			args:    map[string]any{"query": "name:prod"},
+			wantErr: "scope is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := newTestBase()
+			base.Config.Project = tt.project
+			cmd, err := searchAllResourcesCommand(base, tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if !slices.Equal(args[:2], []string{"asset", "search-all-resources"}) {
+				t.Errorf("unexpected command %v", args)
+			}
+			if missing := executortest.Missing(args, tt.want...); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, args)
+			}
+			for _, prefix := range tt.notWant {
+				for _, arg := range args {
+					if strings.HasPrefix(arg, prefix) {
+						t.Errorf("unexpected %s in %v", arg, args)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestToolCall_SearchAllResourcesCSV(t *testing.T) {
+	runner := &executortest.Runner{}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_asset_search_all_resources", map[string]any{
+		"asset_types": []any{"run.googleapis.com/Service"},
+		"output":      "csv",
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+
+	want := "--format=csv(" + csvColumns["resources"] + ")"
+	if missing := executortest.Missing(runner.LastArgs(), "--asset-types=run.googleapis.com/Service", want); len(missing) > 0 {
+		t.Errorf("missing %v in %v", missing, runner.LastArgs())
+	}
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"gcloud-go-mcp/internal/executor"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// redactedValue replaces sensitive argument values in audit entries.
+const redactedValue = "[REDACTED]"
+
+// sensitiveArgs lists argument names whose values are never written to the audit log.
+var sensitiveArgs = map[string]bool{
+	"data":          true,
+	"password":      true,
+	"token":         true,
+	"access_token":  true,
+	"refresh_token": true,
+	"id_token":      true,
+	"auth_token":    true,
+	"private_key":   true,
+	"plaintext":     true,
+}
+
+// AuditEntry is a single JSON line written to the audit log. ExitCode is the
+// exit code of the last gcloud command the tool ran, and is omitted when it
+// ran none. Unconfirmed is set when the confirmation gate refused to run a
+// destructive tool, which is not a success.
+type AuditEntry struct {
+	Tool        string         `json:"tool"`
+	Timestamp   time.Time      `json:"timestamp"`
+	DurationMS  int64          `json:"duration_ms"`
+	Success     bool           `json:"success"`
+	ExitCode    *int           `json:"exit_code,omitempty"`
+	Unconfirmed bool           `json:"unconfirmed,omitempty"`
+	Args        map[string]any `json:"args,omitempty"`
+}
+
+// auditRecord collects what a tool call did for its audit entry.
+type auditRecord struct {
+	mu          sync.Mutex
+	exitCode    *int
+	unconfirmed bool
+}
+
+type auditRecordKey struct{}
+
+// markUnconfirmed records in the audit entry of the call ctx belongs to that
+// the tool was not run because it was not confirmed.
+func markUnconfirmed(ctx context.Context) {
+	if record, ok := ctx.Value(auditRecordKey{}).(*auditRecord); ok {
+		record.mu.Lock()
+		record.unconfirmed = true
+		record.mu.Unlock()
+	}
+}
+
+// AuditLogger records tool invocations as JSON lines.
+type AuditLogger struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewAuditLogger creates an audit logger that writes to w.
+func NewAuditLogger(w io.Writer) *AuditLogger {
+	return &AuditLogger{w: w}
+}
+
+// OpenAuditLog opens (or creates) the audit log file at path for appending.
+func OpenAuditLog(path string) (*AuditLogger, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	return NewAuditLogger(f), nil
+}
+
+// Log writes an entry to the audit log.
+func (a *AuditLogger) Log(entry AuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	_, err = a.w.Write(append(line, '\n'))
+	return err
+}
+
+// Wrap decorates a tool handler so that every invocation is recorded.
+// A nil logger returns the handler unchanged.
+func (a *AuditLogger) Wrap(name string, handler mcp.ToolHandler) mcp.ToolHandler {
+	if a == nil {
+		return handler
+	}
+	return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		record := &auditRecord{}
+		ctx = context.WithValue(ctx, auditRecordKey{}, record)
+		ctx = executor.WithObserver(ctx, func(result *executor.Result) {
+			record.mu.Lock()
+			defer record.mu.Unlock()
+			code := result.ExitCode
+			record.exitCode = &code
+		})
+
+		start := time.Now()
+		result, err := handler(ctx, req)
+
+		record.mu.Lock()
+		entry := AuditEntry{
+			Tool:        name,
+			Timestamp:   start.UTC(),
+			DurationMS:  time.Since(start).Milliseconds(),
+			Success:     err == nil && result != nil && !result.IsError && !record.unconfirmed,
+			ExitCode:    record.exitCode,
+			Unconfirmed: record.unconfirmed,
+		}
+		record.mu.Unlock()
+		if req != nil && req.Params != nil && req.Params.Arguments != nil {
+			var args map[string]any
+			if json.Unmarshal(req.Params.Arguments, &args) == nil {
+				entry.Args = RedactArgs(args)
+			}
+		}
+		_ = a.Log(entry)
+
+		return result, err
+	}
+}
+
+// RedactArgs returns a copy of args with sensitive values replaced.
+func RedactArgs(args map[string]any) map[string]any {
+	if args == nil {
+		return nil
+	}
+	redacted := make(map[string]any, len(args))
+	for k, v := range args {
+		if isSensitiveArg(k) {
+			redacted[k] = redactedValue
+		} else if nested, ok := v.(map[string]any); ok {
+			redacted[k] = RedactArgs(nested)
+		} else {
+			redacted[k] = v
+		}
+	}
+	return redacted
+}
+
+// isSensitiveArg reports whether an argument name holds a secret value.
+func isSensitiveArg(key string) bool {
+	key = strings.ToLower(key)
+	return sensitiveArgs[key] || strings.HasSuffix(key, "_token") || strings.HasSuffix(key, "_password")
+}
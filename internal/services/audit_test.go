@@ -0,0 +1,265 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"gcloud-go-mcp/internal/config"
+	"gcloud-go-mcp/internal/executor"
+	"gcloud-go-mcp/internal/executor/executortest"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func newTestRequest(name string, args map[string]any) *mcp.CallToolRequest {
+	raw, _ := json.Marshal(args)
+	return &mcp.CallToolRequest{
+		Params: &mcp.CallToolParamsRaw{
+			Name:      name,
+			Arguments: raw,
+		},
+	}
+}
+
+func TestAuditLogger_Wrap_RedactsSecrets(t *testing.T) {
+	var buf bytes.Buffer
+	audit := NewAuditLogger(&buf)
+
+	handler := audit.Wrap("gcp_secrets_versions_add", func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return ToolResult("ok"), nil
+	})
+
+	req := newTestRequest("gcp_secrets_versions_add", map[string]any{
+		"secret_id":    "db-password",
+		"data":         "hunter2",
+		"access_token": "ya29.token",
+	})
+	if _, err := handler(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	line := buf.String()
+	if strings.Contains(line, "hunter2") || strings.Contains(line, "ya29.token") {
+		t.Fatalf("audit entry leaked a secret: %s", line)
+	}
+
+	var entry AuditEntry
+	if err := json.Unmarshal([]byte(line), &entry); err != nil {
+		t.Fatalf("audit entry is not valid JSON: %v", err)
+	}
+	if entry.Tool != "gcp_secrets_versions_add" {
+		t.Errorf("expected tool name, got %q", entry.Tool)
+	}
+	if !entry.Success {
+		t.Error("expected success to be true")
+	}
+	if entry.Timestamp.IsZero() {
+		t.Error("expected timestamp to be set")
+	}
+	if entry.Args["secret_id"] != "db-password" {
+		t.Errorf("expected secret_id to be kept, got %v", entry.Args["secret_id"])
+	}
+	if entry.Args["data"] != redactedValue {
+		t.Errorf("expected data to be redacted, got %v", entry.Args["data"])
+	}
+	if entry.Args["access_token"] != redactedValue {
+		t.Errorf("expected access_token to be redacted, got %v", entry.Args["access_token"])
+	}
+}
+
+func TestAuditLogger_Wrap_RecordsFailure(t *testing.T) {
+	var buf bytes.Buffer
+	audit := NewAuditLogger(&buf)
+
+	handler := audit.Wrap("gcp_run_services_describe", func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return ToolError(&testError{msg: "boom"}), nil
+	})
+
+	if _, err := handler(context.Background(), newTestRequest("gcp_run_services_describe", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var entry AuditEntry
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("audit entry is not valid JSON: %v", err)
+	}
+	if entry.Success {
+		t.Error("expected success to be false for an error result")
+	}
+}
+
+func TestAuditLogger_Wrap_RecordsExitCode(t *testing.T) {
+	tests := []struct {
+		name        string
+		handler     func(base *BaseService) mcp.ToolHandler
+		wantSuccess bool
+		wantCode    *int
+	}{
+		{
+			name: "command succeeded",
+			handler: func(base *BaseService) mcp.ToolHandler {
+				return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+					result, err := base.Executor.Command("run", "services", "list").Execute(ctx)
+					if err != nil {
+						return ToolError(err), nil
+					}
+					return base.CommandResult(result), nil
+				}
+			},
+			wantSuccess: true,
+			wantCode:    new(int),
+		},
+		{
+			name: "command failed",
+			handler: func(base *BaseService) mcp.ToolHandler {
+				return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+					if _, err := base.Executor.Command("run", "services", "describe", "api").Execute(ctx); err != nil {
+						return ToolError(err), nil
+					}
+					return ToolResult("ok"), nil
+				}
+			},
+			wantCode: func() *int { code := 2; return &code }(),
+		},
+		{
+			name: "no command",
+			handler: func(base *BaseService) mcp.ToolHandler {
+				return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+					return ToolResult("ok"), nil
+				}
+			},
+			wantSuccess: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &executortest.Runner{Handler: func(args []string) (*executor.Result, error) {
+				if slices.Contains(args, "describe") {
+					return &executor.Result{ExitCode: 2}, errors.New("gcloud command failed: exit status 2")
+				}
+				return &executor.Result{Stdout: "[]"}, nil
+			}}
+			base := newExistsTestBase(runner)
+			var buf bytes.Buffer
+			handler := NewAuditLogger(&buf).Wrap("gcp_run_services_list", tt.handler(base))
+
+			if _, err := handler(context.Background(), newTestRequest("gcp_run_services_list", nil)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var entry AuditEntry
+			if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+				t.Fatalf("audit entry is not valid JSON: %v", err)
+			}
+			if entry.Success != tt.wantSuccess {
+				t.Errorf("success = %v, want %v", entry.Success, tt.wantSuccess)
+			}
+			switch {
+			case tt.wantCode == nil && entry.ExitCode != nil:
+				t.Errorf("expected no exit code, got %d", *entry.ExitCode)
+			case tt.wantCode != nil && (entry.ExitCode == nil || *entry.ExitCode != *tt.wantCode):
+				t.Errorf("exit code = %v, want %d", entry.ExitCode, *tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestAuditLogger_Wrap_RecordsUnconfirmed(t *testing.T) {
+	var buf bytes.Buffer
+	tool := &mcp.Tool{
+		Name:        "gcp_run_services_delete",
+		Annotations: Destructive(),
+		InputSchema: map[string]any{"type": "object", "properties": map[string]any{}},
+	}
+	handler := NewAuditLogger(&buf).Wrap(tool.Name, requireConfirmation(tool, func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		t.Error("expected the handler not to run")
+		return ToolResult("ok"), nil
+	}))
+
+	if _, err := handler(context.Background(), newTestRequest(tool.Name, map[string]any{"service": "api"})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var entry AuditEntry
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("audit entry is not valid JSON: %v", err)
+	}
+	if entry.Success || !entry.Unconfirmed || entry.ExitCode != nil {
+		t.Errorf("expected an unconfirmed, unsuccessful entry without an exit code, got %+v", entry)
+	}
+}
+
+func TestAuditLogger_NilWrapIsPassthrough(t *testing.T) {
+	var audit *AuditLogger
+	called := false
+	handler := audit.Wrap("gcp_projects_list", func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		called = true
+		return ToolResult("ok"), nil
+	})
+
+	if _, err := handler(context.Background(), newTestRequest("gcp_projects_list", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !called {
+		t.Error("expected wrapped handler to be called")
+	}
+}
+
+func TestRedactArgs_Nested(t *testing.T) {
+	args := map[string]any{
+		"labels": map[string]any{
+			"env":      "prod",
+			"password": "s3cret",
+		},
+		"db_password": "s3cret",
+	}
+
+	redacted := RedactArgs(args)
+
+	if redacted["db_password"] != redactedValue {
+		t.Errorf("expected db_password to be redacted, got %v", redacted["db_password"])
+	}
+	nested := redacted["labels"].(map[string]any)
+	if nested["password"] != redactedValue {
+		t.Errorf("expected nested password to be redacted, got %v", nested["password"])
+	}
+	if nested["env"] != "prod" {
+		t.Errorf("expected env to be kept, got %v", nested["env"])
+	}
+	if args["db_password"] != "s3cret" {
+		t.Error("expected input args to be left unmodified")
+	}
+}
+
+func TestNewBaseService_OpensAuditLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	cfg := &config.Config{
+		GCloudPath:     "gcloud",
+		CommandTimeout: time.Minute,
+		AuditLogPath:   path,
+	}
+
+	base := NewBaseService(cfg)
+	if base.Audit == nil {
+		t.Fatal("expected audit logger to be configured")
+	}
+
+	if err := base.Audit.Log(AuditEntry{Tool: "gcp_projects_list", Success: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read audit log: %v", err)
+	}
+	if !strings.Contains(string(data), `"tool":"gcp_projects_list"`) {
+		t.Errorf("expected audit log to contain the entry, got %s", data)
+	}
+}
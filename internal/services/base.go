@@ -3,7 +3,11 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"strings"
 
 	"gcloud-go-mcp/internal/config"
 	"gcloud-go-mcp/internal/executor"
@@ -14,14 +18,112 @@ import (
 type BaseService struct {
 	Executor *executor.Executor
 	Config   *config.Config
+	Audit    *AuditLogger
+	Registry *Registry
 }
 
-// NewBaseService creates a new base service.
+// NewBaseService creates a new base service that runs gcloud as a child process.
 func NewBaseService(cfg *config.Config) *BaseService {
-	return &BaseService{
-		Executor: executor.New(cfg),
+	return NewBaseServiceWithRunner(cfg, executor.ProcessRunner{})
+}
+
+// NewBaseServiceWithRunner creates a new base service whose commands are run
+// by runner. Tests use it to substitute a fake for the gcloud binary.
+func NewBaseServiceWithRunner(cfg *config.Config, runner executor.CommandRunner) *BaseService {
+	base := &BaseService{
+		Executor: executor.NewWithRunner(cfg, runner),
 		Config:   cfg,
+		Registry: &Registry{},
+	}
+	if cfg.AuditLogPath != "" {
+		audit, err := OpenAuditLog(cfg.AuditLogPath)
+		if err != nil {
+			log.Printf("Audit logging disabled: %v", err)
+		} else {
+			base.Audit = audit
+		}
+	}
+	return base
+}
+
+// AddTool registers a tool with the server, applying the handler decorators
+// shared by all services, and records it in the registry.
+func (b *BaseService) AddTool(server *mcp.Server, tool *mcp.Tool, handler mcp.ToolHandler) {
+	b.Registry.add(tool)
+	handler = withCallOverrides(tool, handler)
+	if b.Config.RequireConfirmation && IsDestructive(tool) {
+		handler = requireConfirmation(tool, handler)
+	}
+	server.AddTool(tool, b.Audit.Wrap(tool.Name, handler))
+}
+
+// withCallOverrides adds configuration and gcloud_account arguments to the
+// tool's schema and wraps handler so that the commands it runs use the named
+// gcloud configuration and run as the given account. The account argument is
+// prefixed because some tools already take an account (e.g., billing).
+func withCallOverrides(tool *mcp.Tool, handler mcp.ToolHandler) mcp.ToolHandler {
+	if schema, ok := tool.InputSchema.(map[string]any); ok {
+		if properties, ok := schema["properties"].(map[string]any); ok {
+			properties["configuration"] = map[string]any{
+				"type":        "string",
+				"description": "Named gcloud configuration to run with (overrides GCLOUD_CONFIGURATION)",
+			}
+			properties["gcloud_account"] = map[string]any{
+				"type":        "string",
+				"description": "Authenticated account to run as (overrides GCLOUD_ACCOUNT)",
+			}
+		}
+	}
+
+	return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args map[string]any
+		if req.Params.Arguments != nil {
+			_ = json.Unmarshal(req.Params.Arguments, &args)
+		}
+		ctx = executor.WithConfiguration(ctx, GetOptionalString(args, "configuration", ""))
+		ctx = executor.WithAccount(ctx, GetOptionalString(args, "gcloud_account", ""))
+		return handler(ctx, req)
+	}
+}
+
+// ResolveDefaults looks up the project and account of gcloud's configuration
+// with a single command and makes later commands pass them explicitly, so
+// gcloud does not read its configuration again on every call. Configured
+// values (GCLOUD_PROJECT, GCLOUD_ACCOUNT) still take precedence.
+func (b *BaseService) ResolveDefaults(ctx context.Context) error {
+	result, err := b.Executor.Command("config", "list").
+		WithoutProject().
+		Execute(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to resolve gcloud defaults: %w", err)
+	}
+
+	var properties struct {
+		Core struct {
+			Project string `json:"project"`
+			Account string `json:"account"`
+		} `json:"core"`
+	}
+	if len(result.JSON) > 0 {
+		if err := json.Unmarshal(result.JSON, &properties); err != nil {
+			return fmt.Errorf("failed to parse gcloud configuration: %w", err)
+		}
+	}
+
+	b.Executor.SetDefaults(executor.Defaults{
+		Project: properties.Core.Project,
+		Account: properties.Core.Account,
+	})
+	return nil
+}
+
+// RequireSensitiveOutput returns an error unless tools that return
+// credentials have been allowed in the configuration.
+func (b *BaseService) RequireSensitiveOutput(tool string) error {
+	if !b.Config.AllowSensitiveOutput {
+		return fmt.Errorf("%s returns credentials and is disabled; set GCLOUD_ALLOW_SENSITIVE_OUTPUT=true to enable it", tool)
 	}
+	return nil
 }
 
 // ToolResult creates a successful tool result with text content.
@@ -33,12 +135,19 @@ func ToolResult(text string) *mcp.CallToolResult {
 	}
 }
 
-// ToolError creates an error tool result.
+// ToolError creates an error tool result. Errors from a failed gcloud command
+// are reported as JSON that includes the command line that was run.
 func ToolError(err error) *mcp.CallToolResult {
+	text := err.Error()
+	var cmdErr *executor.CommandError
+	if errors.As(err, &cmdErr) {
+		// The runner's error already includes gcloud's stderr.
+		text = executor.FormatError(err, cmdErr.CommandLine(), "")
+	}
 	return &mcp.CallToolResult{
 		IsError: true,
 		Content: []mcp.Content{
-			&mcp.TextContent{Text: err.Error()},
+			&mcp.TextContent{Text: text},
 		},
 	}
 }
@@ -62,6 +171,46 @@ func GetRequiredString(args map[string]any, key string) (string, error) {
 	return str, nil
 }
 
+// RequireAll checks that every key is a non-empty string in args and returns
+// a single error naming all of the keys that are missing or invalid, so a
+// caller can fix them in one retry. Read the values with GetRequiredString.
+func RequireAll(args map[string]any, keys ...string) error {
+	var missing, invalid []string
+	for _, key := range keys {
+		val, ok := args[key]
+		if !ok {
+			missing = append(missing, key)
+			continue
+		}
+		str, ok := val.(string)
+		if !ok {
+			invalid = append(invalid, key)
+		} else if str == "" {
+			missing = append(missing, key)
+		}
+	}
+
+	var problems []string
+	switch len(missing) {
+	case 0:
+	case 1:
+		problems = append(problems, "missing required parameter: "+missing[0])
+	default:
+		problems = append(problems, "missing required parameters: "+strings.Join(missing, ", "))
+	}
+	switch len(invalid) {
+	case 0:
+	case 1:
+		problems = append(problems, fmt.Sprintf("parameter %s must be a string", invalid[0]))
+	default:
+		problems = append(problems, fmt.Sprintf("parameters %s must be strings", strings.Join(invalid, ", ")))
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(problems, "; "))
+}
+
 // GetOptionalString extracts an optional string parameter.
 func GetOptionalString(args map[string]any, key string, defaultVal string) string {
 	val, ok := args[key]
@@ -1,10 +1,18 @@
 package services
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
 	"testing"
 	"time"
 
 	"gcloud-go-mcp/internal/config"
+	"gcloud-go-mcp/internal/executor"
+	"gcloud-go-mcp/internal/executor/executortest"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 func TestNewBaseService(t *testing.T) {
@@ -58,6 +66,33 @@ func TestToolError(t *testing.T) {
 	}
 }
 
+func TestToolError_CommandError(t *testing.T) {
+	cmdErr := &executor.CommandError{
+		Err:     errors.New("gcloud command failed: exit status 1\nstderr: ERROR: permission denied"),
+		Command: []string{"gcloud", "run", "services", "list", "--filter=metadata.name:web app", "--project=p"},
+	}
+	result := ToolError(fmt.Errorf("deploy failed: %w", cmdErr))
+
+	var parsed executor.ErrorResponse
+	text := result.Content[0].(*mcp.TextContent).Text
+	if err := json.Unmarshal([]byte(text), &parsed); err != nil {
+		t.Fatalf("expected a JSON error, got %q", text)
+	}
+	if want := "gcloud run services list '--filter=metadata.name:web app' --project=p"; parsed.Command != want {
+		t.Errorf("command = %q, want %q", parsed.Command, want)
+	}
+	if want := "deploy failed: gcloud command failed: exit status 1\nstderr: ERROR: permission denied"; parsed.Error != want {
+		t.Errorf("error = %q, want %q", parsed.Error, want)
+	}
+}
+
+func TestToolError_PlainErrorIsText(t *testing.T) {
+	result := ToolError(errors.New("missing required parameter: name"))
+	if text := result.Content[0].(*mcp.TextContent).Text; text != "missing required parameter: name" {
+		t.Errorf("expected the plain message, got %q", text)
+	}
+}
+
 func TestGetRequiredString_Success(t *testing.T) {
 	args := map[string]any{
 		"name": "test-value",
@@ -421,3 +456,144 @@ func BenchmarkGetOptionalStringMap(b *testing.B) {
 		GetOptionalStringMap(args, "labels")
 	}
 }
+
+func TestRequireSensitiveOutput(t *testing.T) {
+	cfg := &config.Config{GCloudPath: "gcloud", CommandTimeout: time.Minute}
+	base := NewBaseService(cfg)
+
+	if err := base.RequireSensitiveOutput("gcp_tool"); err == nil {
+		t.Error("expected error when sensitive output is not allowed")
+	}
+
+	cfg.AllowSensitiveOutput = true
+	if err := base.RequireSensitiveOutput("gcp_tool"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestResolveDefaults(t *testing.T) {
+	runner := &executortest.Runner{Handler: func(args []string) (*executor.Result, error) {
+		if slices.Equal(args[:2], []string{"config", "list"}) {
+			return &executor.Result{Stdout: `{"core": {"account": "dev@example.com", "project": "resolved-project"}}`}, nil
+		}
+		return &executor.Result{Stdout: "[]"}, nil
+	}}
+	base := NewBaseServiceWithRunner(&config.Config{GCloudPath: "gcloud", CommandTimeout: time.Minute}, runner)
+	ctx := context.Background()
+
+	if err := base.ResolveDefaults(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := (executor.Defaults{Project: "resolved-project", Account: "dev@example.com"}); base.Executor.Defaults() != want {
+		t.Errorf("expected defaults %+v, got %+v", want, base.Executor.Defaults())
+	}
+
+	for range 2 {
+		if _, err := base.Executor.Command("run", "services", "list").Execute(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if missing := executortest.Missing(runner.LastArgs(), "--project=resolved-project", "--account=dev@example.com"); len(missing) > 0 {
+			t.Errorf("expected the cached defaults, missing %v in %v", missing, runner.LastArgs())
+		}
+	}
+	if calls := runner.Calls(); len(calls) != 3 {
+		t.Errorf("expected one config lookup and two commands, got %v", calls)
+	}
+
+	// An explicit project still wins over the cached one
+	if _, err := base.Executor.Command("run", "services", "list").WithProject("other").Execute(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if missing := executortest.Missing(runner.LastArgs(), "--project=other"); len(missing) > 0 {
+		t.Errorf("expected the explicit project, got %v", runner.LastArgs())
+	}
+
+	// A per-call configuration reads its own settings
+	if _, err := base.Executor.Command("run", "services", "list").Execute(executor.WithConfiguration(ctx, "staging")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, arg := range runner.LastArgs() {
+		if arg == "--project=resolved-project" || arg == "--account=dev@example.com" {
+			t.Errorf("expected no cached defaults with another configuration, got %v", runner.LastArgs())
+		}
+	}
+}
+
+func TestResolveDefaults_ConfiguredValuesWin(t *testing.T) {
+	runner := &executortest.Runner{Stdout: `{"core": {"account": "dev@example.com", "project": "resolved-project"}}`}
+	base := NewBaseServiceWithRunner(&config.Config{
+		Project:        "configured-project",
+		GCloudPath:     "gcloud",
+		CommandTimeout: time.Minute,
+	}, runner)
+	ctx := context.Background()
+
+	if err := base.ResolveDefaults(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := base.Executor.Command("run", "services", "list").Execute(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if missing := executortest.Missing(runner.LastArgs(), "--project=configured-project", "--account=dev@example.com"); len(missing) > 0 {
+		t.Errorf("missing %v in %v", missing, runner.LastArgs())
+	}
+}
+
+func TestResolveDefaults_Failure(t *testing.T) {
+	runner := &executortest.Runner{Err: errors.New("exit status 1")}
+	base := NewBaseServiceWithRunner(&config.Config{GCloudPath: "gcloud", CommandTimeout: time.Minute}, runner)
+
+	if err := base.ResolveDefaults(context.Background()); err == nil {
+		t.Fatal("expected an error")
+	}
+	if got := base.Executor.Defaults(); got != (executor.Defaults{}) {
+		t.Errorf("expected no defaults, got %+v", got)
+	}
+}
+
+func TestRequireAll(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		wantErr string
+	}{
+		{
+			name: "all present",
+			args: map[string]any{"service": "hello", "image": "gcr.io/p/hello"},
+		},
+		{
+			name:    "one missing",
+			args:    map[string]any{"service": "hello"},
+			wantErr: "missing required parameter: image",
+		},
+		{
+			name:    "several missing or empty",
+			args:    map[string]any{"image": ""},
+			wantErr: "missing required parameters: service, image",
+		},
+		{
+			name:    "missing and invalid",
+			args:    map[string]any{"service": float64(1), "region": []any{"us-central1"}},
+			wantErr: "missing required parameter: image; parameters service, region must be strings",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := []string{"service", "image"}
+			if _, ok := tt.args["region"]; ok {
+				keys = append(keys, "region")
+			}
+			err := RequireAll(tt.args, keys...)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
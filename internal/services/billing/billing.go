@@ -5,7 +5,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
+	"gcloud-go-mcp/internal/executor"
 	"gcloud-go-mcp/internal/services"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -13,28 +15,37 @@ import (
 // RegisterTools registers all Billing tools with the MCP server.
 func RegisterTools(server *mcp.Server, base *services.BaseService) {
 	// List billing accounts
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_billing_accounts_list",
 			Description: "List billing accounts",
 			InputSchema: map[string]any{
-				"type":       "object",
-				"properties": map[string]any{},
+				"type": "object",
+				"properties": map[string]any{
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
 			},
 		},
 		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-			result, err := base.Executor.Command("billing", "accounts", "list").
-				Execute(ctx)
+			args := parseArgs(req)
+
+			cmd := base.Executor.Command("billing", "accounts", "list")
 
+			if err := services.ApplyListOutput(cmd, args, csvColumns["accounts"]); err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Describe billing account
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_billing_accounts_describe",
 			Description: "Get details of a billing account",
@@ -46,6 +57,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "string",
 						"description": "Billing account ID",
 					},
+					"soft_not_found": services.SoftNotFoundProperty(),
 				},
 			},
 		},
@@ -59,15 +71,159 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			result, err := base.Executor.Command("billing", "accounts", "describe", account).
 				Execute(ctx)
 
+			if err != nil {
+				return services.DescribeError(args, result, err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Get billing account IAM policy
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_billing_accounts_get_iam_policy",
+			Description: "Get the IAM policy of a billing account",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"account"},
+				"properties": map[string]any{
+					"account": map[string]any{
+						"type":        "string",
+						"description": "Billing account ID (e.g., 0X0X0X-0X0X0X-0X0X0X)",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			account, err := services.GetRequiredString(args, "account")
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := base.Executor.Command("billing", "accounts", "get-iam-policy", strings.TrimPrefix(account, "billingAccounts/")).
+				Execute(ctx)
+
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Add billing account IAM binding
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_billing_accounts_add_iam_policy_binding",
+			Description: "Add an IAM policy binding to a billing account",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"account", "member", "role"},
+				"properties": map[string]any{
+					"account": map[string]any{
+						"type":        "string",
+						"description": "Billing account ID (e.g., 0X0X0X-0X0X0X-0X0X0X)",
+					},
+					"member": map[string]any{
+						"type":        "string",
+						"description": "Member (e.g., user:alice@example.com, group:finance@example.com)",
+					},
+					"role": map[string]any{
+						"type":        "string",
+						"description": "Role (e.g., roles/billing.user, roles/billing.viewer, roles/billing.admin)",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := accountIAMBindingCommand(base, "add-iam-policy-binding", args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Remove billing account IAM binding
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_billing_accounts_remove_iam_policy_binding",
+			Description: "Remove an IAM policy binding from a billing account",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"account", "member", "role"},
+				"properties": map[string]any{
+					"account": map[string]any{
+						"type":        "string",
+						"description": "Billing account ID (e.g., 0X0X0X-0X0X0X-0X0X0X)",
+					},
+					"member": map[string]any{
+						"type":        "string",
+						"description": "Member (e.g., user:alice@example.com, group:finance@example.com)",
+					},
+					"role": map[string]any{
+						"type":        "string",
+						"description": "Role (e.g., roles/billing.user, roles/billing.viewer, roles/billing.admin)",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := accountIAMBindingCommand(base, "remove-iam-policy-binding", args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// List projects linked to a billing account
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_billing_projects_list",
+			Description: "List the projects linked to a billing account",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"billing_account"},
+				"properties": map[string]any{
+					"billing_account": map[string]any{
+						"type":        "string",
+						"description": "Billing account ID (e.g., 0X0X0X-0X0X0X-0X0X0X)",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := projectsListCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// List budgets
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_billing_budgets_list",
 			Description: "List budgets for a billing account",
@@ -79,6 +235,8 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "string",
 						"description": "Billing account ID",
 					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
 				},
 			},
 		},
@@ -89,19 +247,23 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 				return services.ToolError(err), nil
 			}
 
-			result, err := base.Executor.Command("billing", "budgets", "list").
-				WithFlag("billing-account", billingAccount).
-				Execute(ctx)
+			cmd := base.Executor.Command("billing", "budgets", "list").
+				WithFlag("billing-account", billingAccount)
 
+			if err := services.ApplyListOutput(cmd, args, csvColumns["budgets"]); err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Create budget
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_billing_budgets_create",
 			Description: "Create a budget for a billing account",
@@ -136,45 +298,16 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 		},
 		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			args := parseArgs(req)
-			billingAccount, err := services.GetRequiredString(args, "billing_account")
-			if err != nil {
-				return services.ToolError(err), nil
-			}
-			displayName, err := services.GetRequiredString(args, "display_name")
-			if err != nil {
-				return services.ToolError(err), nil
-			}
-			budgetAmount, err := services.GetRequiredString(args, "budget_amount")
+			cmd, err := budgetCreateCommand(base, args)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
 
-			cmd := base.Executor.Command("billing", "budgets", "create").
-				WithFlag("billing-account", billingAccount).
-				WithFlag("display-name", displayName).
-				WithFlag("budget-amount", budgetAmount)
-
-			// Add threshold rules if provided
-			if thresholds, ok := args["threshold_rules"].([]any); ok {
-				for _, t := range thresholds {
-					if threshold, ok := t.(float64); ok {
-						cmd.WithArrayFlag("threshold-rule", fmt.Sprintf("percent=%g", threshold))
-					}
-				}
-			}
-
-			// Add project filter if provided
-			if projects := services.GetOptionalStringArray(args, "filter_projects"); len(projects) > 0 {
-				for _, p := range projects {
-					cmd.WithArrayFlag("filter-projects", p)
-				}
-			}
-
 			result, err := cmd.Execute(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 }
@@ -189,3 +322,85 @@ func parseArgs(req *mcp.CallToolRequest) map[string]any {
 	}
 	return args
 }
+
+// projectsListCommand builds the command listing the projects linked to a
+// billing account. The account may be given with or without the
+// billingAccounts/ prefix.
+func projectsListCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	account, err := services.GetRequiredString(args, "billing_account")
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := base.Executor.Command("billing", "projects", "list").
+		WithFlag("billing-account", strings.TrimPrefix(account, "billingAccounts/"))
+	if err := services.ApplyListOutput(cmd, args, csvColumns["projects"]); err != nil {
+		return nil, err
+	}
+	return cmd, nil
+}
+
+// budgetCreateCommand builds the `billing budgets create` command. The budget
+// is passed as a gcloud flags file rather than on the command line, so that
+// display names and filters reach gcloud without any quoting or splitting.
+func budgetCreateCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	if err := services.RequireAll(args, "billing_account", "display_name", "budget_amount"); err != nil {
+		return nil, err
+	}
+	billingAccount, _ := services.GetRequiredString(args, "billing_account")
+	displayName, _ := services.GetRequiredString(args, "display_name")
+	budgetAmount, _ := services.GetRequiredString(args, "budget_amount")
+
+	spec := map[string]any{
+		"--billing-account": strings.TrimPrefix(billingAccount, "billingAccounts/"),
+		"--display-name":    displayName,
+		"--budget-amount":   budgetAmount,
+	}
+	if thresholds, ok := args["threshold_rules"].([]any); ok {
+		var rules []string
+		for _, t := range thresholds {
+			if threshold, ok := t.(float64); ok {
+				rules = append(rules, fmt.Sprintf("percent=%g", threshold))
+			}
+		}
+		if len(rules) > 0 {
+			spec["--threshold-rule"] = rules
+		}
+	}
+	if projects := services.GetOptionalStringArray(args, "filter_projects"); len(projects) > 0 {
+		spec["--filter-projects"] = projects
+	}
+
+	return base.Executor.Command("billing", "budgets", "create").
+		WithJSONInput("flags-file", spec), nil
+}
+
+// csvColumns are the default column projections used when a list tool is
+// called with output=csv, keyed by resource.
+var csvColumns = map[string]string{
+	"accounts": "name,displayName,open",
+	"projects": "projectId,billingAccountName,billingEnabled",
+	"budgets":  "name,displayName,amount.specifiedAmount.units,amount.specifiedAmount.currencyCode",
+}
+
+// accountIAMBindingCommand builds an add or remove IAM policy binding command
+// for the billing account named in args. The account may be given with or
+// without the billingAccounts/ prefix.
+func accountIAMBindingCommand(base *services.BaseService, action string, args map[string]any) (*executor.CommandBuilder, error) {
+	account, err := services.GetRequiredString(args, "account")
+	if err != nil {
+		return nil, err
+	}
+	member, err := services.GetRequiredString(args, "member")
+	if err != nil {
+		return nil, err
+	}
+	role, err := services.GetRequiredString(args, "role")
+	if err != nil {
+		return nil, err
+	}
+
+	return base.Executor.Command("billing", "accounts", action, strings.TrimPrefix(account, "billingAccounts/")).
+		WithFlag("member", member).
+		WithFlag("role", role), nil
+}
@@ -0,0 +1,206 @@
+package billing
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"gcloud-go-mcp/internal/executor"
+	"gcloud-go-mcp/internal/executor/executortest"
+	"gcloud-go-mcp/internal/services"
+	"gcloud-go-mcp/internal/services/servicetest"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func newTestBase() *services.BaseService {
+	return services.NewBaseService(servicetest.NewConfig())
+}
+
+func TestRegisterTools(t *testing.T) {
+	server := mcp.NewServer(
+		&mcp.Implementation{
+			Name:    "test-server",
+			Version: "0.0.1",
+		},
+		&mcp.ServerOptions{},
+	)
+
+	// Should not panic
+	RegisterTools(server, newTestBase())
+}
+
+func TestToolCall_AccountsDescribe(t *testing.T) {
+	runner := &executortest.Runner{}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_billing_accounts_describe", map[string]any{
+		"account": "0X0X0X-0X0X0X-0X0X0X",
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+
+	args := runner.LastArgs()
+	if len(args) < 4 || !slices.Equal(args[:4], []string{"billing", "accounts", "describe", "0X0X0X-0X0X0X-0X0X0X"}) {
+		t.Errorf("unexpected command %v", args)
+	}
+	if missing := executortest.Missing(args, "--format=json"); len(missing) > 0 {
+		t.Errorf("missing %v in %v", missing, args)
+	}
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "--account") {
+			t.Errorf("billing account leaked into %s", arg)
+		}
+	}
+}
+
+func TestAccountIAMBindingCommand(t *testing.T) {
+	for _, account := range []string{"0X0X0X-0X0X0X-0X0X0X", "billingAccounts/0X0X0X-0X0X0X-0X0X0X"} {
+		for _, action := range []string{"add-iam-policy-binding", "remove-iam-policy-binding"} {
+			t.Run(action+" "+account, func(t *testing.T) {
+				cmd, err := accountIAMBindingCommand(newTestBase(), action, map[string]any{
+					"account": account,
+					"member":  "group:finance@example.com",
+					"role":    "roles/billing.user",
+				})
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				args := cmd.Build()
+				if !slices.Equal(args[:4], []string{"billing", "accounts", action, "0X0X0X-0X0X0X-0X0X0X"}) {
+					t.Errorf("unexpected command %v", args)
+				}
+				if missing := executortest.Missing(args, "--member=group:finance@example.com", "--role=roles/billing.user"); len(missing) > 0 {
+					t.Errorf("missing %v in %v", missing, args)
+				}
+			})
+		}
+	}
+}
+
+func TestAccountIAMBindingCommand_MissingParams(t *testing.T) {
+	for _, missing := range []string{"account", "member", "role"} {
+		args := map[string]any{
+			"account": "0X0X0X-0X0X0X-0X0X0X",
+			"member":  "group:finance@example.com",
+			"role":    "roles/billing.user",
+		}
+		delete(args, missing)
+
+		_, err := accountIAMBindingCommand(newTestBase(), "add-iam-policy-binding", args)
+		if err == nil || !strings.Contains(err.Error(), missing) {
+			t.Errorf("expected error mentioning %q, got %v", missing, err)
+		}
+	}
+}
+
+func TestToolCall_BudgetsCreate(t *testing.T) {
+	var spec map[string]any
+	runner := &executortest.Runner{Handler: func(args []string) (*executor.Result, error) {
+		for _, arg := range args {
+			if path, ok := strings.CutPrefix(arg, "--flags-file="); ok {
+				data, err := os.ReadFile(path)
+				if err != nil {
+					return nil, err
+				}
+				if err := json.Unmarshal(data, &spec); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return &executor.Result{Stdout: "{}"}, nil
+	}}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_billing_budgets_create", map[string]any{
+		"billing_account": "billingAccounts/0X0X0X-0X0X0X-0X0X0X",
+		"display_name":    "Prod, monthly",
+		"budget_amount":   "1000.00USD",
+		"threshold_rules": []any{0.5, 0.9, 1.0},
+		"filter_projects": []any{"prod-a", "prod-b"},
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+
+	args := runner.LastArgs()
+	if !slices.Equal(args[:3], []string{"billing", "budgets", "create"}) {
+		t.Errorf("unexpected command %v", args)
+	}
+	want := map[string]any{
+		"--billing-account": "0X0X0X-0X0X0X-0X0X0X",
+		"--display-name":    "Prod, monthly",
+		"--budget-amount":   "1000.00USD",
+		"--threshold-rule":  []any{"percent=0.5", "percent=0.9", "percent=1"},
+		"--filter-projects": []any{"prod-a", "prod-b"},
+	}
+	if !reflect.DeepEqual(spec, want) {
+		t.Errorf("flags file = %v, want %v", spec, want)
+	}
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "--display-name") {
+			t.Errorf("expected the budget only in the flags file, got %s", arg)
+		}
+	}
+}
+
+func TestProjectsListCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "account ID",
+			args: map[string]any{"billing_account": "0X0X0X-0X0X0X-0X0X0X"},
+			want: []string{"--billing-account=0X0X0X-0X0X0X-0X0X0X", "--format=json"},
+		},
+		{
+			name: "account resource name",
+			args: map[string]any{"billing_account": "billingAccounts/0X0X0X-0X0X0X-0X0X0X"},
+			want: []string{"--billing-account=0X0X0X-0X0X0X-0X0X0X"},
+		},
+		{
+			name: "csv output",
+			args: map[string]any{"billing_account": "0X0X0X-0X0X0X-0X0X0X", "output": "csv"},
+			want: []string{"--format=csv(projectId,billingAccountName,billingEnabled)"},
+		},
+		{
+			name:    "missing account",
+			args:    map[string]any{},
+			wantErr: "billing_account",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := projectsListCommand(newTestBase(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if !slices.Equal(args[:3], []string{"billing", "projects", "list"}) {
+				t.Errorf("unexpected command %v", args)
+			}
+			for _, w := range tt.want {
+				if !slices.Contains(args, w) {
+					t.Errorf("expected %q in args, got %v", w, args)
+				}
+			}
+		})
+	}
+}
+
+func TestBudgetCreateCommand_ReportsAllMissing(t *testing.T) {
+	_, err := budgetCreateCommand(newTestBase(), map[string]any{"display_name": "Monthly"})
+	if err == nil || !strings.Contains(err.Error(), "missing required parameters: billing_account, budget_amount") {
+		t.Errorf("expected both missing parameters in one error, got %v", err)
+	}
+}
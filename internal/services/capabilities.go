@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Capability describes a registered tool in the gcp_capabilities listing.
+type Capability struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Required    []string `json:"required,omitempty"`
+	Destructive bool     `json:"destructive,omitempty"`
+}
+
+// Registry records the tools registered through AddTool.
+type Registry struct {
+	mu    sync.Mutex
+	tools []Capability
+}
+
+// add records tool, taking its required parameters from its input schema.
+func (r *Registry) add(tool *mcp.Tool) {
+	capability := Capability{
+		Name:        tool.Name,
+		Description: tool.Description,
+		Destructive: IsDestructive(tool),
+	}
+	if schema, ok := tool.InputSchema.(map[string]any); ok {
+		if required, ok := schema["required"].([]string); ok {
+			capability.Required = slices.Clone(required)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tools = append(r.tools, capability)
+}
+
+// ByService returns the registered tools grouped by service and sorted by
+// name. When service is non-empty only that service is returned.
+func (r *Registry) ByService(service string) map[string][]Capability {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	groups := make(map[string][]Capability)
+	for _, tool := range r.tools {
+		s := ToolService(tool.Name)
+		if service != "" && s != service {
+			continue
+		}
+		groups[s] = append(groups[s], tool)
+	}
+	for _, tools := range groups {
+		slices.SortFunc(tools, func(a, b Capability) int { return strings.Compare(a.Name, b.Name) })
+	}
+	return groups
+}
+
+// ToolService returns the service a tool belongs to, the segment after the
+// gcp_ prefix of its name (e.g., "run" for gcp_run_services_list).
+func ToolService(name string) string {
+	name = strings.TrimPrefix(name, "gcp_")
+	service, _, _ := strings.Cut(name, "_")
+	return service
+}
+
+// RegisterCapabilitiesTool registers the gcp_capabilities tool, which lists
+// every tool registered through base. Register it after the service tools.
+func RegisterCapabilitiesTool(server *mcp.Server, base *BaseService) {
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_capabilities",
+			Description: "List the available tools grouped by service, with their required parameters",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"service": map[string]any{
+						"type":        "string",
+						"description": "Only list tools of this service (e.g., run, compute, storage)",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			var args map[string]any
+			if req.Params.Arguments != nil {
+				_ = json.Unmarshal(req.Params.Arguments, &args)
+			}
+			service := GetOptionalString(args, "service", "")
+
+			groups := base.Registry.ByService(service)
+			if service != "" && len(groups) == 0 {
+				return ToolError(fmt.Errorf("unknown service %q", service)), nil
+			}
+			data, err := json.MarshalIndent(groups, "", "  ")
+			if err != nil {
+				return ToolError(err), nil
+			}
+			return ToolResult(string(data)), nil
+		},
+	)
+}
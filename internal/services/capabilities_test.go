@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"gcloud-go-mcp/internal/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestToolService(t *testing.T) {
+	tests := map[string]string{
+		"gcp_run_services_list":            "run",
+		"gcp_compute_routers_nats_create":  "compute",
+		"gcp_resourcemanager_folders_list": "resourcemanager",
+		"gcp_capabilities":                 "capabilities",
+	}
+	for name, want := range tests {
+		if got := ToolService(name); got != want {
+			t.Errorf("ToolService(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestRegistry_ByService(t *testing.T) {
+	base := NewBaseService(&config.Config{GCloudPath: "gcloud"})
+	server := mcp.NewServer(&mcp.Implementation{Name: "test-server", Version: "0.0.1"}, nil)
+	noop := func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return ToolResult(""), nil
+	}
+
+	base.AddTool(server, &mcp.Tool{
+		Name: "gcp_run_services_delete", Annotations: Destructive(),
+		InputSchema: map[string]any{"type": "object", "required": []string{"service"}, "properties": map[string]any{}},
+	}, noop)
+	base.AddTool(server, &mcp.Tool{
+		Name:        "gcp_run_services_list",
+		InputSchema: map[string]any{"type": "object", "properties": map[string]any{}},
+	}, noop)
+	base.AddTool(server, &mcp.Tool{
+		Name:        "gcp_storage_buckets_list",
+		InputSchema: map[string]any{"type": "object", "properties": map[string]any{}},
+	}, noop)
+
+	groups := base.Registry.ByService("")
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 services, got %v", groups)
+	}
+	run := groups["run"]
+	if len(run) != 2 || run[0].Name != "gcp_run_services_delete" || run[1].Name != "gcp_run_services_list" {
+		t.Fatalf("expected run tools sorted by name, got %+v", run)
+	}
+	if !slices.Equal(run[0].Required, []string{"service"}) || !run[0].Destructive {
+		t.Errorf("expected required service and destructive, got %+v", run[0])
+	}
+	if run[1].Required != nil || run[1].Destructive {
+		t.Errorf("expected no required parameters, got %+v", run[1])
+	}
+
+	if groups := base.Registry.ByService("storage"); len(groups) != 1 || len(groups["storage"]) != 1 {
+		t.Errorf("expected only storage tools, got %v", groups)
+	}
+}
@@ -0,0 +1,209 @@
+// Package certmanager provides MCP tools for Certificate Manager.
+package certmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gcloud-go-mcp/internal/executor"
+	"gcloud-go-mcp/internal/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RegisterTools registers all Certificate Manager tools with the MCP server.
+func RegisterTools(server *mcp.Server, base *services.BaseService) {
+	// List certificates
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_certmanager_certificates_list",
+			Description: "List Certificate Manager certificates",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"location": map[string]any{
+						"type":        "string",
+						"description": "Location of the certificates (e.g., global, us-central1)",
+						"default":     "global",
+					},
+					"filter": map[string]any{
+						"type":        "string",
+						"description": "Filter expression",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd := base.Executor.Command("certificate-manager", "certificates", "list").
+				WithFlag("location", services.GetOptionalString(args, "location", "global")).
+				WithFlag("filter", services.GetOptionalString(args, "filter", "")).
+				WithProject(services.GetOptionalString(args, "project", ""))
+			if err := services.ApplyListOutput(cmd, args, csvColumns["certificates"]); err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Create managed certificate
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_certmanager_certificates_create",
+			Description: "Create a Google-managed certificate for one or more domains. Provisioning completes once the domains' DNS authorizations or load balancer are in place",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"certificate", "domains"},
+				"properties": map[string]any{
+					"certificate": map[string]any{
+						"type":        "string",
+						"description": "Certificate ID",
+					},
+					"domains": map[string]any{
+						"type":        "array",
+						"items":       map[string]any{"type": "string"},
+						"description": "Domains the certificate covers (e.g., [\"example.com\", \"*.example.com\"])",
+					},
+					"dns_authorizations": map[string]any{
+						"type":        "array",
+						"items":       map[string]any{"type": "string"},
+						"description": "DNS authorizations for the domains; required for wildcard domains",
+					},
+					"location": map[string]any{
+						"type":        "string",
+						"description": "Location of the certificate",
+						"default":     "global",
+					},
+					"scope": map[string]any{
+						"type":        "string",
+						"description": "Where the certificate can be used",
+						"enum":        []string{"DEFAULT", "EDGE_CACHE", "ALL_REGIONS"},
+					},
+					"description": map[string]any{
+						"type":        "string",
+						"description": "Description of the certificate",
+					},
+					"labels": map[string]any{
+						"type":        "object",
+						"description": "Labels for the certificate",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := managedCertificateCreateCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.DeployError(result, err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// List certificate maps
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_certmanager_maps_list",
+			Description: "List certificate maps, which attach certificates to load balancer target proxies",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"filter": map[string]any{
+						"type":        "string",
+						"description": "Filter expression",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd := base.Executor.Command("certificate-manager", "maps", "list").
+				WithFlag("filter", services.GetOptionalString(args, "filter", "")).
+				WithProject(services.GetOptionalString(args, "project", ""))
+			if err := services.ApplyListOutput(cmd, args, csvColumns["maps"]); err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+}
+
+func parseArgs(req *mcp.CallToolRequest) map[string]any {
+	var args map[string]any
+	if req.Params.Arguments != nil {
+		_ = json.Unmarshal(req.Params.Arguments, &args)
+	}
+	if args == nil {
+		args = make(map[string]any)
+	}
+	return args
+}
+
+// csvColumns are the default column projections used when a list tool is
+// called with output=csv, keyed by resource.
+var csvColumns = map[string]string{
+	"certificates": "name.basename(),sanDnsnames.join(';'),managed.state,expireTime",
+	"maps":         "name.basename(),description,createTime",
+}
+
+// managedCertificateCreateCommand builds the `certificate-manager
+// certificates create` command for a Google-managed certificate.
+func managedCertificateCreateCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	certificate, err := services.GetRequiredString(args, "certificate")
+	if err != nil {
+		return nil, err
+	}
+	domains := services.GetOptionalStringArray(args, "domains")
+	if len(domains) == 0 {
+		return nil, fmt.Errorf("domains is required")
+	}
+	authorizations := services.GetOptionalStringArray(args, "dns_authorizations")
+	if len(authorizations) == 0 {
+		for _, domain := range domains {
+			if strings.HasPrefix(domain, "*.") {
+				return nil, fmt.Errorf("wildcard domain %s requires dns_authorizations", domain)
+			}
+		}
+	}
+
+	return base.Executor.Command("certificate-manager", "certificates", "create", certificate).
+		WithFlag("domains", strings.Join(domains, ",")).
+		WithFlag("dns-authorizations", strings.Join(authorizations, ",")).
+		WithFlag("location", services.GetOptionalString(args, "location", "")).
+		WithFlag("scope", services.GetOptionalString(args, "scope", "")).
+		WithFlag("description", services.GetOptionalString(args, "description", "")).
+		WithFlag("labels", base.Labels(args)).
+		WithProject(services.GetOptionalString(args, "project", "")), nil
+}
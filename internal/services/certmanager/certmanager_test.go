@@ -0,0 +1,106 @@
+package certmanager
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"gcloud-go-mcp/internal/executor/executortest"
+	"gcloud-go-mcp/internal/services"
+	"gcloud-go-mcp/internal/services/servicetest"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func newTestBase() *services.BaseService {
+	return services.NewBaseService(servicetest.NewConfig())
+}
+
+func TestRegisterTools(t *testing.T) {
+	server := mcp.NewServer(
+		&mcp.Implementation{
+			Name:    "test-server",
+			Version: "0.0.1",
+		},
+		&mcp.ServerOptions{},
+	)
+
+	// Should not panic
+	RegisterTools(server, newTestBase())
+}
+
+func TestManagedCertificateCreateCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		notWant []string
+		wantErr string
+	}{
+		{
+			name: "load balancer authorization",
+			args: map[string]any{
+				"certificate": "www-cert",
+				"domains":     []any{"example.com", "www.example.com"},
+				"labels":      map[string]any{"team": "web"},
+			},
+			want:    []string{"--domains=example.com,www.example.com", "--labels=team=web", "--project=test-project"},
+			notWant: []string{"--dns-authorizations=", "--location="},
+		},
+		{
+			name: "wildcard with dns authorization",
+			args: map[string]any{
+				"certificate":        "wildcard-cert",
+				"domains":            []any{"*.example.com"},
+				"dns_authorizations": []any{"example-com-auth"},
+				"location":           "us-central1",
+				"scope":              "ALL_REGIONS",
+			},
+			want: []string{"--domains=*.example.com", "--dns-authorizations=example-com-auth", "--location=us-central1", "--scope=ALL_REGIONS"},
+		},
+		{
+			name:    "wildcard without dns authorization",
+			args:    map[string]any{"certificate": "wildcard-cert", "domains": []any{"*.example.com"}},
+			wantErr: "requires dns_authorizations",
+		},
+		{
+			name:    "no domains",
+			args:    map[string]any{"certificate": "www-cert"},
+			wantErr: "domains is required",
+		},
+		{
+			name:    "missing certificate",
+			args:    map[string]any{"domains": []any{"example.com"}},
+			wantErr: "certificate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := managedCertificateCreateCommand(newTestBase(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if !slices.Equal(args[:4], []string{"certificate-manager", "certificates", "create", tt.args["certificate"].(string)}) {
+				t.Errorf("unexpected command %v", args)
+			}
+			if missing := executortest.Missing(args, tt.want...); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, args)
+			}
+			for _, prefix := range tt.notWant {
+				for _, arg := range args {
+					if strings.HasPrefix(arg, prefix) {
+						t.Errorf("unexpected %s in %v", arg, args)
+					}
+				}
+			}
+		})
+	}
+}
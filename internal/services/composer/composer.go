@@ -0,0 +1,272 @@
+// Package composer provides MCP tools for Cloud Composer (managed Airflow).
+package composer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"gcloud-go-mcp/internal/executor"
+	"gcloud-go-mcp/internal/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RegisterTools registers all Cloud Composer tools with the MCP server.
+func RegisterTools(server *mcp.Server, base *services.BaseService) {
+	// List environments
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_composer_environments_list",
+			Description: "List Cloud Composer environments in a location",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"location": map[string]any{
+						"type":        "string",
+						"description": "Composer location, a region such as us-central1 (uses the default region if not specified)",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			location, err := requiredLocation(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			cmd := base.Executor.Command("composer", "environments", "list").
+				WithFlag("locations", location).
+				WithProject(services.GetOptionalString(args, "project", ""))
+
+			if err := services.ApplyListOutput(cmd, args, csvColumns["environments"]); err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Describe environment
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_composer_environments_describe",
+			Description: "Get details of a Cloud Composer environment, including its Airflow web UI and DAG bucket",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"environment"},
+				"properties": map[string]any{
+					"environment": map[string]any{
+						"type":        "string",
+						"description": "Environment name",
+					},
+					"location": map[string]any{
+						"type":        "string",
+						"description": "Composer location (uses the default region if not specified)",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"soft_not_found": services.SoftNotFoundProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			environment, err := services.GetRequiredString(args, "environment")
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			location, err := requiredLocation(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := base.Executor.Command("composer", "environments", "describe", environment).
+				WithFlag("location", location).
+				WithProject(services.GetOptionalString(args, "project", "")).
+				Execute(ctx)
+
+			if err != nil {
+				return services.DescribeError(args, result, err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Create environment
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_composer_environments_create",
+			Description: "Create a Cloud Composer environment. Creation takes 20 minutes or more, so by default the tool returns once the operation has started",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"environment"},
+				"properties": map[string]any{
+					"environment": map[string]any{
+						"type":        "string",
+						"description": "Environment name",
+					},
+					"location": map[string]any{
+						"type":        "string",
+						"description": "Composer location (uses the default region if not specified)",
+					},
+					"image_version": map[string]any{
+						"type":        "string",
+						"description": "Composer and Airflow image version (e.g., composer-2.9.7-airflow-2.9.3 or composer-3-airflow-2). Defaults to the latest",
+					},
+					"environment_size": map[string]any{
+						"type":        "string",
+						"description": "Preset size of the environment's infrastructure",
+						"enum":        []string{"small", "medium", "large"},
+					},
+					"scheduler_count": map[string]any{
+						"type":        "number",
+						"description": "Number of Airflow schedulers",
+					},
+					"min_workers": map[string]any{
+						"type":        "number",
+						"description": "Minimum number of Airflow workers",
+					},
+					"max_workers": map[string]any{
+						"type":        "number",
+						"description": "Maximum number of Airflow workers",
+					},
+					"network": map[string]any{
+						"type":        "string",
+						"description": "VPC network for the environment",
+					},
+					"subnetwork": map[string]any{
+						"type":        "string",
+						"description": "Subnetwork for the environment (requires network)",
+					},
+					"service_account": map[string]any{
+						"type":        "string",
+						"description": "Service account the environment's nodes run as",
+					},
+					"labels": map[string]any{
+						"type":        "object",
+						"description": "Labels (key-value pairs)",
+					},
+					"async": map[string]any{
+						"type":        "boolean",
+						"description": "Return once the operation has started instead of waiting for it to finish",
+						"default":     true,
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := environmentCreateCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.DeployError(result, err), nil
+			}
+			if id := services.OperationID(result.Stderr); id != "" && result.Stdout == "" {
+				return services.ToolResult("Environment creation started: " + id), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+}
+
+func parseArgs(req *mcp.CallToolRequest) map[string]any {
+	var args map[string]any
+	if req.Params.Arguments != nil {
+		_ = json.Unmarshal(req.Params.Arguments, &args)
+	}
+	if args == nil {
+		args = make(map[string]any)
+	}
+	return args
+}
+
+// csvColumns are the default column projections used when a list tool is
+// called with output=csv, keyed by resource.
+var csvColumns = map[string]string{
+	"environments": "name.basename(),state,config.softwareConfig.imageVersion,createTime",
+}
+
+// requiredLocation returns the Composer location, falling back to the
+// configured region. Composer commands fail without one.
+func requiredLocation(base *services.BaseService, args map[string]any) (string, error) {
+	location := services.GetOptionalString(args, "location", base.Config.Region)
+	if location == "" {
+		return "", fmt.Errorf("location is required (pass location or set GCLOUD_REGION)")
+	}
+	return location, nil
+}
+
+// environmentCreateCommand builds the `composer environments create` command.
+func environmentCreateCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	environment, err := services.GetRequiredString(args, "environment")
+	if err != nil {
+		return nil, err
+	}
+	location, err := requiredLocation(base, args)
+	if err != nil {
+		return nil, err
+	}
+
+	network := services.GetOptionalString(args, "network", "")
+	subnetwork := services.GetOptionalString(args, "subnetwork", "")
+	if subnetwork != "" && network == "" {
+		return nil, fmt.Errorf("subnetwork requires network")
+	}
+
+	cmd := base.Executor.Command("composer", "environments", "create", environment).
+		WithFlag("location", location).
+		WithFlag("image-version", services.GetOptionalString(args, "image_version", "")).
+		WithFlag("environment-size", services.GetOptionalString(args, "environment_size", "")).
+		WithFlag("network", network).
+		WithFlag("subnetwork", subnetwork).
+		WithFlag("service-account", services.GetOptionalString(args, "service_account", "")).
+		WithFlag("labels", base.Labels(args)).
+		WithProject(services.GetOptionalString(args, "project", ""))
+
+	for _, option := range []struct{ arg, flag string }{
+		{"scheduler_count", "scheduler-count"},
+		{"min_workers", "min-workers"},
+		{"max_workers", "max-workers"},
+	} {
+		if _, ok := args[option.arg]; !ok {
+			continue
+		}
+		n := services.GetOptionalInt(args, option.arg, 0)
+		if n < 1 {
+			return nil, fmt.Errorf("%s must be at least 1", option.arg)
+		}
+		cmd.WithFlag(option.flag, strconv.Itoa(n))
+	}
+	minWorkers := services.GetOptionalInt(args, "min_workers", 0)
+	maxWorkers := services.GetOptionalInt(args, "max_workers", 0)
+	if minWorkers > 0 && maxWorkers > 0 && minWorkers > maxWorkers {
+		return nil, fmt.Errorf("min_workers must not be greater than max_workers")
+	}
+
+	if services.GetOptionalBool(args, "async", true) {
+		cmd.WithBoolFlag("async")
+	}
+	return cmd, nil
+}
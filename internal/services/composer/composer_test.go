@@ -0,0 +1,137 @@
+package composer
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"gcloud-go-mcp/internal/executor/executortest"
+	"gcloud-go-mcp/internal/services"
+	"gcloud-go-mcp/internal/services/servicetest"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func newTestBase() *services.BaseService {
+	return services.NewBaseService(servicetest.NewConfig())
+}
+
+func TestRegisterTools(t *testing.T) {
+	server := mcp.NewServer(
+		&mcp.Implementation{
+			Name:    "test-server",
+			Version: "0.0.1",
+		},
+		&mcp.ServerOptions{},
+	)
+
+	// Should not panic
+	RegisterTools(server, newTestBase())
+}
+
+func TestEnvironmentCreateCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		region  string
+		args    map[string]any
+		want    []string
+		notWant []string
+		wantErr string
+	}{
+		{
+			name:    "defaults",
+			region:  "us-central1",
+			args:    map[string]any{"environment": "etl"},
+			want:    []string{"--location=us-central1", "--async"},
+			notWant: []string{"--image-version=", "--environment-size="},
+		},
+		{
+			name:   "image version and node config",
+			region: "us-central1",
+			args: map[string]any{
+				"environment":      "etl",
+				"location":         "europe-west1",
+				"image_version":    "composer-2.9.7-airflow-2.9.3",
+				"environment_size": "medium",
+				"scheduler_count":  float64(2),
+				"min_workers":      float64(1),
+				"max_workers":      float64(6),
+				"network":          "vpc",
+				"subnetwork":       "composer",
+				"service_account":  "composer@test-project.iam.gserviceaccount.com",
+			},
+			want: []string{
+				"--location=europe-west1",
+				"--image-version=composer-2.9.7-airflow-2.9.3",
+				"--environment-size=medium",
+				"--scheduler-count=2",
+				"--min-workers=1",
+				"--max-workers=6",
+				"--network=vpc",
+				"--subnetwork=composer",
+				"--service-account=composer@test-project.iam.gserviceaccount.com",
+			},
+		},
+		{
+			name:    "wait for completion",
+			region:  "us-central1",
+			args:    map[string]any{"environment": "etl", "async": false},
+			notWant: []string{"--async"},
+		},
+		{
+			name:    "no location",
+			args:    map[string]any{"environment": "etl"},
+			wantErr: "location is required",
+		},
+		{
+			name:    "subnetwork without network",
+			region:  "us-central1",
+			args:    map[string]any{"environment": "etl", "subnetwork": "composer"},
+			wantErr: "requires network",
+		},
+		{
+			name:    "workers out of order",
+			region:  "us-central1",
+			args:    map[string]any{"environment": "etl", "min_workers": float64(4), "max_workers": float64(2)},
+			wantErr: "min_workers",
+		},
+		{
+			name:    "zero schedulers",
+			region:  "us-central1",
+			args:    map[string]any{"environment": "etl", "scheduler_count": float64(0)},
+			wantErr: "scheduler_count",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := newTestBase()
+			base.Config.Region = tt.region
+
+			cmd, err := environmentCreateCommand(base, tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if !slices.Equal(args[:4], []string{"composer", "environments", "create", "etl"}) {
+				t.Errorf("unexpected command %v", args)
+			}
+			if missing := executortest.Missing(args, tt.want...); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, args)
+			}
+			for _, arg := range args {
+				for _, nw := range tt.notWant {
+					if strings.HasPrefix(arg, nw) {
+						t.Errorf("unexpected %q in %v", arg, args)
+					}
+				}
+			}
+		})
+	}
+}
@@ -2,11 +2,17 @@
 package compute
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"math"
+	"path"
+	"slices"
 	"strings"
 
+	"gcloud-go-mcp/internal/executor"
 	"gcloud-go-mcp/internal/services"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -14,7 +20,7 @@ import (
 // RegisterTools registers all Compute Engine tools with the MCP server.
 func RegisterTools(server *mcp.Server, base *services.BaseService) {
 	// List instances
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_compute_instances_list",
 			Description: "List Compute Engine VM instances",
@@ -33,6 +39,8 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "string",
 						"description": "Filter expression",
 					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
 				},
 			},
 		},
@@ -49,22 +57,119 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 				cmd.WithFlag("filter", filter)
 			}
 
+			if err := services.ApplyListOutput(cmd, args, csvColumns["instances"]); err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// List instance IPs
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_compute_instances_ips",
+			Description: "Get the internal and external IP addresses and status of VM instances, keyed by instance name",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"zone": map[string]any{
+						"type":        "string",
+						"description": "Zone (leave empty for all zones)",
+					},
+					"filter": map[string]any{
+						"type":        "string",
+						"description": "Filter expression",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+
+			cmd := base.Executor.Command("compute", "instances", "list").
+				WithProject(services.GetOptionalString(args, "project", ""))
+
+			if zone := services.GetOptionalString(args, "zone", ""); zone != "" {
+				cmd.WithFlag("zones", zone)
+			}
+			if filter := services.GetOptionalString(args, "filter", ""); filter != "" {
+				cmd.WithFlag("filter", filter)
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			ips, err := instanceIPs(result)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			data, _ := json.MarshalIndent(ips, "", "  ")
+			return base.TextResult(string(data)), nil
+		},
+	)
+
+	// Estimate running cost of instances
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_compute_instances_cost_estimate",
+			Description: "Estimate the hourly and monthly cost of running VM instances from an approximate on-demand price table (vCPU and memory only, not a billing source)",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"zone": map[string]any{
+						"type":        "string",
+						"description": "Zone (leave empty for all zones)",
+					},
+					"filter": map[string]any{
+						"type":        "string",
+						"description": "Filter expression",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+
+			cmd := base.Executor.Command("compute", "instances", "list").
+				WithFlag("zones", services.GetOptionalString(args, "zone", "")).
+				WithFlag("filter", services.GetOptionalString(args, "filter", "")).
+				WithProject(services.GetOptionalString(args, "project", ""))
+
 			result, err := cmd.Execute(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			estimate, err := instanceCostEstimate(result)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			data, _ := json.MarshalIndent(estimate, "", "  ")
+			return base.TextResult(string(data)), nil
 		},
 	)
 
 	// Describe instance
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_compute_instances_describe",
 			Description: "Get details of a VM instance",
 			InputSchema: map[string]any{
 				"type":     "object",
-				"required": []string{"instance", "zone"},
+				"required": []string{"instance"},
 				"properties": map[string]any{
 					"instance": map[string]any{
 						"type":        "string",
@@ -72,12 +177,13 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 					},
 					"zone": map[string]any{
 						"type":        "string",
-						"description": "Zone of the instance",
+						"description": "Zone of the instance (defaults to GCLOUD_COMPUTE_ZONE, then GCLOUD_ZONE)",
 					},
 					"project": map[string]any{
 						"type":        "string",
 						"description": "GCP project ID",
 					},
+					"soft_not_found": services.SoftNotFoundProperty(),
 				},
 			},
 		},
@@ -87,7 +193,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			zone, err := services.GetRequiredString(args, "zone")
+			zone, err := requiredZone(base, args)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
@@ -98,20 +204,20 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 				ExecuteWithZone(ctx)
 
 			if err != nil {
-				return services.ToolError(err), nil
+				return services.DescribeError(args, result, err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
-	// Create instance
-	server.AddTool(
+	// Get effective firewalls
+	base.AddTool(server,
 		&mcp.Tool{
-			Name:        "gcp_compute_instances_create",
-			Description: "Create a new VM instance",
+			Name:        "gcp_compute_instances_network_interfaces_get_effective_firewalls",
+			Description: "Get the firewall rules and policies that apply to a VM instance's network interface, for debugging connectivity",
 			InputSchema: map[string]any{
 				"type":     "object",
-				"required": []string{"instance", "zone"},
+				"required": []string{"instance"},
 				"properties": map[string]any{
 					"instance": map[string]any{
 						"type":        "string",
@@ -119,70 +225,114 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 					},
 					"zone": map[string]any{
 						"type":        "string",
-						"description": "Zone for the instance",
+						"description": "Zone of the instance (defaults to GCLOUD_COMPUTE_ZONE, then GCLOUD_ZONE)",
 					},
-					"machine_type": map[string]any{
+					"network_interface": map[string]any{
 						"type":        "string",
-						"description": "Machine type (e.g., e2-micro, n1-standard-1)",
-						"default":     "e2-micro",
+						"description": "Network interface name",
+						"default":     "nic0",
 					},
-					"image_family": map[string]any{
+					"project": map[string]any{
 						"type":        "string",
-						"description": "Image family (e.g., debian-11, ubuntu-2204-lts)",
-						"default":     "debian-11",
+						"description": "GCP project ID",
 					},
-					"image_project": map[string]any{
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := effectiveFirewallsCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Get guest attributes
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_compute_instances_get_guest_attributes",
+			Description: "Get guest attributes, the metadata written by software running inside a VM instance (e.g., host keys under hostkeys/). Requires enable-guest-attributes=TRUE in the instance metadata",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"instance"},
+				"properties": map[string]any{
+					"instance": map[string]any{
 						"type":        "string",
-						"description": "Image project",
-						"default":     "debian-cloud",
+						"description": "Instance name",
 					},
-					"boot_disk_size": map[string]any{
+					"zone": map[string]any{
 						"type":        "string",
-						"description": "Boot disk size (e.g., 10GB, 50GB)",
+						"description": "Zone of the instance (defaults to GCLOUD_COMPUTE_ZONE, then GCLOUD_ZONE)",
 					},
-					"boot_disk_type": map[string]any{
+					"query_path": map[string]any{
 						"type":        "string",
-						"description": "Boot disk type (pd-standard, pd-ssd, pd-balanced)",
+						"description": "Namespace or key to return (e.g., hostkeys/ for a namespace, hostkeys/ssh-rsa for a single key); all attributes when empty",
 					},
-					"network": map[string]any{
+					"project": map[string]any{
 						"type":        "string",
-						"description": "Network name",
+						"description": "GCP project ID",
 					},
-					"subnet": map[string]any{
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := guestAttributesCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Create instance
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_compute_instances_create",
+			Description: "Create a new VM instance, optionally running a container on Container-Optimized OS",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"instance"},
+				"properties": instanceConfigProperties(map[string]any{
+					"instance": map[string]any{
 						"type":        "string",
-						"description": "Subnet name",
+						"description": "Instance name",
 					},
-					"service_account": map[string]any{
+					"container_image": map[string]any{
 						"type":        "string",
-						"description": "Service account email",
+						"description": "Container image to run on the VM (e.g., us-docker.pkg.dev/my-project/app/web:v1). The VM boots Container-Optimized OS (cos-stable from cos-cloud unless a cos-* image_family is given)",
 					},
-					"scopes": map[string]any{
-						"type":        "array",
-						"description": "API scopes",
-						"items":       map[string]any{"type": "string"},
+					"container_env": map[string]any{
+						"type":        "object",
+						"description": "Environment variables of the container as key-value pairs. Requires container_image",
 					},
-					"tags": map[string]any{
+					"container_args": map[string]any{
 						"type":        "array",
-						"description": "Network tags",
+						"description": "Arguments passed to the container's entrypoint, in order. Requires container_image",
 						"items":       map[string]any{"type": "string"},
 					},
-					"labels": map[string]any{
-						"type":        "object",
-						"description": "Labels",
-					},
-					"metadata": map[string]any{
-						"type":        "object",
-						"description": "Metadata key-value pairs",
-					},
-					"preemptible": map[string]any{
-						"type":        "boolean",
-						"description": "Use preemptible VM",
+					"zone": map[string]any{
+						"type":        "string",
+						"description": "Zone for the instance (defaults to GCLOUD_COMPUTE_ZONE, then GCLOUD_ZONE)",
 					},
 					"project": map[string]any{
 						"type":        "string",
 						"description": "GCP project ID",
 					},
-				},
+					"if_not_exists": services.IfNotExistsProperty(),
+				}),
 			},
 		},
 		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -191,74 +341,86 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			zone, err := services.GetRequiredString(args, "zone")
+			zone, err := requiredZone(base, args)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
 
-			cmd := base.Executor.Command("compute", "instances", "create", instance).
-				WithZone(zone).
-				WithProject(services.GetOptionalString(args, "project", ""))
-
-			cmd.WithFlag("machine-type", services.GetOptionalString(args, "machine_type", "e2-micro"))
-			cmd.WithFlag("image-family", services.GetOptionalString(args, "image_family", "debian-11"))
-			cmd.WithFlag("image-project", services.GetOptionalString(args, "image_project", "debian-cloud"))
-
-			if bootDiskSize := services.GetOptionalString(args, "boot_disk_size", ""); bootDiskSize != "" {
-				cmd.WithFlag("boot-disk-size", bootDiskSize)
-			}
-			if bootDiskType := services.GetOptionalString(args, "boot_disk_type", ""); bootDiskType != "" {
-				cmd.WithFlag("boot-disk-type", bootDiskType)
-			}
-			if network := services.GetOptionalString(args, "network", ""); network != "" {
-				cmd.WithFlag("network", network)
-			}
-			if subnet := services.GetOptionalString(args, "subnet", ""); subnet != "" {
-				cmd.WithFlag("subnet", subnet)
-			}
-			if sa := services.GetOptionalString(args, "service_account", ""); sa != "" {
-				cmd.WithFlag("service-account", sa)
-			}
-			if scopes := services.GetOptionalStringArray(args, "scopes"); len(scopes) > 0 {
-				cmd.WithFlag("scopes", strings.Join(scopes, ","))
+			existing, err := base.ExistingResource(ctx, args, base.Executor.Command("compute", "instances", "describe", instance).
+				WithFlag("zone", zone).
+				WithProject(services.GetOptionalString(args, "project", "")))
+			if err != nil {
+				return services.ToolError(err), nil
 			}
-			if tags := services.GetOptionalStringArray(args, "tags"); len(tags) > 0 {
-				cmd.WithFlag("tags", strings.Join(tags, ","))
+			if existing != nil {
+				return existing, nil
 			}
-			if labels := services.GetOptionalStringMap(args, "labels"); len(labels) > 0 {
-				var pairs []string
-				for k, v := range labels {
-					pairs = append(pairs, fmt.Sprintf("%s=%s", k, v))
-				}
-				cmd.WithFlag("labels", strings.Join(pairs, ","))
+
+			cmd, err := instanceCreateCommand(base, instance, zone, args)
+			if err != nil {
+				return services.ToolError(err), nil
 			}
-			if metadata := services.GetOptionalStringMap(args, "metadata"); len(metadata) > 0 {
-				var pairs []string
-				for k, v := range metadata {
-					pairs = append(pairs, fmt.Sprintf("%s=%s", k, v))
-				}
-				cmd.WithFlag("metadata", strings.Join(pairs, ","))
+
+			result, err := cmd.ExecuteWithZone(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
 			}
-			if services.GetOptionalBool(args, "preemptible", false) {
-				cmd.WithBoolFlag("preemptible")
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Bulk create instances
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_compute_instances_bulk_create",
+			Description: "Create multiple identical VM instances in one request",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"name_pattern", "count"},
+				"properties": instanceConfigProperties(map[string]any{
+					"name_pattern": map[string]any{
+						"type":        "string",
+						"description": "Name pattern where a run of # characters is replaced by a sequence number (e.g., web-###)",
+					},
+					"count": map[string]any{
+						"type":        "number",
+						"description": "Number of instances to create",
+					},
+					"zone": map[string]any{
+						"type":        "string",
+						"description": "Zone for the instances (defaults to GCLOUD_COMPUTE_ZONE, then GCLOUD_ZONE)",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				}),
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := bulkCreateCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
 			}
 
-			result, err := cmd.ExecuteWithZone(ctx)
+			result, err := cmd.Execute(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Delete instance
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_compute_instances_delete",
 			Description: "Delete a VM instance",
+			Annotations: services.Destructive(),
 			InputSchema: map[string]any{
 				"type":     "object",
-				"required": []string{"instance", "zone"},
+				"required": []string{"instance"},
 				"properties": map[string]any{
 					"instance": map[string]any{
 						"type":        "string",
@@ -266,7 +428,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 					},
 					"zone": map[string]any{
 						"type":        "string",
-						"description": "Zone of the instance",
+						"description": "Zone of the instance (defaults to GCLOUD_COMPUTE_ZONE, then GCLOUD_ZONE)",
 					},
 					"project": map[string]any{
 						"type":        "string",
@@ -281,7 +443,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			zone, err := services.GetRequiredString(args, "zone")
+			zone, err := requiredZone(base, args)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
@@ -300,13 +462,13 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 	)
 
 	// Start instance
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_compute_instances_start",
 			Description: "Start a stopped VM instance",
 			InputSchema: map[string]any{
 				"type":     "object",
-				"required": []string{"instance", "zone"},
+				"required": []string{"instance"},
 				"properties": map[string]any{
 					"instance": map[string]any{
 						"type":        "string",
@@ -314,7 +476,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 					},
 					"zone": map[string]any{
 						"type":        "string",
-						"description": "Zone of the instance",
+						"description": "Zone of the instance (defaults to GCLOUD_COMPUTE_ZONE, then GCLOUD_ZONE)",
 					},
 					"project": map[string]any{
 						"type":        "string",
@@ -329,7 +491,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			zone, err := services.GetRequiredString(args, "zone")
+			zone, err := requiredZone(base, args)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
@@ -342,18 +504,18 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Stop instance
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_compute_instances_stop",
 			Description: "Stop a running VM instance",
 			InputSchema: map[string]any{
 				"type":     "object",
-				"required": []string{"instance", "zone"},
+				"required": []string{"instance"},
 				"properties": map[string]any{
 					"instance": map[string]any{
 						"type":        "string",
@@ -361,7 +523,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 					},
 					"zone": map[string]any{
 						"type":        "string",
-						"description": "Zone of the instance",
+						"description": "Zone of the instance (defaults to GCLOUD_COMPUTE_ZONE, then GCLOUD_ZONE)",
 					},
 					"project": map[string]any{
 						"type":        "string",
@@ -376,7 +538,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			zone, err := services.GetRequiredString(args, "zone")
+			zone, err := requiredZone(base, args)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
@@ -389,18 +551,19 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Reset instance
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_compute_instances_reset",
 			Description: "Reset (hard reboot) a VM instance",
+			Annotations: services.Destructive(),
 			InputSchema: map[string]any{
 				"type":     "object",
-				"required": []string{"instance", "zone"},
+				"required": []string{"instance"},
 				"properties": map[string]any{
 					"instance": map[string]any{
 						"type":        "string",
@@ -408,7 +571,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 					},
 					"zone": map[string]any{
 						"type":        "string",
-						"description": "Zone of the instance",
+						"description": "Zone of the instance (defaults to GCLOUD_COMPUTE_ZONE, then GCLOUD_ZONE)",
 					},
 					"project": map[string]any{
 						"type":        "string",
@@ -423,7 +586,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			zone, err := services.GetRequiredString(args, "zone")
+			zone, err := requiredZone(base, args)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
@@ -436,18 +599,63 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Simulate maintenance event
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_compute_instances_simulate_maintenance_event",
+			Description: "Simulate a host maintenance event on a VM instance to test how it handles live migration or termination",
+			Annotations: services.Destructive(),
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"instance"},
+				"properties": map[string]any{
+					"instance": map[string]any{
+						"type":        "string",
+						"description": "Instance name",
+					},
+					"zone": map[string]any{
+						"type":        "string",
+						"description": "Zone of the instance (defaults to GCLOUD_COMPUTE_ZONE, then GCLOUD_ZONE)",
+					},
+					"async": map[string]any{
+						"type":        "boolean",
+						"description": "Return as soon as the event is started instead of waiting for it to finish",
+						"default":     false,
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := simulateMaintenanceCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// SSH command
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_compute_instances_ssh_command",
 			Description: "Get SSH command for connecting to an instance",
 			InputSchema: map[string]any{
 				"type":     "object",
-				"required": []string{"instance", "zone"},
+				"required": []string{"instance"},
 				"properties": map[string]any{
 					"instance": map[string]any{
 						"type":        "string",
@@ -455,7 +663,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 					},
 					"zone": map[string]any{
 						"type":        "string",
-						"description": "Zone of the instance",
+						"description": "Zone of the instance (defaults to GCLOUD_COMPUTE_ZONE, then GCLOUD_ZONE)",
 					},
 					"project": map[string]any{
 						"type":        "string",
@@ -465,6 +673,11 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "string",
 						"description": "SSH username",
 					},
+					"skip_precheck": map[string]any{
+						"type":        "boolean",
+						"description": "Skip checking that the instance is running",
+						"default":     false,
+					},
 				},
 			},
 		},
@@ -474,13 +687,26 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			zone, err := services.GetRequiredString(args, "zone")
+			zone, err := requiredZone(base, args)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
 			project := services.GetOptionalString(args, "project", "")
 			user := services.GetOptionalString(args, "user", "")
 
+			if !services.GetOptionalBool(args, "skip_precheck", false) {
+				result, err := base.Executor.Command("compute", "instances", "describe", instance).
+					WithFlag("zone", zone).
+					WithProject(project).
+					Execute(ctx)
+				if err != nil {
+					return services.ToolError(err), nil
+				}
+				if err := checkInstanceRunning(instance, result); err != nil {
+					return services.ToolError(err), nil
+				}
+			}
+
 			// Build SSH command string
 			sshCmd := fmt.Sprintf("gcloud compute ssh %s --zone=%s", instance, zone)
 			if project != "" {
@@ -497,51 +723,101 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 		},
 	)
 
-	// List disks
-	server.AddTool(
+	// Reset Windows password
+	base.AddTool(server,
 		&mcp.Tool{
-			Name:        "gcp_compute_disks_list",
-			Description: "List persistent disks",
+			Name:        "gcp_compute_instances_reset_windows_password",
+			Description: "Reset and return the password of a Windows user on a VM instance. The output contains credentials and requires GCLOUD_ALLOW_SENSITIVE_OUTPUT=true",
+			Annotations: services.Destructive(),
 			InputSchema: map[string]any{
-				"type": "object",
+				"type":     "object",
+				"required": []string{"instance", "user"},
 				"properties": map[string]any{
-					"project": map[string]any{
+					"instance": map[string]any{
 						"type":        "string",
-						"description": "GCP project ID",
+						"description": "Instance name",
 					},
 					"zone": map[string]any{
 						"type":        "string",
-						"description": "Zone",
+						"description": "Zone of the instance (defaults to GCLOUD_COMPUTE_ZONE, then GCLOUD_ZONE)",
+					},
+					"user": map[string]any{
+						"type":        "string",
+						"description": "Windows username (created if it does not exist)",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
 					},
 				},
 			},
 		},
 		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			args := parseArgs(req)
+			cmd, err := resetWindowsPasswordCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
 
-			cmd := base.Executor.Command("compute", "disks", "list").
-				WithProject(services.GetOptionalString(args, "project", ""))
-
-			if zone := services.GetOptionalString(args, "zone", ""); zone != "" {
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// List disks
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_compute_disks_list",
+			Description: "List persistent disks",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"zone": map[string]any{
+						"type":        "string",
+						"description": "Zone",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+
+			cmd := base.Executor.Command("compute", "disks", "list").
+				WithProject(services.GetOptionalString(args, "project", ""))
+
+			if zone := services.GetOptionalString(args, "zone", ""); zone != "" {
 				cmd.WithFlag("zones", zone)
 			}
 
+			if err := services.ApplyListOutput(cmd, args, csvColumns["disks"]); err != nil {
+				return services.ToolError(err), nil
+			}
+
 			result, err := cmd.Execute(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Create disk
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_compute_disks_create",
 			Description: "Create a persistent disk",
 			InputSchema: map[string]any{
 				"type":     "object",
-				"required": []string{"disk", "zone"},
+				"required": []string{"disk"},
 				"properties": map[string]any{
 					"disk": map[string]any{
 						"type":        "string",
@@ -549,7 +825,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 					},
 					"zone": map[string]any{
 						"type":        "string",
-						"description": "Zone",
+						"description": "Zone (defaults to GCLOUD_COMPUTE_ZONE, then GCLOUD_ZONE)",
 					},
 					"size": map[string]any{
 						"type":        "string",
@@ -563,6 +839,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "string",
 						"description": "GCP project ID",
 					},
+					"if_not_exists": services.IfNotExistsProperty(),
 				},
 			},
 		},
@@ -572,10 +849,20 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			zone, err := services.GetRequiredString(args, "zone")
+			zone, err := requiredZone(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			existing, err := base.ExistingResource(ctx, args, base.Executor.Command("compute", "disks", "describe", disk).
+				WithFlag("zone", zone).
+				WithProject(services.GetOptionalString(args, "project", "")))
 			if err != nil {
 				return services.ToolError(err), nil
 			}
+			if existing != nil {
+				return existing, nil
+			}
 
 			cmd := base.Executor.Command("compute", "disks", "create", disk).
 				WithZone(zone).
@@ -592,12 +879,12 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// List snapshots
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_compute_snapshots_list",
 			Description: "List disk snapshots",
@@ -608,31 +895,37 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "string",
 						"description": "GCP project ID",
 					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
 				},
 			},
 		},
 		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			args := parseArgs(req)
 
-			result, err := base.Executor.Command("compute", "snapshots", "list").
-				WithProject(services.GetOptionalString(args, "project", "")).
-				Execute(ctx)
+			cmd := base.Executor.Command("compute", "snapshots", "list").
+				WithProject(services.GetOptionalString(args, "project", ""))
+
+			if err := services.ApplyListOutput(cmd, args, csvColumns["snapshots"]); err != nil {
+				return services.ToolError(err), nil
+			}
 
+			result, err := cmd.Execute(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Create snapshot
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_compute_disks_snapshot",
 			Description: "Create a snapshot of a disk",
 			InputSchema: map[string]any{
 				"type":     "object",
-				"required": []string{"disk", "zone", "snapshot_name"},
+				"required": []string{"disk", "snapshot_name"},
 				"properties": map[string]any{
 					"disk": map[string]any{
 						"type":        "string",
@@ -640,7 +933,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 					},
 					"zone": map[string]any{
 						"type":        "string",
-						"description": "Zone of the disk",
+						"description": "Zone of the disk (defaults to GCLOUD_COMPUTE_ZONE, then GCLOUD_ZONE)",
 					},
 					"snapshot_name": map[string]any{
 						"type":        "string",
@@ -659,7 +952,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			zone, err := services.GetRequiredString(args, "zone")
+			zone, err := requiredZone(base, args)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
@@ -677,18 +970,1335 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
-}
 
-func parseArgs(req *mcp.CallToolRequest) map[string]any {
-	var args map[string]any
-	if req.Params.Arguments != nil {
-		_ = json.Unmarshal(req.Params.Arguments, &args)
-	}
-	if args == nil {
-		args = make(map[string]any)
-	}
-	return args
+	// List regions
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_compute_regions_list",
+			Description: "List Compute Engine regions with their status (UP/DOWN) and quotas",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"filter": map[string]any{
+						"type":        "string",
+						"description": "Filter expression (e.g., 'name~^us-' or 'status=UP')",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+
+			cmd := locationsListCommand(base, "regions", args)
+
+			if err := services.ApplyListOutput(cmd, args, csvColumns["regions"]); err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// List zones
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_compute_zones_list",
+			Description: "List Compute Engine zones with their status (UP/DOWN)",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"filter": map[string]any{
+						"type":        "string",
+						"description": "Filter expression (e.g., 'region:us-central1' or 'status=UP')",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+
+			cmd := locationsListCommand(base, "zones", args)
+
+			if err := services.ApplyListOutput(cmd, args, csvColumns["zones"]); err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Describe project info
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_compute_project_info_describe",
+			Description: "Get project-wide Compute Engine settings and quotas. By default returns a summary of quota usage vs limit per metric, most used first",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"quotas_only": map[string]any{
+						"type":        "boolean",
+						"description": "Return only the quota usage summary instead of the full project info",
+						"default":     true,
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+
+			cmd := base.Executor.Command("compute", "project-info", "describe").
+				WithProject(services.GetOptionalString(args, "project", ""))
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			if !services.GetOptionalBool(args, "quotas_only", true) {
+				return base.CommandResult(result), nil
+			}
+			quotas, err := quotaUsages(result)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			data, _ := json.MarshalIndent(quotas, "", "  ")
+			return base.TextResult(string(data)), nil
+		},
+	)
+
+	// Set scheduling
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_compute_instances_set_scheduling",
+			Description: "Change scheduling options of a VM instance (provisioning model, preemptibility, restart and maintenance policy). Changing the provisioning model requires the instance to be stopped.",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"instance"},
+				"properties": map[string]any{
+					"instance": map[string]any{
+						"type":        "string",
+						"description": "Instance name",
+					},
+					"zone": map[string]any{
+						"type":        "string",
+						"description": "Zone of the instance (defaults to GCLOUD_COMPUTE_ZONE, then GCLOUD_ZONE)",
+					},
+					"provisioning_model": map[string]any{
+						"type":        "string",
+						"description": "Provisioning model",
+						"enum":        []string{"STANDARD", "SPOT"},
+					},
+					"instance_termination_action": map[string]any{
+						"type":        "string",
+						"description": "Action taken when a Spot VM is preempted",
+						"enum":        []string{"STOP", "DELETE"},
+					},
+					"preemptible": map[string]any{
+						"type":        "boolean",
+						"description": "Make the instance preemptible",
+					},
+					"restart_on_failure": map[string]any{
+						"type":        "boolean",
+						"description": "Automatically restart the instance if it is terminated by Compute Engine",
+					},
+					"maintenance_policy": map[string]any{
+						"type":        "string",
+						"description": "Behavior during host maintenance events",
+						"enum":        []string{"MIGRATE", "TERMINATE"},
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := setSchedulingCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.ExecuteWithZone(ctx)
+			if err != nil {
+				if result != nil && requiresStoppedInstance(result.Stderr) {
+					return services.ToolError(fmt.Errorf("instance %s must be stopped before changing its scheduling; stop it with gcp_compute_instances_stop and retry\n%w",
+						services.GetOptionalString(args, "instance", ""), err)), nil
+				}
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Update instance
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_compute_instances_update",
+			Description: "Update the labels and deletion protection of a VM instance without restarting it",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"instance"},
+				"properties": map[string]any{
+					"instance": map[string]any{
+						"type":        "string",
+						"description": "Instance name",
+					},
+					"zone": map[string]any{
+						"type":        "string",
+						"description": "Zone of the instance (defaults to GCLOUD_COMPUTE_ZONE, then GCLOUD_ZONE)",
+					},
+					"update_labels": map[string]any{
+						"type":        "object",
+						"description": "Labels to add or update",
+					},
+					"remove_labels": map[string]any{
+						"type":        "array",
+						"items":       map[string]any{"type": "string"},
+						"description": "Label keys to remove",
+					},
+					"deletion_protection": map[string]any{
+						"type":        "boolean",
+						"description": "Enable or disable deletion protection",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := instanceUpdateCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Export instance configuration
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_compute_instances_export",
+			Description: "Export a VM instance's configuration as YAML, for use with gcp_compute_instances_import",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"instance"},
+				"properties": map[string]any{
+					"instance": map[string]any{
+						"type":        "string",
+						"description": "Instance name",
+					},
+					"zone": map[string]any{
+						"type":        "string",
+						"description": "Zone of the instance (defaults to GCLOUD_COMPUTE_ZONE, then GCLOUD_ZONE)",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := exportCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Import instance configuration
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_compute_instances_import",
+			Description: "Create a VM instance from YAML configuration, such as the output of gcp_compute_instances_export",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"instance"},
+				"properties": map[string]any{
+					"instance": map[string]any{
+						"type":        "string",
+						"description": "Name of the instance to create",
+					},
+					"zone": map[string]any{
+						"type":        "string",
+						"description": "Zone to create the instance in (defaults to GCLOUD_COMPUTE_ZONE, then GCLOUD_ZONE)",
+					},
+					"yaml": map[string]any{
+						"type":        "string",
+						"description": "Instance configuration YAML (passed on stdin). Mutually exclusive with source_file",
+					},
+					"source_file": map[string]any{
+						"type":        "string",
+						"description": "Local YAML file containing the instance configuration",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := importCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			if result.Stdout == "" {
+				return services.ToolResult("Instance imported successfully"), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	registerLoadBalancingTools(server, base)
+	registerRouterTools(server, base)
+	registerOperationTools(server, base)
+}
+
+func parseArgs(req *mcp.CallToolRequest) map[string]any {
+	var args map[string]any
+	if req.Params.Arguments != nil {
+		_ = json.Unmarshal(req.Params.Arguments, &args)
+	}
+	if args == nil {
+		args = make(map[string]any)
+	}
+	return args
+}
+
+// csvColumns are the default column projections used when a list tool is
+// called with output=csv, keyed by resource.
+var csvColumns = map[string]string{
+	"instances": "name,zone.basename(),machineType.basename(),status,networkInterfaces[0].networkIP,networkInterfaces[0].accessConfigs[0].natIP",
+	"disks":     "name,zone.basename(),sizeGb,type.basename(),status",
+	"snapshots": "name,diskSizeGb,sourceDisk.basename(),status,creationTimestamp",
+	"regions":   "name,status",
+	"zones":     "name,region.basename(),status",
+
+	"forwarding-rules": "name,region.basename(),IPAddress,IPProtocol,portRange,target.basename()",
+	"target-pools":     "name,region.basename(),sessionAffinity,healthChecks[0].basename()",
+	"ssl-certificates": "name,type,managed.status,subjectAlternativeNames.list(),expireTime",
+	"url-maps":         "name,defaultService.basename()",
+	"backend-services": "name,protocol,loadBalancingScheme,backends[].group.basename().list()",
+	"backend-buckets":  "name,bucketName,enableCdn,cdnPolicy.cacheMode",
+	"routers":          "name,region.basename(),network.basename(),nats[].name.list()",
+	"health-checks":    "name,type,region.basename(),checkIntervalSec,timeoutSec",
+	"operations":       "name,operationType,targetLink.basename(),status,insertTime,zone.basename(),region.basename()",
+}
+
+// locationsListCommand builds a `compute regions list` or `compute zones list` command.
+func locationsListCommand(base *services.BaseService, resource string, args map[string]any) *executor.CommandBuilder {
+	cmd := base.Executor.Command("compute", resource, "list").
+		WithProject(services.GetOptionalString(args, "project", ""))
+
+	if filter := services.GetOptionalString(args, "filter", ""); filter != "" {
+		cmd.WithFlag("filter", filter)
+	}
+	return cmd
+}
+
+// setSchedulingCommand builds the `compute instances set-scheduling` command.
+func setSchedulingCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	instance, err := services.GetRequiredString(args, "instance")
+	if err != nil {
+		return nil, err
+	}
+	zone, err := requiredZone(base, args)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := base.Executor.Command("compute", "instances", "set-scheduling", instance).
+		WithZone(zone).
+		WithProject(services.GetOptionalString(args, "project", ""))
+
+	if model := services.GetOptionalString(args, "provisioning_model", ""); model != "" {
+		model = strings.ToUpper(model)
+		if model != "STANDARD" && model != "SPOT" {
+			return nil, fmt.Errorf("invalid provisioning_model %q: must be STANDARD or SPOT", model)
+		}
+		cmd.WithFlag("provisioning-model", model)
+	}
+	if action := services.GetOptionalString(args, "instance_termination_action", ""); action != "" {
+		cmd.WithFlag("instance-termination-action", strings.ToUpper(action))
+	}
+	if services.GetOptionalBool(args, "preemptible", false) {
+		cmd.WithBoolFlag("preemptible")
+	}
+	if _, ok := args["restart_on_failure"]; ok {
+		if services.GetOptionalBool(args, "restart_on_failure", true) {
+			cmd.WithBoolFlag("restart-on-failure")
+		} else {
+			cmd.WithBoolFlag("no-restart-on-failure")
+		}
+	}
+	if policy := services.GetOptionalString(args, "maintenance_policy", ""); policy != "" {
+		cmd.WithFlag("maintenance-policy", strings.ToUpper(policy))
+	}
+	return cmd, nil
+}
+
+// instanceUpdateCommand builds the `compute instances update` command.
+// Deletion protection is only changed when deletion_protection is given.
+func instanceUpdateCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	instance, err := services.GetRequiredString(args, "instance")
+	if err != nil {
+		return nil, err
+	}
+	zone, err := requiredZone(base, args)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := base.Executor.Command("compute", "instances", "update", instance).
+		WithFlag("zone", zone).
+		WithProject(services.GetOptionalString(args, "project", ""))
+
+	updated := false
+	if labels := services.GetOptionalStringMap(args, "update_labels"); len(labels) > 0 {
+		cmd.WithFlag("update-labels", services.FormatLabels(labels))
+		updated = true
+	}
+	if keys := services.GetOptionalStringArray(args, "remove_labels"); len(keys) > 0 {
+		cmd.WithFlag("remove-labels", strings.Join(keys, ","))
+		updated = true
+	}
+	if _, ok := args["deletion_protection"]; ok {
+		if services.GetOptionalBool(args, "deletion_protection", false) {
+			cmd.WithBoolFlag("deletion-protection")
+		} else {
+			cmd.WithBoolFlag("no-deletion-protection")
+		}
+		updated = true
+	}
+
+	if !updated {
+		return nil, fmt.Errorf("no updates specified")
+	}
+	return cmd, nil
+}
+
+// exportCommand builds the `compute instances export` command, which prints
+// the instance configuration as YAML.
+func exportCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	instance, err := services.GetRequiredString(args, "instance")
+	if err != nil {
+		return nil, err
+	}
+	zone, err := requiredZone(base, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return base.Executor.Command("compute", "instances", "export", instance).
+		WithFlag("zone", zone).
+		WithProject(services.GetOptionalString(args, "project", "")).
+		WithTextFormat(), nil
+}
+
+// importCommand builds the `compute instances import` command. Inline YAML
+// is written to stdin, which gcloud reads when --source is omitted.
+func importCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	instance, err := services.GetRequiredString(args, "instance")
+	if err != nil {
+		return nil, err
+	}
+	zone, err := requiredZone(base, args)
+	if err != nil {
+		return nil, err
+	}
+
+	yaml := services.GetOptionalString(args, "yaml", "")
+	source := services.GetOptionalString(args, "source_file", "")
+	if (yaml == "") == (source == "") {
+		return nil, fmt.Errorf("exactly one of yaml or source_file is required")
+	}
+
+	cmd := base.Executor.Command("compute", "instances", "import", instance).
+		WithFlag("zone", zone).
+		WithFlag("source", source).
+		WithProject(services.GetOptionalString(args, "project", "")).
+		WithTextFormat()
+
+	if yaml != "" {
+		cmd.WithStdin([]byte(yaml))
+	}
+	return cmd, nil
+}
+
+// requiresStoppedInstance reports whether gcloud rejected a change because the instance is running.
+func requiresStoppedInstance(stderr string) bool {
+	stderr = strings.ToLower(stderr)
+	return strings.Contains(stderr, "must be stopped") ||
+		strings.Contains(stderr, "terminated state") ||
+		strings.Contains(stderr, "instance is running")
+}
+
+// requiredZone returns the zone argument, falling back to the configured
+// Compute Engine zone (GCLOUD_COMPUTE_ZONE) and then the default zone.
+func requiredZone(base *services.BaseService, args map[string]any) (string, error) {
+	zone := services.GetOptionalString(args, "zone", base.Config.ComputeZone)
+	if zone == "" {
+		zone = base.Config.Zone
+	}
+	if zone == "" {
+		return "", fmt.Errorf("zone is required (pass zone or set GCLOUD_COMPUTE_ZONE or GCLOUD_ZONE)")
+	}
+	return zone, nil
+}
+
+// resetWindowsPasswordCommand builds the `compute reset-windows-password`
+// command. The command prints a password, so it is refused unless sensitive
+// output has been allowed.
+func resetWindowsPasswordCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	if err := base.RequireSensitiveOutput("gcp_compute_instances_reset_windows_password"); err != nil {
+		return nil, err
+	}
+	instance, err := services.GetRequiredString(args, "instance")
+	if err != nil {
+		return nil, err
+	}
+	zone, err := requiredZone(base, args)
+	if err != nil {
+		return nil, err
+	}
+	user, err := services.GetRequiredString(args, "user")
+	if err != nil {
+		return nil, err
+	}
+
+	return base.Executor.Command("compute", "reset-windows-password", instance).
+		WithFlag("zone", zone).
+		WithFlag("user", user).
+		WithProject(services.GetOptionalString(args, "project", "")).
+		WithBoolFlag("quiet"), nil
+}
+
+// simulateMaintenanceCommand builds the `compute instances
+// simulate-maintenance-event` command.
+func simulateMaintenanceCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	instance, err := services.GetRequiredString(args, "instance")
+	if err != nil {
+		return nil, err
+	}
+	zone, err := requiredZone(base, args)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := base.Executor.Command("compute", "instances", "simulate-maintenance-event", instance).
+		WithFlag("zone", zone).
+		WithProject(services.GetOptionalString(args, "project", ""))
+	if services.GetOptionalBool(args, "async", false) {
+		cmd.WithBoolFlag("async")
+	}
+	return cmd, nil
+}
+
+// effectiveFirewallsCommand builds the `compute instances network-interfaces
+// get-effective-firewalls` command.
+func effectiveFirewallsCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	instance, err := services.GetRequiredString(args, "instance")
+	if err != nil {
+		return nil, err
+	}
+	zone, err := requiredZone(base, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return base.Executor.Command("compute", "instances", "network-interfaces", "get-effective-firewalls", instance).
+		WithFlag("zone", zone).
+		WithFlag("network-interface", services.GetOptionalString(args, "network_interface", "nic0")).
+		WithProject(services.GetOptionalString(args, "project", "")), nil
+}
+
+// guestAttributesCommand builds the `compute instances get-guest-attributes`
+// command.
+func guestAttributesCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	instance, err := services.GetRequiredString(args, "instance")
+	if err != nil {
+		return nil, err
+	}
+	zone, err := requiredZone(base, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return base.Executor.Command("compute", "instances", "get-guest-attributes", instance).
+		WithFlag("zone", zone).
+		WithFlag("query-path", services.GetOptionalString(args, "query_path", "")).
+		WithProject(services.GetOptionalString(args, "project", "")), nil
+}
+
+// instanceConfigProperties returns the machine, image, disk, network and
+// metadata properties shared by the instance create tools, merged with the
+// tool-specific properties.
+func instanceConfigProperties(properties map[string]any) map[string]any {
+	shared := map[string]any{
+		"machine_type": map[string]any{
+			"type":        "string",
+			"description": "Machine type (e.g., e2-micro, n1-standard-1). Defaults to e2-micro unless custom_cpu and custom_memory are given, and cannot be combined with them",
+		},
+		"custom_cpu": map[string]any{
+			"type":        "integer",
+			"description": "Number of vCPUs of a custom machine type (requires custom_memory)",
+		},
+		"custom_memory": map[string]any{
+			"type":        "string",
+			"description": "Memory of a custom machine type (e.g., 6GB, 7680MB; requires custom_cpu)",
+		},
+		"custom_vm_type": map[string]any{
+			"type":        "string",
+			"description": "Machine family of a custom machine type (e.g., n2, n2d, e2); gcloud's default family when empty",
+		},
+		"custom_extensions": map[string]any{
+			"type":        "boolean",
+			"description": "Allow custom_memory beyond the family's per-vCPU limit (extended memory)",
+		},
+		"image_family": map[string]any{
+			"type":        "string",
+			"description": "Image family (e.g., debian-11, ubuntu-2204-lts). Defaults to debian-11",
+		},
+		"image_project": map[string]any{
+			"type":        "string",
+			"description": "Image project. Defaults to debian-cloud",
+		},
+		"boot_disk_size": map[string]any{
+			"type":        "string",
+			"description": "Boot disk size (e.g., 10GB, 50GB)",
+		},
+		"boot_disk_type": map[string]any{
+			"type":        "string",
+			"description": "Boot disk type (pd-standard, pd-ssd, pd-balanced)",
+		},
+		"network": map[string]any{
+			"type":        "string",
+			"description": "Network name",
+		},
+		"subnet": map[string]any{
+			"type":        "string",
+			"description": "Subnet name",
+		},
+		"no_external_ip": map[string]any{
+			"type":        "boolean",
+			"description": "Create the instance without an external IP address, reachable only from inside the VPC",
+		},
+		"network_interfaces": map[string]any{
+			"type":        "array",
+			"description": "Network interfaces of a multi-NIC instance, each in a different VPC network. Cannot be combined with network, subnet or no_external_ip",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"network": map[string]any{
+						"type":        "string",
+						"description": "Network name",
+					},
+					"subnet": map[string]any{
+						"type":        "string",
+						"description": "Subnet name",
+					},
+					"private_network_ip": map[string]any{
+						"type":        "string",
+						"description": "Internal IP address to assign",
+					},
+					"address": map[string]any{
+						"type":        "string",
+						"description": "Reserved external IP address to assign",
+					},
+					"no_external_ip": map[string]any{
+						"type":        "boolean",
+						"description": "Give this interface no external IP address",
+					},
+					"nic_type": map[string]any{
+						"type":        "string",
+						"description": "Virtual NIC type",
+						"enum":        []string{"GVNIC", "VIRTIO_NET"},
+					},
+				},
+			},
+		},
+		"service_account": map[string]any{
+			"type":        "string",
+			"description": "Service account email",
+		},
+		"scopes": map[string]any{
+			"type":        "array",
+			"description": "API scopes",
+			"items":       map[string]any{"type": "string"},
+		},
+		"tags": map[string]any{
+			"type":        "array",
+			"description": "Network tags",
+			"items":       map[string]any{"type": "string"},
+		},
+		"labels": map[string]any{
+			"type":        "object",
+			"description": "Labels",
+		},
+		"metadata": map[string]any{
+			"type":        "object",
+			"description": "Metadata key-value pairs",
+		},
+		"preemptible": map[string]any{
+			"type":        "boolean",
+			"description": "Use preemptible VM",
+		},
+		"shielded_secure_boot": map[string]any{
+			"type":        "boolean",
+			"description": "Enable Shielded VM Secure Boot",
+		},
+		"shielded_vtpm": map[string]any{
+			"type":        "boolean",
+			"description": "Enable the Shielded VM virtual Trusted Platform Module",
+		},
+		"shielded_integrity_monitoring": map[string]any{
+			"type":        "boolean",
+			"description": "Enable Shielded VM integrity monitoring",
+		},
+		"disks": map[string]any{
+			"type":        "array",
+			"description": "Additional data disks to create and attach",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"name": map[string]any{
+						"type":        "string",
+						"description": "Disk name (defaults to a generated name)",
+					},
+					"size": map[string]any{
+						"type":        []string{"string", "integer"},
+						"description": "Disk size (e.g., 100GB, or a whole number of GB)",
+					},
+					"type": map[string]any{
+						"type":        "string",
+						"description": "Disk type (pd-standard, pd-ssd, pd-balanced)",
+					},
+					"auto_delete": map[string]any{
+						"type":        "boolean",
+						"description": "Delete the disk when the instance is deleted",
+					},
+				},
+			},
+		},
+		"confidential_compute": map[string]any{
+			"type":        "boolean",
+			"description": "Create a Confidential VM (requires a supported machine type such as n2d; sets the maintenance policy to TERMINATE)",
+		},
+		"accelerator_type": map[string]any{
+			"type":        "string",
+			"description": "GPU type to attach (e.g., nvidia-tesla-t4, nvidia-l4). Sets the maintenance policy to TERMINATE",
+		},
+		"accelerator_count": map[string]any{
+			"type":        "number",
+			"description": "Number of GPUs to attach (requires accelerator_type)",
+			"default":     1,
+		},
+	}
+	for k, v := range properties {
+		shared[k] = v
+	}
+	return shared
+}
+
+// applyInstanceConfig adds the flags for the properties returned by
+// instanceConfigProperties to cmd.
+func applyInstanceConfig(base *services.BaseService, cmd *executor.CommandBuilder, args map[string]any) error {
+	if err := applyMachineType(cmd, args); err != nil {
+		return err
+	}
+	cmd.WithFlag("image-family", services.GetOptionalString(args, "image_family", "debian-11"))
+	cmd.WithFlag("image-project", services.GetOptionalString(args, "image_project", "debian-cloud"))
+
+	if bootDiskSize := services.GetOptionalString(args, "boot_disk_size", ""); bootDiskSize != "" {
+		cmd.WithFlag("boot-disk-size", bootDiskSize)
+	}
+	if bootDiskType := services.GetOptionalString(args, "boot_disk_type", ""); bootDiskType != "" {
+		cmd.WithFlag("boot-disk-type", bootDiskType)
+	}
+	if err := applyNetworkInterfaces(cmd, args); err != nil {
+		return err
+	}
+	if sa := services.GetOptionalString(args, "service_account", ""); sa != "" {
+		cmd.WithFlag("service-account", sa)
+	}
+	if scopes := services.GetOptionalStringArray(args, "scopes"); len(scopes) > 0 {
+		cmd.WithFlag("scopes", strings.Join(scopes, ","))
+	}
+	if tags := services.GetOptionalStringArray(args, "tags"); len(tags) > 0 {
+		cmd.WithFlag("tags", strings.Join(tags, ","))
+	}
+	if labels := base.Labels(args); labels != "" {
+		cmd.WithFlag("labels", labels)
+	}
+	if metadata := services.GetOptionalStringMap(args, "metadata"); len(metadata) > 0 {
+		var pairs []string
+		for k, v := range metadata {
+			pairs = append(pairs, fmt.Sprintf("%s=%s", k, v))
+		}
+		cmd.WithFlag("metadata", strings.Join(pairs, ","))
+	}
+	if services.GetOptionalBool(args, "preemptible", false) {
+		cmd.WithBoolFlag("preemptible")
+	}
+
+	// Shielded VM options are only passed when set so the image defaults
+	// apply otherwise
+	for _, option := range []struct{ arg, flag string }{
+		{"shielded_secure_boot", "shielded-secure-boot"},
+		{"shielded_vtpm", "shielded-vtpm"},
+		{"shielded_integrity_monitoring", "shielded-integrity-monitoring"},
+	} {
+		if _, ok := args[option.arg]; !ok {
+			continue
+		}
+		if services.GetOptionalBool(args, option.arg, false) {
+			cmd.WithBoolFlag(option.flag)
+		} else {
+			cmd.WithBoolFlag("no-" + option.flag)
+		}
+	}
+
+	// Confidential VMs can't live migrate
+	if services.GetOptionalBool(args, "confidential_compute", false) {
+		cmd.WithBoolFlag("confidential-compute")
+		cmd.WithFlag("maintenance-policy", "TERMINATE")
+	}
+
+	accelerator, err := acceleratorFlag(args)
+	if err != nil {
+		return err
+	}
+	if accelerator != "" {
+		// GPU instances can't live migrate
+		cmd.WithFlag("accelerator", accelerator)
+		cmd.WithFlag("maintenance-policy", "TERMINATE")
+	}
+
+	disks, err := createDiskFlags(args)
+	if err != nil {
+		return err
+	}
+	for _, disk := range disks {
+		cmd.WithArrayFlag("create-disk", disk)
+	}
+	return nil
+}
+
+// applyMachineType adds either --machine-type or, when custom_cpu and
+// custom_memory are given, the --custom-* flags that describe a custom
+// machine type to cmd.
+func applyMachineType(cmd *executor.CommandBuilder, args map[string]any) error {
+	cpu := services.GetOptionalInt(args, "custom_cpu", 0)
+	memory := services.GetOptionalString(args, "custom_memory", "")
+	family := services.GetOptionalString(args, "custom_vm_type", "")
+	extensions := services.GetOptionalBool(args, "custom_extensions", false)
+
+	if cpu == 0 && memory == "" {
+		if family != "" || extensions {
+			return fmt.Errorf("custom_vm_type and custom_extensions require custom_cpu and custom_memory")
+		}
+		cmd.WithFlag("machine-type", services.GetOptionalString(args, "machine_type", "e2-micro"))
+		return nil
+	}
+
+	if services.GetOptionalString(args, "machine_type", "") != "" {
+		return fmt.Errorf("machine_type cannot be combined with custom_cpu or custom_memory")
+	}
+	if cpu < 1 || memory == "" {
+		return fmt.Errorf("custom_cpu and custom_memory must be given together, with custom_cpu of at least 1")
+	}
+	cmd.WithFlag("custom-cpu", fmt.Sprintf("%d", cpu)).
+		WithFlag("custom-memory", memory).
+		WithFlag("custom-vm-type", family)
+	if extensions {
+		cmd.WithBoolFlag("custom-extensions")
+	}
+	return nil
+}
+
+// acceleratorFlag returns the --accelerator value for the accelerator_type
+// and accelerator_count arguments, or an empty string when no GPU is requested.
+func acceleratorFlag(args map[string]any) (string, error) {
+	acceleratorType := services.GetOptionalString(args, "accelerator_type", "")
+	_, hasCount := args["accelerator_count"]
+	if acceleratorType == "" {
+		if hasCount {
+			return "", fmt.Errorf("accelerator_count requires accelerator_type")
+		}
+		return "", nil
+	}
+	if strings.ContainsAny(acceleratorType, ",=") {
+		return "", fmt.Errorf("accelerator_type must not contain ',' or '='")
+	}
+
+	count := services.GetOptionalInt(args, "accelerator_count", 1)
+	if count < 1 {
+		return "", fmt.Errorf("accelerator_count must be at least 1")
+	}
+	return fmt.Sprintf("type=%s,count=%d", acceleratorType, count), nil
+}
+
+// applyNetworkInterfaces adds either a --network-interface flag for each
+// entry of network_interfaces or the single-interface --network, --subnet and
+// --no-address flags to cmd. gcloud rejects mixing the two forms.
+func applyNetworkInterfaces(cmd *executor.CommandBuilder, args map[string]any) error {
+	nics, err := networkInterfaceFlags(args)
+	if err != nil {
+		return err
+	}
+	network := services.GetOptionalString(args, "network", "")
+	subnet := services.GetOptionalString(args, "subnet", "")
+	noExternalIP := services.GetOptionalBool(args, "no_external_ip", false)
+
+	if len(nics) > 0 {
+		if network != "" || subnet != "" || noExternalIP {
+			return fmt.Errorf("network_interfaces cannot be combined with network, subnet or no_external_ip; set them on each interface instead")
+		}
+		for _, nic := range nics {
+			cmd.WithArrayFlag("network-interface", nic)
+		}
+		return nil
+	}
+
+	cmd.WithFlag("network", network).
+		WithFlag("subnet", subnet)
+	if noExternalIP {
+		cmd.WithBoolFlag("no-address")
+	}
+	return nil
+}
+
+// networkInterfaceFlags returns a --network-interface value for each entry
+// of the network_interfaces argument, formatted as the comma-separated
+// key=value list gcloud expects.
+func networkInterfaceFlags(args map[string]any) ([]string, error) {
+	raw, ok := args["network_interfaces"].([]any)
+	if !ok {
+		return nil, nil
+	}
+
+	flags := make([]string, 0, len(raw))
+	for i, entry := range raw {
+		nic, ok := entry.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("network_interfaces[%d] must be an object", i)
+		}
+		if services.GetOptionalString(nic, "network", "") == "" && services.GetOptionalString(nic, "subnet", "") == "" {
+			return nil, fmt.Errorf("network_interfaces[%d] must set network or subnet", i)
+		}
+
+		var props []string
+		for _, key := range []string{"network", "subnet", "private_network_ip", "address", "nic_type"} {
+			value := services.GetOptionalString(nic, key, "")
+			if value == "" {
+				continue
+			}
+			if strings.ContainsAny(value, ",=") {
+				return nil, fmt.Errorf("network_interfaces[%d].%s must not contain ',' or '='", i, key)
+			}
+			props = append(props, strings.ReplaceAll(key, "_", "-")+"="+value)
+		}
+
+		if services.GetOptionalBool(nic, "no_external_ip", false) {
+			if services.GetOptionalString(nic, "address", "") != "" {
+				return nil, fmt.Errorf("network_interfaces[%d] cannot set both address and no_external_ip", i)
+			}
+			props = append(props, "no-address")
+		}
+		flags = append(flags, strings.Join(props, ","))
+	}
+	return flags, nil
+}
+
+// Container-Optimized OS image used for instances created with a
+// container_image.
+const (
+	cosImageFamily  = "cos-stable"
+	cosImageProject = "cos-cloud"
+)
+
+// instanceCreateCommand builds the `compute instances create` command, or
+// `compute instances create-with-container` when a container_image is given.
+// Containers need Container-Optimized OS, so the image then defaults to
+// cosImageFamily and other image families are rejected.
+func instanceCreateCommand(base *services.BaseService, instance, zone string, args map[string]any) (*executor.CommandBuilder, error) {
+	image := services.GetOptionalString(args, "container_image", "")
+	env := services.GetOptionalStringMap(args, "container_env")
+	containerArgs := services.GetOptionalStringArray(args, "container_args")
+
+	if image == "" {
+		if len(env) > 0 || len(containerArgs) > 0 {
+			return nil, fmt.Errorf("container_env and container_args require container_image")
+		}
+		cmd := base.Executor.Command("compute", "instances", "create", instance).
+			WithZone(zone).
+			WithProject(services.GetOptionalString(args, "project", ""))
+		if err := applyInstanceConfig(base, cmd, args); err != nil {
+			return nil, err
+		}
+		return cmd, nil
+	}
+
+	family := services.GetOptionalString(args, "image_family", cosImageFamily)
+	project := services.GetOptionalString(args, "image_project", cosImageProject)
+	if !strings.HasPrefix(family, "cos-") || project != cosImageProject {
+		return nil, fmt.Errorf("container_image runs on Container-Optimized OS; image_family must be a cos-* family from %s, got %s from %s", cosImageProject, family, project)
+	}
+	configArgs := maps.Clone(args)
+	configArgs["image_family"] = family
+	configArgs["image_project"] = project
+
+	cmd := base.Executor.Command("compute", "instances", "create-with-container", instance).
+		WithFlag("container-image", image).
+		WithZone(zone).
+		WithProject(services.GetOptionalString(args, "project", ""))
+	if err := applyInstanceConfig(base, cmd, configArgs); err != nil {
+		return nil, err
+	}
+
+	keys := slices.Sorted(maps.Keys(env))
+	for _, k := range keys {
+		if strings.Contains(env[k], ",") {
+			return nil, fmt.Errorf("container_env value of %s must not contain ','", k)
+		}
+		cmd.WithArrayFlag("container-env", k+"="+env[k])
+	}
+	for _, arg := range containerArgs {
+		cmd.WithArrayFlag("container-arg", arg)
+	}
+	return cmd, nil
+}
+
+// createDiskFlags returns a --create-disk value for each entry of the disks
+// argument, formatted as the comma-separated key=value list gcloud expects.
+func createDiskFlags(args map[string]any) ([]string, error) {
+	raw, ok := args["disks"].([]any)
+	if !ok {
+		return nil, nil
+	}
+
+	flags := make([]string, 0, len(raw))
+	for i, entry := range raw {
+		disk, ok := entry.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("disks[%d] must be an object", i)
+		}
+
+		var props []string
+		for _, key := range []string{"name", "type"} {
+			value := services.GetOptionalString(disk, key, "")
+			if value == "" {
+				continue
+			}
+			if strings.ContainsAny(value, ",=") {
+				return nil, fmt.Errorf("disks[%d].%s must not contain ',' or '='", i, key)
+			}
+			props = append(props, key+"="+value)
+		}
+
+		switch size := disk["size"].(type) {
+		case nil:
+		case float64:
+			if size <= 0 {
+				return nil, fmt.Errorf("disks[%d].size must be greater than 0", i)
+			}
+			if size != math.Trunc(size) {
+				return nil, fmt.Errorf("disks[%d].size must be a whole number of GB, got %v", i, size)
+			}
+			props = append(props, fmt.Sprintf("size=%dGB", int(size)))
+		case string:
+			if strings.ContainsAny(size, ",=") {
+				return nil, fmt.Errorf("disks[%d].size must not contain ',' or '='", i)
+			}
+			props = append(props, "size="+size)
+		default:
+			return nil, fmt.Errorf("disks[%d].size must be a number of GB or a size such as 100GB", i)
+		}
+
+		if autoDelete, ok := disk["auto_delete"].(bool); ok {
+			if autoDelete {
+				props = append(props, "auto-delete=yes")
+			} else {
+				props = append(props, "auto-delete=no")
+			}
+		}
+
+		if len(props) == 0 {
+			return nil, fmt.Errorf("disks[%d] must set at least one of name, size, type or auto_delete", i)
+		}
+		flags = append(flags, strings.Join(props, ","))
+	}
+	return flags, nil
+}
+
+// bulkCreateCommand builds the `compute instances bulk create` command.
+func bulkCreateCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	pattern, err := services.GetRequiredString(args, "name_pattern")
+	if err != nil {
+		return nil, err
+	}
+	if !strings.Contains(pattern, "#") {
+		return nil, fmt.Errorf("name_pattern %q must contain at least one # placeholder", pattern)
+	}
+	zone, err := requiredZone(base, args)
+	if err != nil {
+		return nil, err
+	}
+	count := services.GetOptionalInt(args, "count", 0)
+	if count <= 0 {
+		return nil, fmt.Errorf("count must be greater than 0")
+	}
+
+	cmd := base.Executor.Command("compute", "instances", "bulk", "create").
+		WithFlag("name-pattern", pattern).
+		WithFlag("count", fmt.Sprintf("%d", count)).
+		WithFlag("zone", zone).
+		WithProject(services.GetOptionalString(args, "project", ""))
+
+	if err := applyInstanceConfig(base, cmd, args); err != nil {
+		return nil, err
+	}
+	return cmd, nil
+}
+
+// checkInstanceRunning inspects `compute instances describe` output and
+// returns a friendly error when the instance is not running.
+func checkInstanceRunning(instance string, result *executor.Result) error {
+	var desc struct {
+		Status string `json:"status"`
+	}
+	if err := result.ParseJSON(&desc); err != nil {
+		return fmt.Errorf("failed to read status of instance %s: %w", instance, err)
+	}
+
+	switch desc.Status {
+	case "RUNNING":
+		return nil
+	case "TERMINATED", "STOPPED":
+		return fmt.Errorf("instance %s is %s; start it first with gcp_compute_instances_start", instance, desc.Status)
+	case "SUSPENDED":
+		return fmt.Errorf("instance %s is SUSPENDED; resume it first with gcloud compute instances resume %s", instance, instance)
+	case "PROVISIONING", "STAGING", "REPAIRING":
+		return fmt.Errorf("instance %s is %s; wait until it is RUNNING and retry", instance, desc.Status)
+	case "STOPPING":
+		return fmt.Errorf("instance %s is STOPPING; wait for it to stop, then start it with gcp_compute_instances_start", instance)
+	case "SUSPENDING":
+		return fmt.Errorf("instance %s is SUSPENDING; wait for it to be suspended, then resume it with gcloud compute instances resume %s", instance, instance)
+	default:
+		return fmt.Errorf("instance %s is not running (status %q)", instance, desc.Status)
+	}
+}
+
+// instanceAddresses holds the addresses and status of a VM instance.
+type instanceAddresses struct {
+	Zone       string `json:"zone"`
+	Status     string `json:"status"`
+	InternalIP string `json:"internalIP,omitempty"`
+	ExternalIP string `json:"externalIP,omitempty"`
+}
+
+// instanceIPs maps each instance in an instances list result to the IPs of
+// its primary network interface. Instances without an external IP have no
+// externalIP. When instances in different zones share a name, they are keyed
+// as zone/name.
+func instanceIPs(result *executor.Result) (map[string]instanceAddresses, error) {
+	var instances []struct {
+		Name              string `json:"name"`
+		Zone              string `json:"zone"`
+		Status            string `json:"status"`
+		NetworkInterfaces []struct {
+			NetworkIP     string `json:"networkIP"`
+			AccessConfigs []struct {
+				NatIP string `json:"natIP"`
+			} `json:"accessConfigs"`
+		} `json:"networkInterfaces"`
+	}
+	if err := result.ParseJSON(&instances); err != nil {
+		return nil, fmt.Errorf("failed to parse instances: %w", err)
+	}
+
+	counts := make(map[string]int, len(instances))
+	for _, inst := range instances {
+		counts[inst.Name]++
+	}
+
+	ips := make(map[string]instanceAddresses, len(instances))
+	for _, inst := range instances {
+		addrs := instanceAddresses{
+			Zone:   path.Base(inst.Zone),
+			Status: inst.Status,
+		}
+		if len(inst.NetworkInterfaces) > 0 {
+			nic := inst.NetworkInterfaces[0]
+			addrs.InternalIP = nic.NetworkIP
+			for _, ac := range nic.AccessConfigs {
+				if ac.NatIP != "" {
+					addrs.ExternalIP = ac.NatIP
+					break
+				}
+			}
+		}
+
+		key := inst.Name
+		if counts[inst.Name] > 1 {
+			key = addrs.Zone + "/" + inst.Name
+		}
+		ips[key] = addrs
+	}
+	return ips, nil
+}
+
+// instanceCost is the estimated cost of a running instance.
+type instanceCost struct {
+	Name        string  `json:"name"`
+	Zone        string  `json:"zone"`
+	MachineType string  `json:"machineType"`
+	HourlyUSD   float64 `json:"hourlyUSD"`
+}
+
+// costEstimate is the result of gcp_compute_instances_cost_estimate.
+// Instances whose machine type is not in hourlyPrices are listed in Unpriced
+// and left out of the totals.
+type costEstimate struct {
+	Instances      []instanceCost `json:"instances"`
+	Unpriced       []instanceCost `json:"unpriced,omitempty"`
+	NotRunning     int            `json:"notRunning"`
+	TotalHourlyUSD float64        `json:"totalHourlyUSD"`
+	MonthlyUSD     float64        `json:"monthlyUSD"`
+	Note           string         `json:"note"`
+}
+
+// instanceCostEstimate prices the running instances in an instances list
+// result with hourlyPrices. Instances that are not RUNNING do not incur
+// vCPU and memory charges and are only counted.
+func instanceCostEstimate(result *executor.Result) (*costEstimate, error) {
+	var instances []struct {
+		Name        string `json:"name"`
+		Zone        string `json:"zone"`
+		Status      string `json:"status"`
+		MachineType string `json:"machineType"`
+	}
+	if err := result.ParseJSON(&instances); err != nil {
+		return nil, fmt.Errorf("failed to parse instances: %w", err)
+	}
+
+	estimate := &costEstimate{Instances: []instanceCost{}, Note: priceTableNote}
+	var total float64
+	for _, inst := range instances {
+		if inst.Status != "RUNNING" {
+			estimate.NotRunning++
+			continue
+		}
+		cost := instanceCost{
+			Name:        inst.Name,
+			Zone:        path.Base(inst.Zone),
+			MachineType: path.Base(inst.MachineType),
+		}
+		price, ok := hourlyPrices[cost.MachineType]
+		if !ok {
+			estimate.Unpriced = append(estimate.Unpriced, cost)
+			continue
+		}
+		cost.HourlyUSD = price
+		total += price
+		estimate.Instances = append(estimate.Instances, cost)
+	}
+
+	estimate.TotalHourlyUSD = roundUSD(total, 4)
+	estimate.MonthlyUSD = roundUSD(total*hoursPerMonth, 2)
+	return estimate, nil
+}
+
+// roundUSD rounds a USD amount to the given number of decimal places.
+func roundUSD(amount float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(amount*scale) / scale
+}
+
+// quotaUsage is the usage of a single quota metric.
+type quotaUsage struct {
+	Metric  string  `json:"metric"`
+	Usage   float64 `json:"usage"`
+	Limit   float64 `json:"limit"`
+	Percent float64 `json:"percent"`
+}
+
+// quotaUsages extracts the quotas of a `compute project-info describe` (or
+// `compute regions describe`) result, sorted by the share of the limit in
+// use, highest first. Percent is rounded to one decimal and is 0 for metrics
+// with no limit.
+func quotaUsages(result *executor.Result) ([]quotaUsage, error) {
+	var info struct {
+		Quotas []quotaUsage `json:"quotas"`
+	}
+	if err := result.ParseJSON(&info); err != nil {
+		return nil, fmt.Errorf("failed to parse project info: %w", err)
+	}
+
+	quotas := info.Quotas
+	if quotas == nil {
+		quotas = []quotaUsage{}
+	}
+	for i, q := range quotas {
+		if q.Limit > 0 {
+			quotas[i].Percent = math.Round(q.Usage/q.Limit*1000) / 10
+		}
+	}
+	slices.SortStableFunc(quotas, func(a, b quotaUsage) int {
+		if c := cmp.Compare(b.Percent, a.Percent); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Metric, b.Metric)
+	})
+	return quotas, nil
 }
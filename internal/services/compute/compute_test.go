@@ -0,0 +1,1403 @@
+package compute
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"gcloud-go-mcp/internal/executor"
+	"gcloud-go-mcp/internal/executor/executortest"
+	"gcloud-go-mcp/internal/services"
+	"gcloud-go-mcp/internal/services/servicetest"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func newTestBase() *services.BaseService {
+	return services.NewBaseService(servicetest.NewConfig())
+}
+
+func TestRegisterTools(t *testing.T) {
+	server := mcp.NewServer(
+		&mcp.Implementation{
+			Name:    "test-server",
+			Version: "0.0.1",
+		},
+		&mcp.ServerOptions{},
+	)
+
+	// Should not panic
+	RegisterTools(server, newTestBase())
+}
+
+func TestLocationsListCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource string
+		args     map[string]any
+		want     []string
+		notWant  []string
+	}{
+		{
+			name:     "regions without filter",
+			resource: "regions",
+			args:     map[string]any{},
+			want:     []string{"compute", "regions", "list", "--project=test-project", "--format=json"},
+			notWant:  []string{"--filter="},
+		},
+		{
+			name:     "zones with filter and project",
+			resource: "zones",
+			args: map[string]any{
+				"project": "other-project",
+				"filter":  "status=UP",
+			},
+			want: []string{"compute", "zones", "list", "--project=other-project", "--filter=status=UP"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := locationsListCommand(newTestBase(), tt.resource, tt.args).Build()
+			for _, w := range tt.want {
+				if !slices.Contains(args, w) {
+					t.Errorf("expected %q in args, got %v", w, args)
+				}
+			}
+			for _, nw := range tt.notWant {
+				for _, arg := range args {
+					if strings.HasPrefix(arg, nw) {
+						t.Errorf("did not expect %q in args, got %v", nw, args)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSetSchedulingCommand_ProvisioningModel(t *testing.T) {
+	tests := []struct {
+		name    string
+		model   string
+		want    string
+		wantErr bool
+	}{
+		{name: "spot", model: "SPOT", want: "--provisioning-model=SPOT"},
+		{name: "lowercase spot", model: "spot", want: "--provisioning-model=SPOT"},
+		{name: "standard", model: "Standard", want: "--provisioning-model=STANDARD"},
+		{name: "invalid", model: "preemptible", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := setSchedulingCommand(newTestBase(), map[string]any{
+				"instance":           "vm-1",
+				"zone":               "us-east1-b",
+				"provisioning_model": tt.model,
+			})
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error for invalid provisioning model")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			args := cmd.Build()
+			if !slices.Contains(args, tt.want) {
+				t.Errorf("expected %q in args, got %v", tt.want, args)
+			}
+		})
+	}
+}
+
+func TestSetSchedulingCommand_RestartOnFailure(t *testing.T) {
+	base := newTestBase()
+
+	cmd, err := setSchedulingCommand(base, map[string]any{
+		"instance":           "vm-1",
+		"zone":               "us-east1-b",
+		"restart_on_failure": false,
+		"preemptible":        true,
+		"maintenance_policy": "terminate",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	args := cmd.Build()
+	for _, want := range []string{"--no-restart-on-failure", "--preemptible", "--maintenance-policy=TERMINATE"} {
+		if !slices.Contains(args, want) {
+			t.Errorf("expected %q in args, got %v", want, args)
+		}
+	}
+
+	cmd, _ = setSchedulingCommand(base, map[string]any{"instance": "vm-1", "zone": "us-east1-b"})
+	for _, arg := range cmd.Build() {
+		if strings.Contains(arg, "restart-on-failure") {
+			t.Errorf("expected no restart flag when unset, got %q", arg)
+		}
+	}
+}
+
+func TestRequiresStoppedInstance(t *testing.T) {
+	if !requiresStoppedInstance("ERROR: (gcloud.compute.instances.set-scheduling) Could not fetch resource:\n - Instance must be stopped before changing the provisioning model.") {
+		t.Error("expected stopped-instance error to be detected")
+	}
+	if requiresStoppedInstance("ERROR: PERMISSION_DENIED") {
+		t.Error("did not expect permission error to be detected")
+	}
+}
+
+func TestResetWindowsPasswordCommand(t *testing.T) {
+	args := map[string]any{
+		"instance": "win-1",
+		"zone":     "us-east1-b",
+		"user":     "admin",
+	}
+
+	if _, err := resetWindowsPasswordCommand(newTestBase(), args); err == nil ||
+		!strings.Contains(err.Error(), "GCLOUD_ALLOW_SENSITIVE_OUTPUT") {
+		t.Fatalf("expected sensitive output gating error, got %v", err)
+	}
+
+	base := newTestBase()
+	base.Config.AllowSensitiveOutput = true
+	cmd, err := resetWindowsPasswordCommand(base, args)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"compute", "reset-windows-password", "win-1", "--zone=us-east1-b", "--user=admin", "--quiet"}
+	built := cmd.Build()
+	for _, w := range want {
+		if !slices.Contains(built, w) {
+			t.Errorf("expected %q in args, got %v", w, built)
+		}
+	}
+
+	delete(args, "user")
+	if _, err := resetWindowsPasswordCommand(base, args); err == nil {
+		t.Error("expected error for missing user")
+	}
+}
+
+func TestSimulateMaintenanceCommand(t *testing.T) {
+	args := map[string]any{"instance": "web-1", "zone": "us-east1-b"}
+
+	cmd, err := simulateMaintenanceCommand(newTestBase(), args)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	built := cmd.Build()
+	if !slices.Equal(built[:4], []string{"compute", "instances", "simulate-maintenance-event", "web-1"}) {
+		t.Errorf("unexpected command %v", built)
+	}
+	if missing := executortest.Missing(built, "--zone=us-east1-b", "--project=test-project"); len(missing) > 0 {
+		t.Errorf("missing %v in %v", missing, built)
+	}
+	if slices.Contains(built, "--async") {
+		t.Errorf("did not expect --async in %v", built)
+	}
+
+	args["async"] = true
+	cmd, err = simulateMaintenanceCommand(newTestBase(), args)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if built := cmd.Build(); !slices.Contains(built, "--async") {
+		t.Errorf("expected --async in %v", built)
+	}
+
+	delete(args, "zone")
+	base := newTestBase()
+	base.Config.Zone = ""
+	if _, err := simulateMaintenanceCommand(base, args); err == nil {
+		t.Error("expected error for missing zone")
+	}
+}
+
+func TestEffectiveFirewallsCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "default interface",
+			args: map[string]any{"instance": "web-1"},
+			want: []string{"--zone=us-central1-a", "--network-interface=nic0", "--project=test-project"},
+		},
+		{
+			name: "explicit interface and zone",
+			args: map[string]any{"instance": "web-1", "zone": "europe-west1-b", "network_interface": "nic1", "project": "other-project"},
+			want: []string{"--zone=europe-west1-b", "--network-interface=nic1", "--project=other-project"},
+		},
+		{
+			name:    "missing instance",
+			args:    map[string]any{},
+			wantErr: "instance",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := effectiveFirewallsCommand(newTestBase(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if !slices.Equal(args[:5], []string{"compute", "instances", "network-interfaces", "get-effective-firewalls", "web-1"}) {
+				t.Errorf("unexpected command %v", args)
+			}
+			if missing := executortest.Missing(args, tt.want...); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, args)
+			}
+		})
+	}
+}
+
+func TestGuestAttributesCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "all attributes",
+			args:    map[string]any{"instance": "web-1"},
+			want:    []string{"--zone=us-central1-a", "--project=test-project"},
+			notWant: []string{"--query-path="},
+		},
+		{
+			name: "namespace",
+			args: map[string]any{"instance": "web-1", "zone": "us-east1-b", "query_path": "hostkeys/"},
+			want: []string{"--zone=us-east1-b", "--query-path=hostkeys/"},
+		},
+		{
+			name: "single key",
+			args: map[string]any{"instance": "web-1", "query_path": "hostkeys/ssh-ed25519"},
+			want: []string{"--query-path=hostkeys/ssh-ed25519"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := guestAttributesCommand(newTestBase(), tt.args)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if !slices.Equal(args[:4], []string{"compute", "instances", "get-guest-attributes", "web-1"}) {
+				t.Errorf("unexpected command %v", args)
+			}
+			if missing := executortest.Missing(args, tt.want...); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, args)
+			}
+			for _, prefix := range tt.notWant {
+				for _, arg := range args {
+					if strings.HasPrefix(arg, prefix) {
+						t.Errorf("unexpected %s in %v", arg, args)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRequiredZone(t *testing.T) {
+	tests := []struct {
+		name        string
+		zone        string
+		computeZone string
+		args        map[string]any
+		want        string
+		wantErr     bool
+	}{
+		{"default zone", "us-central1-a", "", map[string]any{}, "us-central1-a", false},
+		{"compute zone", "us-central1-a", "europe-west1-b", map[string]any{}, "europe-west1-b", false},
+		{"argument", "us-central1-a", "europe-west1-b", map[string]any{"zone": "asia-east1-a"}, "asia-east1-a", false},
+		{"none", "", "", map[string]any{}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := newTestBase()
+			base.Config.Zone = tt.zone
+			base.Config.ComputeZone = tt.computeZone
+
+			got, err := requiredZone(base, tt.args)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("requiredZone() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToolCall_InstancesStartComputeZone(t *testing.T) {
+	cfg := servicetest.NewConfig()
+	cfg.ComputeZone = "europe-west1-b"
+	runner := &executortest.Runner{Stdout: "{}"}
+	result := servicetest.CallToolWithConfig(t, cfg, RegisterTools, runner, "gcp_compute_instances_start", map[string]any{"instance": "web-1"})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+	if args := runner.LastArgs(); !slices.Contains(args, "--zone=europe-west1-b") {
+		t.Errorf("expected the compute zone in %v", args)
+	}
+}
+
+func TestToolCall_InstancesListNamesOnly(t *testing.T) {
+	runner := &executortest.Runner{Stdout: "web-1\nweb-2\n"}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_compute_instances_list", map[string]any{"names_only": true})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+
+	args := runner.LastArgs()
+	if !slices.Contains(args, "--format=value(name)") {
+		t.Errorf("expected a names-only projection in %v", args)
+	}
+	if slices.Contains(args, "--format=json") {
+		t.Errorf("expected no JSON format with names_only, got %v", args)
+	}
+	if text := servicetest.Text(result); text != "web-1\nweb-2\n" {
+		t.Errorf("expected one name per line, got %q", text)
+	}
+}
+
+func TestBulkCreateCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "defaults",
+			args: map[string]any{"name_pattern": "web-###", "count": float64(3), "zone": "us-east1-b"},
+			want: []string{
+				"compute", "instances", "bulk", "create",
+				"--name-pattern=web-###", "--count=3", "--zone=us-east1-b",
+				"--machine-type=e2-micro", "--image-family=debian-11", "--image-project=debian-cloud",
+			},
+		},
+		{
+			name: "machine and image parameters",
+			args: map[string]any{
+				"name_pattern":  "batch-##",
+				"count":         float64(10),
+				"zone":          "us-east1-b",
+				"machine_type":  "n2-standard-4",
+				"image_family":  "ubuntu-2204-lts",
+				"image_project": "ubuntu-os-cloud",
+				"preemptible":   true,
+				"tags":          []any{"http", "batch"},
+			},
+			want: []string{
+				"--count=10", "--machine-type=n2-standard-4", "--image-family=ubuntu-2204-lts",
+				"--image-project=ubuntu-os-cloud", "--preemptible", "--tags=http,batch",
+			},
+		},
+		{
+			name:    "zero count",
+			args:    map[string]any{"name_pattern": "web-###", "count": float64(0), "zone": "us-east1-b"},
+			wantErr: "count must be greater than 0",
+		},
+		{
+			name:    "missing count",
+			args:    map[string]any{"name_pattern": "web-###", "zone": "us-east1-b"},
+			wantErr: "count must be greater than 0",
+		},
+		{
+			name:    "pattern without placeholder",
+			args:    map[string]any{"name_pattern": "web", "count": float64(2), "zone": "us-east1-b"},
+			wantErr: "must contain at least one #",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := bulkCreateCommand(newTestBase(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			args := cmd.Build()
+			for _, w := range tt.want {
+				if !slices.Contains(args, w) {
+					t.Errorf("expected %q in args, got %v", w, args)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckInstanceRunning(t *testing.T) {
+	tests := []struct {
+		status  string
+		wantErr string
+	}{
+		{status: "RUNNING"},
+		{status: "TERMINATED", wantErr: "instance vm-1 is TERMINATED; start it first"},
+		{status: "SUSPENDED", wantErr: "instance vm-1 is SUSPENDED; resume it first with gcloud compute instances resume vm-1"},
+		{status: "STAGING", wantErr: "wait until it is RUNNING"},
+		{status: "STOPPING", wantErr: "wait for it to stop"},
+		{status: "SUSPENDING", wantErr: "then resume it"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			result := &executor.Result{
+				JSON: json.RawMessage(`{"name": "vm-1", "status": "` + tt.status + `"}`),
+			}
+			err := checkInstanceRunning("vm-1", result)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCheckInstanceRunning_NoJSON(t *testing.T) {
+	if err := checkInstanceRunning("vm-1", &executor.Result{}); err == nil {
+		t.Error("expected error when describe output is missing")
+	}
+}
+
+func TestToolCall_RegionsList(t *testing.T) {
+	runner := &executortest.Runner{}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_compute_regions_list", map[string]any{
+		"filter": "status=UP",
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+
+	args := runner.LastArgs()
+	if len(args) < 3 || !slices.Equal(args[:3], []string{"compute", "regions", "list"}) {
+		t.Errorf("unexpected command %v", args)
+	}
+	if missing := executortest.Missing(args, "--filter=status=UP", "--project=test-project", "--format=json"); len(missing) > 0 {
+		t.Errorf("missing %v in %v", missing, args)
+	}
+}
+
+func TestApplyInstanceConfig_SecurityOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		notWant []string
+	}{
+		{
+			name:    "unset",
+			args:    map[string]any{},
+			notWant: []string{"--shielded-secure-boot", "--no-shielded-vtpm", "--confidential-compute", "--maintenance-policy=TERMINATE"},
+		},
+		{
+			name: "shielded enabled",
+			args: map[string]any{
+				"shielded_secure_boot":          true,
+				"shielded_vtpm":                 true,
+				"shielded_integrity_monitoring": true,
+			},
+			want:    []string{"--shielded-secure-boot", "--shielded-vtpm", "--shielded-integrity-monitoring"},
+			notWant: []string{"--maintenance-policy=TERMINATE"},
+		},
+		{
+			name:    "shielded disabled",
+			args:    map[string]any{"shielded_vtpm": false, "shielded_integrity_monitoring": false},
+			want:    []string{"--no-shielded-vtpm", "--no-shielded-integrity-monitoring"},
+			notWant: []string{"--shielded-vtpm", "--no-shielded-secure-boot"},
+		},
+		{
+			name: "confidential implies terminate",
+			args: map[string]any{"confidential_compute": true, "machine_type": "n2d-standard-2"},
+			want: []string{"--confidential-compute", "--maintenance-policy=TERMINATE", "--machine-type=n2d-standard-2"},
+		},
+		{
+			name: "confidential and shielded",
+			args: map[string]any{"confidential_compute": true, "shielded_secure_boot": true},
+			want: []string{"--confidential-compute", "--maintenance-policy=TERMINATE", "--shielded-secure-boot"},
+		},
+		{
+			name:    "confidential disabled",
+			args:    map[string]any{"confidential_compute": false},
+			notWant: []string{"--confidential-compute", "--no-confidential-compute", "--maintenance-policy=TERMINATE"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := newTestBase()
+			cmd := base.Executor.Command("compute", "instances", "create", "vm-1")
+			if err := applyInstanceConfig(base, cmd, tt.args); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			for _, w := range tt.want {
+				if !slices.Contains(args, w) {
+					t.Errorf("expected %q in args, got %v", w, args)
+				}
+			}
+			for _, nw := range tt.notWant {
+				if slices.Contains(args, nw) {
+					t.Errorf("did not expect %q in args, got %v", nw, args)
+				}
+			}
+		})
+	}
+}
+
+func TestToolCall_InstancesListCSV(t *testing.T) {
+	runner := &executortest.Runner{Stdout: "name,zone,machine_type,status,network_ip,nat_ip\nweb-1,us-central1-a,e2-micro,RUNNING,10.128.0.2,34.1.2.3\n"}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_compute_instances_list", map[string]any{
+		"output": "csv",
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+
+	args := runner.LastArgs()
+	want := "--format=csv(name,zone.basename(),machineType.basename(),status,networkInterfaces[0].networkIP,networkInterfaces[0].accessConfigs[0].natIP)"
+	if !slices.Contains(args, want) {
+		t.Errorf("expected %q in %v", want, args)
+	}
+	if slices.Contains(args, "--format=json") {
+		t.Errorf("did not expect JSON format in %v", args)
+	}
+	if text := servicetest.Text(result); !strings.HasPrefix(text, "name,zone,") {
+		t.Errorf("expected CSV output, got %q", text)
+	}
+}
+
+func TestToolCall_InstancesListInvalidOutput(t *testing.T) {
+	runner := &executortest.Runner{}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_compute_instances_list", map[string]any{
+		"output": "xml",
+	})
+	if !result.IsError {
+		t.Fatal("expected an error result")
+	}
+	if len(runner.Calls()) != 0 {
+		t.Errorf("expected no gcloud calls, got %v", runner.Calls())
+	}
+}
+
+func TestInstanceIPs(t *testing.T) {
+	payload := `[
+		{
+			"name": "web-1",
+			"zone": "https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a",
+			"status": "RUNNING",
+			"networkInterfaces": [{"networkIP": "10.128.0.2", "accessConfigs": [{"name": "External NAT", "natIP": "34.1.2.3"}]}]
+		},
+		{
+			"name": "worker-1",
+			"zone": "https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-b",
+			"status": "RUNNING",
+			"networkInterfaces": [{"networkIP": "10.128.0.3"}]
+		},
+		{
+			"name": "stopped-1",
+			"zone": "https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a",
+			"status": "TERMINATED",
+			"networkInterfaces": [{"networkIP": "10.128.0.4", "accessConfigs": [{"name": "External NAT"}]}]
+		},
+		{"name": "db", "zone": "projects/p/zones/europe-west1-b", "status": "RUNNING", "networkInterfaces": [{"networkIP": "10.132.0.2"}]},
+		{"name": "db", "zone": "projects/p/zones/us-east1-c", "status": "RUNNING", "networkInterfaces": [{"networkIP": "10.142.0.2"}]}
+	]`
+
+	ips, err := instanceIPs(&executor.Result{JSON: json.RawMessage(payload)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string]instanceAddresses{
+		"web-1":             {Zone: "us-central1-a", Status: "RUNNING", InternalIP: "10.128.0.2", ExternalIP: "34.1.2.3"},
+		"worker-1":          {Zone: "us-central1-b", Status: "RUNNING", InternalIP: "10.128.0.3"},
+		"stopped-1":         {Zone: "us-central1-a", Status: "TERMINATED", InternalIP: "10.128.0.4"},
+		"europe-west1-b/db": {Zone: "europe-west1-b", Status: "RUNNING", InternalIP: "10.132.0.2"},
+		"us-east1-c/db":     {Zone: "us-east1-c", Status: "RUNNING", InternalIP: "10.142.0.2"},
+	}
+	if len(ips) != len(want) {
+		t.Fatalf("expected %d instances, got %v", len(want), ips)
+	}
+	for name, w := range want {
+		if got := ips[name]; got != w {
+			t.Errorf("%s: got %+v, want %+v", name, got, w)
+		}
+	}
+
+	data, _ := json.Marshal(ips["worker-1"])
+	if strings.Contains(string(data), "externalIP") {
+		t.Errorf("expected externalIP to be omitted, got %s", data)
+	}
+}
+
+func TestInstanceIPs_NoJSON(t *testing.T) {
+	if _, err := instanceIPs(&executor.Result{Stdout: "not json"}); err == nil {
+		t.Error("expected an error without JSON output")
+	}
+}
+
+func TestInstanceCostEstimate(t *testing.T) {
+	payload := `[
+		{
+			"name": "web-1",
+			"zone": "https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a",
+			"status": "RUNNING",
+			"machineType": "https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a/machineTypes/e2-medium"
+		},
+		{
+			"name": "web-2",
+			"zone": "https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-b",
+			"status": "RUNNING",
+			"machineType": "https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-b/machineTypes/e2-medium"
+		},
+		{
+			"name": "db",
+			"zone": "https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a",
+			"status": "RUNNING",
+			"machineType": "https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a/machineTypes/n2-standard-4"
+		},
+		{
+			"name": "batch",
+			"zone": "https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a",
+			"status": "TERMINATED",
+			"machineType": "https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a/machineTypes/n2-standard-32"
+		},
+		{
+			"name": "custom",
+			"zone": "https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a",
+			"status": "RUNNING",
+			"machineType": "https://www.googleapis.com/compute/v1/projects/p/zones/us-central1-a/machineTypes/e2-custom-4-8192"
+		}
+	]`
+	estimate, err := instanceCostEstimate(&executor.Result{JSON: json.RawMessage(payload)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(estimate.Instances) != 3 {
+		t.Fatalf("expected 3 priced instances, got %+v", estimate.Instances)
+	}
+	if got := estimate.Instances[2]; got.Name != "db" || got.Zone != "us-central1-a" || got.MachineType != "n2-standard-4" || got.HourlyUSD != 0.1942 {
+		t.Errorf("unexpected cost for db: %+v", got)
+	}
+	// 2 x e2-medium (0.0335) + n2-standard-4 (0.1942)
+	if estimate.TotalHourlyUSD != 0.2612 {
+		t.Errorf("expected total 0.2612 USD/hour, got %v", estimate.TotalHourlyUSD)
+	}
+	if estimate.MonthlyUSD != 190.68 {
+		t.Errorf("expected 190.68 USD/month, got %v", estimate.MonthlyUSD)
+	}
+	if estimate.NotRunning != 1 {
+		t.Errorf("expected 1 instance not running, got %d", estimate.NotRunning)
+	}
+	if len(estimate.Unpriced) != 1 || estimate.Unpriced[0].MachineType != "e2-custom-4-8192" {
+		t.Errorf("expected the custom machine type to be unpriced, got %+v", estimate.Unpriced)
+	}
+	if estimate.Note == "" {
+		t.Error("expected the estimate to say it is approximate")
+	}
+}
+
+func TestInstanceCostEstimate_NoInstances(t *testing.T) {
+	estimate, err := instanceCostEstimate(&executor.Result{JSON: json.RawMessage(`[]`)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(estimate.Instances) != 0 || estimate.TotalHourlyUSD != 0 || estimate.MonthlyUSD != 0 {
+		t.Errorf("expected an empty estimate, got %+v", estimate)
+	}
+}
+
+func TestQuotaUsages(t *testing.T) {
+	payload := `{
+		"name": "p",
+		"commonInstanceMetadata": {"items": [{"key": "enable-oslogin", "value": "TRUE"}]},
+		"quotas": [
+			{"metric": "SNAPSHOTS", "limit": 5000.0, "usage": 12.0},
+			{"metric": "NETWORKS", "limit": 15.0, "usage": 14.0},
+			{"metric": "FIREWALLS", "limit": 200.0, "usage": 3.0},
+			{"metric": "IMAGES", "limit": 2000.0, "usage": 0.0},
+			{"metric": "SECURITY_POLICIES", "limit": 0.0, "usage": 0.0},
+			{"metric": "GLOBAL_INTERNAL_ADDRESSES", "limit": 5000.0, "usage": 0.0}
+		]
+	}`
+
+	quotas, err := quotaUsages(&executor.Result{JSON: json.RawMessage(payload)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []quotaUsage{
+		{Metric: "NETWORKS", Usage: 14, Limit: 15, Percent: 93.3},
+		{Metric: "FIREWALLS", Usage: 3, Limit: 200, Percent: 1.5},
+		{Metric: "SNAPSHOTS", Usage: 12, Limit: 5000, Percent: 0.2},
+		{Metric: "GLOBAL_INTERNAL_ADDRESSES", Usage: 0, Limit: 5000},
+		{Metric: "IMAGES", Usage: 0, Limit: 2000},
+		{Metric: "SECURITY_POLICIES", Usage: 0, Limit: 0},
+	}
+	if !slices.Equal(quotas, want) {
+		t.Errorf("quotas = %+v, want %+v", quotas, want)
+	}
+}
+
+func TestQuotaUsages_NoQuotas(t *testing.T) {
+	quotas, err := quotaUsages(&executor.Result{JSON: json.RawMessage(`{"name": "p"}`)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if data, _ := json.Marshal(quotas); string(data) != "[]" {
+		t.Errorf("quotas = %s, want []", data)
+	}
+}
+
+func TestQuotaUsages_NoJSON(t *testing.T) {
+	if _, err := quotaUsages(&executor.Result{Stdout: "not json"}); err == nil {
+		t.Error("expected an error without JSON output")
+	}
+}
+
+func TestToolCall_ProjectInfoDescribe(t *testing.T) {
+	runner := &executortest.Runner{Stdout: `{"name": "p", "quotas": [{"metric": "CPUS_ALL_REGIONS", "limit": 32, "usage": 8}]}`}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_compute_project_info_describe", map[string]any{
+		"project": "other-project",
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+
+	args := runner.LastArgs()
+	if !slices.Equal(args[:3], []string{"compute", "project-info", "describe"}) {
+		t.Errorf("unexpected command %v", args)
+	}
+	if missing := executortest.Missing(args, "--project=other-project"); len(missing) > 0 {
+		t.Errorf("missing %v in %v", missing, args)
+	}
+	if got := servicetest.Text(result); !strings.Contains(got, `"percent": 25`) || strings.Contains(got, `"name"`) {
+		t.Errorf("expected a quota summary, got %s", got)
+	}
+}
+
+func TestCreateDiskFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		disks   any
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "two disks with different types",
+			disks: []any{
+				map[string]any{"name": "data", "size": "200GB", "type": "pd-ssd", "auto_delete": false},
+				map[string]any{"name": "scratch", "size": float64(50), "type": "pd-standard", "auto_delete": true},
+			},
+			want: []string{
+				"name=data,type=pd-ssd,size=200GB,auto-delete=no",
+				"name=scratch,type=pd-standard,size=50GB,auto-delete=yes",
+			},
+		},
+		{
+			name:  "size only",
+			disks: []any{map[string]any{"size": "10GB"}},
+			want:  []string{"size=10GB"},
+		},
+		{
+			name:    "empty entry",
+			disks:   []any{map[string]any{}},
+			wantErr: "at least one of",
+		},
+		{
+			name:    "comma in value",
+			disks:   []any{map[string]any{"name": "a,b"}},
+			wantErr: "must not contain",
+		},
+		{
+			name:    "non-positive size",
+			disks:   []any{map[string]any{"size": float64(0)}},
+			wantErr: "greater than 0",
+		},
+		{
+			name:    "fractional size",
+			disks:   []any{map[string]any{"size": 0.5}},
+			wantErr: "whole number of GB",
+		},
+		{
+			name:    "entry not an object",
+			disks:   []any{"data"},
+			wantErr: "must be an object",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := createDiskFlags(map[string]any{"disks": tt.disks})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestApplyInstanceConfig_CreateDisks(t *testing.T) {
+	base := newTestBase()
+	cmd := base.Executor.Command("compute", "instances", "create", "vm-1")
+	err := applyInstanceConfig(base, cmd, map[string]any{
+		"disks": []any{
+			map[string]any{"name": "data", "size": "200GB", "type": "pd-ssd"},
+			map[string]any{"name": "logs", "size": "50GB", "type": "pd-balanced"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	args := cmd.Build()
+	for _, w := range []string{
+		"--create-disk=name=data,type=pd-ssd,size=200GB",
+		"--create-disk=name=logs,type=pd-balanced,size=50GB",
+	} {
+		if !slices.Contains(args, w) {
+			t.Errorf("expected %q in args, got %v", w, args)
+		}
+	}
+}
+
+func TestExportCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "instance and zone",
+			args: map[string]any{"instance": "web-1", "zone": "europe-west1-b"},
+			want: []string{"--zone=europe-west1-b", "--project=test-project"},
+		},
+		{
+			name: "project override",
+			args: map[string]any{"instance": "web-1", "zone": "europe-west1-b", "project": "other"},
+			want: []string{"--zone=europe-west1-b", "--project=other"},
+		},
+		{
+			name: "zone defaults to config",
+			args: map[string]any{"instance": "web-1"},
+			want: []string{"--zone=us-central1-a"},
+		},
+		{
+			name:    "missing instance",
+			args:    map[string]any{"zone": "europe-west1-b"},
+			wantErr: "instance",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := exportCommand(newTestBase(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if !slices.Equal(args[:4], []string{"compute", "instances", "export", "web-1"}) {
+				t.Errorf("unexpected command %v", args)
+			}
+			if missing := executortest.Missing(args, tt.want...); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, args)
+			}
+			for _, arg := range args {
+				if strings.HasPrefix(arg, "--format=") {
+					t.Errorf("expected YAML output without --format, got %v", args)
+				}
+			}
+		})
+	}
+}
+
+func TestToolCall_InstancesImport(t *testing.T) {
+	const config = "name: web-1\nmachineType: e2-small\n"
+
+	runner := &executortest.Runner{}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_compute_instances_import", map[string]any{
+		"instance": "web-2",
+		"zone":     "us-central1-b",
+		"yaml":     config,
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+
+	args := runner.LastArgs()
+	if !slices.Equal(args[:4], []string{"compute", "instances", "import", "web-2"}) {
+		t.Errorf("unexpected command %v", args)
+	}
+	if !slices.Contains(args, "--zone=us-central1-b") {
+		t.Errorf("expected --zone=us-central1-b in %v", args)
+	}
+	if got := string(runner.LastStdin()); got != config {
+		t.Errorf("expected YAML on stdin, got %q", got)
+	}
+
+	result = servicetest.CallTool(t, RegisterTools, runner, "gcp_compute_instances_import", map[string]any{
+		"instance":    "web-2",
+		"zone":        "us-central1-b",
+		"yaml":        config,
+		"source_file": "web-1.yaml",
+	})
+	if !result.IsError {
+		t.Error("expected error when both yaml and source_file are set")
+	}
+}
+
+func TestApplyInstanceConfig_Accelerators(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		notWant []string
+		wantErr string
+	}{
+		{
+			name:    "no accelerator",
+			args:    map[string]any{},
+			notWant: []string{"--maintenance-policy=TERMINATE"},
+		},
+		{
+			name: "type with default count",
+			args: map[string]any{"accelerator_type": "nvidia-tesla-t4", "machine_type": "n1-standard-4"},
+			want: []string{"--accelerator=type=nvidia-tesla-t4,count=1", "--maintenance-policy=TERMINATE", "--machine-type=n1-standard-4"},
+		},
+		{
+			name: "type and count",
+			args: map[string]any{"accelerator_type": "nvidia-l4", "accelerator_count": float64(2)},
+			want: []string{"--accelerator=type=nvidia-l4,count=2", "--maintenance-policy=TERMINATE"},
+		},
+		{
+			name:    "count without type",
+			args:    map[string]any{"accelerator_count": float64(2)},
+			wantErr: "requires accelerator_type",
+		},
+		{
+			name:    "zero count",
+			args:    map[string]any{"accelerator_type": "nvidia-l4", "accelerator_count": float64(0)},
+			wantErr: "at least 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := newTestBase()
+			cmd := base.Executor.Command("compute", "instances", "create", "vm-1")
+			err := applyInstanceConfig(base, cmd, tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if missing := executortest.Missing(args, tt.want...); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, args)
+			}
+			for _, nw := range tt.notWant {
+				if slices.Contains(args, nw) {
+					t.Errorf("unexpected %q in %v", nw, args)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyInstanceConfig_CustomMachineType(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		notWant []string
+		wantErr string
+	}{
+		{
+			name:    "default machine type",
+			args:    map[string]any{},
+			want:    []string{"--machine-type=e2-micro"},
+			notWant: []string{"--custom-cpu"},
+		},
+		{
+			name:    "custom cpu and memory",
+			args:    map[string]any{"custom_cpu": float64(6), "custom_memory": "24GB"},
+			want:    []string{"--custom-cpu=6", "--custom-memory=24GB"},
+			notWant: []string{"--machine-type=e2-micro", "--custom-vm-type", "--custom-extensions"},
+		},
+		{
+			name: "family and extended memory",
+			args: map[string]any{"custom_cpu": float64(2), "custom_memory": "20GB", "custom_vm_type": "n2", "custom_extensions": true},
+			want: []string{"--custom-cpu=2", "--custom-memory=20GB", "--custom-vm-type=n2", "--custom-extensions"},
+		},
+		{
+			name:    "combined with machine type",
+			args:    map[string]any{"machine_type": "n2-standard-4", "custom_cpu": float64(6), "custom_memory": "24GB"},
+			wantErr: "cannot be combined",
+		},
+		{
+			name:    "cpu without memory",
+			args:    map[string]any{"custom_cpu": float64(6)},
+			wantErr: "must be given together",
+		},
+		{
+			name:    "memory without cpu",
+			args:    map[string]any{"custom_memory": "24GB"},
+			wantErr: "must be given together",
+		},
+		{
+			name:    "family without custom shape",
+			args:    map[string]any{"custom_vm_type": "n2"},
+			wantErr: "require custom_cpu and custom_memory",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := newTestBase()
+			cmd := base.Executor.Command("compute", "instances", "create", "vm-1")
+			err := applyInstanceConfig(base, cmd, tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if missing := executortest.Missing(args, tt.want...); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, args)
+			}
+			for _, prefix := range tt.notWant {
+				for _, arg := range args {
+					if strings.HasPrefix(arg, prefix) {
+						t.Errorf("unexpected %s in %v", arg, args)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestInstanceUpdateCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		notWant []string
+		wantErr string
+	}{
+		{
+			name: "labels",
+			args: map[string]any{
+				"instance":      "vm-1",
+				"update_labels": map[string]any{"env": "prod", "team": "web"},
+				"remove_labels": []any{"owner", "temp"},
+			},
+			want:    []string{"--update-labels=env=prod,team=web", "--remove-labels=owner,temp", "--zone=us-central1-a", "--project=test-project"},
+			notWant: []string{"--deletion-protection", "--no-deletion-protection"},
+		},
+		{
+			name: "enable deletion protection",
+			args: map[string]any{"instance": "vm-1", "deletion_protection": true},
+			want: []string{"--deletion-protection"},
+		},
+		{
+			name:    "disable deletion protection",
+			args:    map[string]any{"instance": "vm-1", "deletion_protection": false, "zone": "europe-west1-b"},
+			want:    []string{"--no-deletion-protection", "--zone=europe-west1-b"},
+			notWant: []string{"--deletion-protection"},
+		},
+		{
+			name:    "nothing to update",
+			args:    map[string]any{"instance": "vm-1"},
+			wantErr: "no updates specified",
+		},
+		{
+			name:    "missing instance",
+			args:    map[string]any{"deletion_protection": true},
+			wantErr: "instance",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := instanceUpdateCommand(newTestBase(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if !slices.Equal(args[:4], []string{"compute", "instances", "update", "vm-1"}) {
+				t.Errorf("unexpected command %v", args)
+			}
+			if missing := executortest.Missing(args, tt.want...); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, args)
+			}
+			for _, nw := range tt.notWant {
+				if slices.Contains(args, nw) {
+					t.Errorf("unexpected %q in %v", nw, args)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyInstanceConfig_NetworkInterfaces(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		notWant []string
+		wantErr string
+	}{
+		{
+			name:    "internal only",
+			args:    map[string]any{"network": "prod-vpc", "subnet": "prod-us-central1", "no_external_ip": true},
+			want:    []string{"--network=prod-vpc", "--subnet=prod-us-central1", "--no-address"},
+			notWant: []string{"--network-interface"},
+		},
+		{
+			name:    "external ip by default",
+			args:    map[string]any{"subnet": "prod-us-central1"},
+			want:    []string{"--subnet=prod-us-central1"},
+			notWant: []string{"--no-address"},
+		},
+		{
+			name: "two interfaces",
+			args: map[string]any{
+				"network_interfaces": []any{
+					map[string]any{"network": "frontend-vpc", "subnet": "frontend-us-central1"},
+					map[string]any{"subnet": "backend-us-central1", "private_network_ip": "10.1.0.5", "no_external_ip": true, "nic_type": "GVNIC"},
+				},
+			},
+			want: []string{
+				"--network-interface=network=frontend-vpc,subnet=frontend-us-central1",
+				"--network-interface=subnet=backend-us-central1,private-network-ip=10.1.0.5,nic-type=GVNIC,no-address",
+			},
+			notWant: []string{"--network=", "--subnet=", "--no-address"},
+		},
+		{
+			name: "interfaces combined with network",
+			args: map[string]any{
+				"network":            "prod-vpc",
+				"network_interfaces": []any{map[string]any{"network": "frontend-vpc"}},
+			},
+			wantErr: "cannot be combined",
+		},
+		{
+			name:    "interface without network",
+			args:    map[string]any{"network_interfaces": []any{map[string]any{"no_external_ip": true}}},
+			wantErr: "must set network or subnet",
+		},
+		{
+			name:    "address and no external ip",
+			args:    map[string]any{"network_interfaces": []any{map[string]any{"network": "frontend-vpc", "address": "34.1.2.3", "no_external_ip": true}}},
+			wantErr: "cannot set both",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := newTestBase()
+			cmd := base.Executor.Command("compute", "instances", "create", "vm-1")
+			err := applyInstanceConfig(base, cmd, tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if missing := executortest.Missing(args, tt.want...); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, args)
+			}
+			for _, prefix := range tt.notWant {
+				for _, arg := range args {
+					if strings.HasPrefix(arg, prefix) {
+						t.Errorf("unexpected %s in %v", arg, args)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestInstanceCreateCommand_Container(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		command []string
+		want    []string
+		notWant []string
+		wantErr string
+	}{
+		{
+			name:    "vm",
+			args:    map[string]any{},
+			command: []string{"compute", "instances", "create", "web-1"},
+			want:    []string{"--image-family=debian-11", "--image-project=debian-cloud"},
+			notWant: []string{"--container-"},
+		},
+		{
+			name: "container",
+			args: map[string]any{
+				"container_image": "us-docker.pkg.dev/test-project/app/web:v1",
+				"container_env":   map[string]any{"PORT": "8080", "MODE": "production"},
+				"container_args":  []any{"--workers", "4"},
+			},
+			command: []string{"compute", "instances", "create-with-container", "web-1"},
+			want: []string{
+				"--container-image=us-docker.pkg.dev/test-project/app/web:v1",
+				"--image-family=cos-stable", "--image-project=cos-cloud",
+				"--container-env=MODE=production", "--container-env=PORT=8080",
+				"--container-arg=--workers", "--container-arg=4",
+			},
+			notWant: []string{"--image-family=debian-11"},
+		},
+		{
+			name:    "container with cos family",
+			args:    map[string]any{"container_image": "nginx:1.27", "image_family": "cos-113-lts"},
+			command: []string{"compute", "instances", "create-with-container", "web-1"},
+			want:    []string{"--container-image=nginx:1.27", "--image-family=cos-113-lts", "--image-project=cos-cloud"},
+		},
+		{
+			name:    "container with debian",
+			args:    map[string]any{"container_image": "nginx:1.27", "image_family": "debian-12"},
+			wantErr: "Container-Optimized OS",
+		},
+		{
+			name:    "env without image",
+			args:    map[string]any{"container_env": map[string]any{"PORT": "8080"}},
+			wantErr: "require container_image",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := instanceCreateCommand(newTestBase(), "web-1", "us-central1-a", tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if !slices.Equal(args[:len(tt.command)], tt.command) {
+				t.Errorf("expected %v, got %v", tt.command, args)
+			}
+			if missing := executortest.Missing(args, tt.want...); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, args)
+			}
+			if tt.args["container_args"] != nil {
+				var containerArgs []string
+				for _, arg := range args {
+					if value, ok := strings.CutPrefix(arg, "--container-arg="); ok {
+						containerArgs = append(containerArgs, value)
+					}
+				}
+				if !slices.Equal(containerArgs, []string{"--workers", "4"}) {
+					t.Errorf("expected container args in order, got %v", containerArgs)
+				}
+			}
+			for _, prefix := range tt.notWant {
+				for _, arg := range args {
+					if strings.HasPrefix(arg, prefix) {
+						t.Errorf("unexpected %s in %v", arg, args)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestToolCall_InstancesCreateDiskSizes(t *testing.T) {
+	runner := &executortest.Runner{Stdout: `[]`}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_compute_instances_create", map[string]any{
+		"instance": "web-1",
+		"disks": []any{
+			map[string]any{"name": "data", "size": 50},
+			map[string]any{"name": "logs", "size": "20GB"},
+		},
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+	if missing := executortest.Missing(runner.LastArgs(), "--create-disk=name=data,size=50GB", "--create-disk=name=logs,size=20GB"); len(missing) > 0 {
+		t.Errorf("missing %v in %v", missing, runner.LastArgs())
+	}
+}
@@ -0,0 +1,1044 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gcloud-go-mcp/internal/executor"
+	"gcloud-go-mcp/internal/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// forwardingRuleTargets maps the target_type argument of forwarding rule
+// create to its gcloud flag.
+var forwardingRuleTargets = map[string]string{
+	"target-pool":        "target-pool",
+	"target-instance":    "target-instance",
+	"target-http-proxy":  "target-http-proxy",
+	"target-https-proxy": "target-https-proxy",
+	"target-tcp-proxy":   "target-tcp-proxy",
+	"target-ssl-proxy":   "target-ssl-proxy",
+	"backend-service":    "backend-service",
+}
+
+// registerLoadBalancingTools registers the forwarding rule, target pool, SSL
+// certificate, URL map, backend service and backend bucket tools.
+func registerLoadBalancingTools(server *mcp.Server, base *services.BaseService) {
+	// List forwarding rules
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_compute_forwarding_rules_list",
+			Description: "List forwarding rules",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"region": map[string]any{
+						"type":        "string",
+						"description": "Only list regional rules in this region",
+					},
+					"global": map[string]any{
+						"type":        "boolean",
+						"description": "Only list global rules",
+						"default":     false,
+					},
+					"filter": map[string]any{
+						"type":        "string",
+						"description": "Filter expression",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := scopedListCommand(base, "forwarding-rules", args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Create forwarding rule
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_compute_forwarding_rules_create",
+			Description: "Create a regional or global forwarding rule that sends traffic for an IP address and ports to a target",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"name", "target"},
+				"properties": map[string]any{
+					"name": map[string]any{
+						"type":        "string",
+						"description": "Forwarding rule name",
+					},
+					"target": map[string]any{
+						"type":        "string",
+						"description": "Name of the target that receives the traffic",
+					},
+					"target_type": map[string]any{
+						"type":        "string",
+						"description": "Kind of target. Target pools and target instances are regional only",
+						"default":     "target-pool",
+						"enum":        []string{"target-pool", "target-instance", "target-http-proxy", "target-https-proxy", "target-tcp-proxy", "target-ssl-proxy", "backend-service"},
+					},
+					"address": map[string]any{
+						"type":        "string",
+						"description": "Reserved IP address name or literal IP (an ephemeral address is used if omitted)",
+					},
+					"ports": map[string]any{
+						"type":        "array",
+						"description": "Ports or port ranges (e.g., [\"80\"], [\"8000-8100\"])",
+						"items":       map[string]any{"type": "string"},
+					},
+					"ip_protocol": map[string]any{
+						"type":        "string",
+						"description": "IP protocol",
+						"enum":        []string{"TCP", "UDP", "ESP", "AH", "SCTP", "ICMP", "L3_DEFAULT"},
+					},
+					"region": map[string]any{
+						"type":        "string",
+						"description": "Region for a regional rule (defaults to GCLOUD_REGION)",
+					},
+					"global": map[string]any{
+						"type":        "boolean",
+						"description": "Create a global rule instead of a regional one",
+						"default":     false,
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := forwardingRuleCreateCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// List target pools
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_compute_target_pools_list",
+			Description: "List target pools",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"region": map[string]any{
+						"type":        "string",
+						"description": "Only list target pools in this region",
+					},
+					"filter": map[string]any{
+						"type":        "string",
+						"description": "Filter expression",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := scopedListCommand(base, "target-pools", args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Create target pool
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_compute_target_pools_create",
+			Description: "Create a target pool for a network load balancer",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"name"},
+				"properties": map[string]any{
+					"name": map[string]any{
+						"type":        "string",
+						"description": "Target pool name",
+					},
+					"region": map[string]any{
+						"type":        "string",
+						"description": "Region (defaults to GCLOUD_REGION)",
+					},
+					"health_check": map[string]any{
+						"type":        "string",
+						"description": "Legacy HTTP health check used to probe instances in the pool",
+					},
+					"session_affinity": map[string]any{
+						"type":        "string",
+						"description": "Session affinity",
+						"enum":        []string{"NONE", "CLIENT_IP", "CLIENT_IP_PROTO"},
+					},
+					"description": map[string]any{
+						"type":        "string",
+						"description": "Description",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := targetPoolCreateCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// List SSL certificates
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_compute_ssl_certificates_list",
+			Description: "List SSL certificates",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"region": map[string]any{
+						"type":        "string",
+						"description": "Only list regional certificates in this region",
+					},
+					"global": map[string]any{
+						"type":        "boolean",
+						"description": "Only list global resources",
+						"default":     false,
+					},
+					"filter": map[string]any{
+						"type":        "string",
+						"description": "Filter expression",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := scopedListCommand(base, "ssl-certificates", args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Create SSL certificate
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_compute_ssl_certificates_create",
+			Description: "Create an SSL certificate for an HTTPS load balancer, either Google-managed (domains) or self-managed (certificate and private_key)",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"name"},
+				"properties": map[string]any{
+					"name": map[string]any{
+						"type":        "string",
+						"description": "Certificate name",
+					},
+					"domains": map[string]any{
+						"type":        "array",
+						"description": "Domains of a Google-managed certificate (e.g., [\"www.example.com\"]). Managed certificates are global only",
+						"items":       map[string]any{"type": "string"},
+					},
+					"certificate": map[string]any{
+						"type":        "string",
+						"description": "Path to the PEM certificate chain of a self-managed certificate",
+					},
+					"private_key": map[string]any{
+						"type":        "string",
+						"description": "Path to the PEM private key of a self-managed certificate",
+					},
+					"region": map[string]any{
+						"type":        "string",
+						"description": "Create a regional resource in this region (global if omitted)",
+					},
+					"description": map[string]any{
+						"type":        "string",
+						"description": "Description",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := sslCertificateCreateCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// List URL maps
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_compute_url_maps_list",
+			Description: "List URL maps",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"region": map[string]any{
+						"type":        "string",
+						"description": "Only list regional URL maps in this region",
+					},
+					"global": map[string]any{
+						"type":        "boolean",
+						"description": "Only list global resources",
+						"default":     false,
+					},
+					"filter": map[string]any{
+						"type":        "string",
+						"description": "Filter expression",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := scopedListCommand(base, "url-maps", args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Create URL map
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_compute_url_maps_create",
+			Description: "Create a URL map that routes requests to a default backend service or backend bucket",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"name"},
+				"properties": map[string]any{
+					"name": map[string]any{
+						"type":        "string",
+						"description": "URL map name",
+					},
+					"default_service": map[string]any{
+						"type":        "string",
+						"description": "Backend service that receives requests matching no other rule",
+					},
+					"default_backend_bucket": map[string]any{
+						"type":        "string",
+						"description": "Backend bucket that receives requests matching no other rule",
+					},
+					"region": map[string]any{
+						"type":        "string",
+						"description": "Create a regional resource in this region (global if omitted)",
+					},
+					"description": map[string]any{
+						"type":        "string",
+						"description": "Description",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := urlMapCreateCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// List backend services
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_compute_backend_services_list",
+			Description: "List backend services",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"region": map[string]any{
+						"type":        "string",
+						"description": "Only list regional backend services in this region",
+					},
+					"global": map[string]any{
+						"type":        "boolean",
+						"description": "Only list global resources",
+						"default":     false,
+					},
+					"filter": map[string]any{
+						"type":        "string",
+						"description": "Filter expression",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := scopedListCommand(base, "backend-services", args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Create backend service
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_compute_backend_services_create",
+			Description: "Create a backend service for a load balancer. Add instance groups to it with gcloud compute backend-services add-backend",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"name"},
+				"properties": map[string]any{
+					"name": map[string]any{
+						"type":        "string",
+						"description": "Backend service name",
+					},
+					"protocol": map[string]any{
+						"type":        "string",
+						"description": "Protocol used to reach the backends",
+						"enum":        []string{"HTTP", "HTTPS", "HTTP2", "TCP", "SSL", "UDP", "GRPC"},
+					},
+					"health_checks": map[string]any{
+						"type":        "array",
+						"description": "Health checks used to probe the backends",
+						"items":       map[string]any{"type": "string"},
+					},
+					"port_name": map[string]any{
+						"type":        "string",
+						"description": "Named port on the backend instance groups",
+					},
+					"load_balancing_scheme": map[string]any{
+						"type":        "string",
+						"description": "Load balancing scheme",
+						"enum":        []string{"EXTERNAL", "EXTERNAL_MANAGED", "INTERNAL", "INTERNAL_MANAGED", "INTERNAL_SELF_MANAGED"},
+					},
+					"timeout_seconds": map[string]any{
+						"type":        "integer",
+						"description": "Seconds to wait for a backend to respond",
+					},
+					"enable_cdn": map[string]any{
+						"type":        "boolean",
+						"description": "Enable Cloud CDN",
+						"default":     false,
+					},
+					"region": map[string]any{
+						"type":        "string",
+						"description": "Create a regional resource in this region (global if omitted)",
+					},
+					"description": map[string]any{
+						"type":        "string",
+						"description": "Description",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := backendServiceCreateCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// List backend buckets
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_compute_backend_buckets_list",
+			Description: "List backend buckets, which serve Cloud Storage buckets through an HTTP(S) load balancer",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"filter": map[string]any{
+						"type":        "string",
+						"description": "Filter expression",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd := base.Executor.Command("compute", "backend-buckets", "list").
+				WithFlag("filter", services.GetOptionalString(args, "filter", "")).
+				WithProject(services.GetOptionalString(args, "project", ""))
+			if err := services.ApplyListOutput(cmd, args, csvColumns["backend-buckets"]); err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Create backend bucket
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_compute_backend_buckets_create",
+			Description: "Create a backend bucket that serves a Cloud Storage bucket through an HTTP(S) load balancer, optionally with Cloud CDN. Use it as a URL map's default_backend_bucket",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"backend_bucket", "gcs_bucket_name"},
+				"properties": map[string]any{
+					"backend_bucket": map[string]any{
+						"type":        "string",
+						"description": "Backend bucket name",
+					},
+					"gcs_bucket_name": map[string]any{
+						"type":        "string",
+						"description": "Cloud Storage bucket to serve (without gs://)",
+					},
+					"enable_cdn": map[string]any{
+						"type":        "boolean",
+						"description": "Enable Cloud CDN",
+						"default":     false,
+					},
+					"cache_mode": map[string]any{
+						"type":        "string",
+						"description": "Cloud CDN cache mode (requires enable_cdn)",
+						"enum":        []string{"CACHE_ALL_STATIC", "USE_ORIGIN_HEADERS", "FORCE_CACHE_ALL"},
+					},
+					"description": map[string]any{
+						"type":        "string",
+						"description": "Description",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := backendBucketCreateCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// List health checks
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_compute_health_checks_list",
+			Description: "List health checks",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"region": map[string]any{
+						"type":        "string",
+						"description": "Only list regional health checks in this region",
+					},
+					"global": map[string]any{
+						"type":        "boolean",
+						"description": "Only list global resources",
+						"default":     false,
+					},
+					"filter": map[string]any{
+						"type":        "string",
+						"description": "Filter expression",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := scopedListCommand(base, "health-checks", args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Create health check
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_compute_health_checks_create",
+			Description: "Create an HTTP, HTTPS or TCP health check that load balancers use to probe their backends",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"name", "protocol"},
+				"properties": map[string]any{
+					"name": map[string]any{
+						"type":        "string",
+						"description": "Health check name",
+					},
+					"protocol": map[string]any{
+						"type":        "string",
+						"description": "Protocol used to probe the backends",
+						"enum":        []string{"http", "https", "tcp"},
+					},
+					"port": map[string]any{
+						"type":        "integer",
+						"description": "Port to probe (defaults to 80 for http and tcp, 443 for https)",
+					},
+					"request_path": map[string]any{
+						"type":        "string",
+						"description": "Request path for http and https checks (e.g., /healthz)",
+					},
+					"check_interval_seconds": map[string]any{
+						"type":        "integer",
+						"description": "Seconds between probes",
+					},
+					"timeout_seconds": map[string]any{
+						"type":        "integer",
+						"description": "Seconds to wait for a response",
+					},
+					"healthy_threshold": map[string]any{
+						"type":        "integer",
+						"description": "Consecutive successes after which a backend is healthy",
+					},
+					"unhealthy_threshold": map[string]any{
+						"type":        "integer",
+						"description": "Consecutive failures after which a backend is unhealthy",
+					},
+					"region": map[string]any{
+						"type":        "string",
+						"description": "Create a regional resource in this region (global if omitted)",
+					},
+					"description": map[string]any{
+						"type":        "string",
+						"description": "Description",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := healthCheckCreateCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+}
+
+// scopedListCommand builds a list command for a resource that can be regional
+// or global, narrowed by the region and global arguments.
+func scopedListCommand(base *services.BaseService, resource string, args map[string]any) (*executor.CommandBuilder, error) {
+	cmd := base.Executor.Command("compute", resource, "list").
+		WithProject(services.GetOptionalString(args, "project", ""))
+
+	region := services.GetOptionalString(args, "region", "")
+	if services.GetOptionalBool(args, "global", false) {
+		if region != "" {
+			return nil, fmt.Errorf("region and global are mutually exclusive")
+		}
+		cmd.WithBoolFlag("global")
+	} else if region != "" {
+		cmd.WithFlag("regions", region)
+	}
+	if filter := services.GetOptionalString(args, "filter", ""); filter != "" {
+		cmd.WithFlag("filter", filter)
+	}
+	if err := services.ApplyListOutput(cmd, args, csvColumns[resource]); err != nil {
+		return nil, err
+	}
+	return cmd, nil
+}
+
+// resourceRegion returns the region argument, falling back to the configured
+// default region.
+func resourceRegion(base *services.BaseService, args map[string]any) (string, error) {
+	region := services.GetOptionalString(args, "region", base.Config.Region)
+	if region == "" {
+		return "", fmt.Errorf("region is required (pass region or set GCLOUD_REGION)")
+	}
+	return region, nil
+}
+
+// forwardingRuleCreateCommand builds the `compute forwarding-rules create`
+// command for a regional or global rule.
+func forwardingRuleCreateCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	name, err := services.GetRequiredString(args, "name")
+	if err != nil {
+		return nil, err
+	}
+	target, err := services.GetRequiredString(args, "target")
+	if err != nil {
+		return nil, err
+	}
+	targetType := services.GetOptionalString(args, "target_type", "target-pool")
+	targetFlag, ok := forwardingRuleTargets[targetType]
+	if !ok {
+		return nil, fmt.Errorf("invalid target_type %q", targetType)
+	}
+
+	cmd := base.Executor.Command("compute", "forwarding-rules", "create", name).
+		WithFlag(targetFlag, target).
+		WithProject(services.GetOptionalString(args, "project", ""))
+
+	if services.GetOptionalBool(args, "global", false) {
+		if services.GetOptionalString(args, "region", "") != "" {
+			return nil, fmt.Errorf("region and global are mutually exclusive")
+		}
+		if targetType == "target-pool" || targetType == "target-instance" {
+			return nil, fmt.Errorf("%s targets are regional and can't be used with a global forwarding rule", targetType)
+		}
+		cmd.WithBoolFlag("global")
+	} else {
+		region, err := resourceRegion(base, args)
+		if err != nil {
+			return nil, err
+		}
+		cmd.WithFlag("region", region)
+	}
+
+	if address := services.GetOptionalString(args, "address", ""); address != "" {
+		cmd.WithFlag("address", address)
+	}
+	if ports := services.GetOptionalStringArray(args, "ports"); len(ports) > 0 {
+		cmd.WithFlag("ports", strings.Join(ports, ","))
+	}
+	if protocol := services.GetOptionalString(args, "ip_protocol", ""); protocol != "" {
+		cmd.WithFlag("ip-protocol", protocol)
+	}
+	return cmd, nil
+}
+
+// targetPoolCreateCommand builds the `compute target-pools create` command.
+func targetPoolCreateCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	name, err := services.GetRequiredString(args, "name")
+	if err != nil {
+		return nil, err
+	}
+	region, err := resourceRegion(base, args)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := base.Executor.Command("compute", "target-pools", "create", name).
+		WithFlag("region", region).
+		WithProject(services.GetOptionalString(args, "project", ""))
+
+	if healthCheck := services.GetOptionalString(args, "health_check", ""); healthCheck != "" {
+		cmd.WithFlag("http-health-check", healthCheck)
+	}
+	if affinity := services.GetOptionalString(args, "session_affinity", ""); affinity != "" {
+		cmd.WithFlag("session-affinity", affinity)
+	}
+	if description := services.GetOptionalString(args, "description", ""); description != "" {
+		cmd.WithFlag("description", description)
+	}
+	return cmd, nil
+}
+
+// applyGlobalOrRegion scopes a create command to the region argument, or to
+// the global scope when no region is given.
+func applyGlobalOrRegion(cmd *executor.CommandBuilder, args map[string]any) {
+	if region := services.GetOptionalString(args, "region", ""); region != "" {
+		cmd.WithFlag("region", region)
+	} else {
+		cmd.WithBoolFlag("global")
+	}
+}
+
+// sslCertificateCreateCommand builds the `compute ssl-certificates create`
+// command for a Google-managed or self-managed certificate.
+func sslCertificateCreateCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	name, err := services.GetRequiredString(args, "name")
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := base.Executor.Command("compute", "ssl-certificates", "create", name).
+		WithProject(services.GetOptionalString(args, "project", ""))
+
+	domains := services.GetOptionalStringArray(args, "domains")
+	certificate := services.GetOptionalString(args, "certificate", "")
+	privateKey := services.GetOptionalString(args, "private_key", "")
+	switch {
+	case len(domains) > 0:
+		if certificate != "" || privateKey != "" {
+			return nil, fmt.Errorf("domains (managed) and certificate/private_key (self-managed) are mutually exclusive")
+		}
+		if services.GetOptionalString(args, "region", "") != "" {
+			return nil, fmt.Errorf("managed certificates are global only")
+		}
+		cmd.WithFlag("domains", strings.Join(domains, ","))
+	case certificate != "" && privateKey != "":
+		cmd.WithFlag("certificate", certificate).
+			WithFlag("private-key", privateKey)
+	default:
+		return nil, fmt.Errorf("either domains or both certificate and private_key are required")
+	}
+
+	applyGlobalOrRegion(cmd, args)
+	if description := services.GetOptionalString(args, "description", ""); description != "" {
+		cmd.WithFlag("description", description)
+	}
+	return cmd, nil
+}
+
+// urlMapCreateCommand builds the `compute url-maps create` command.
+func urlMapCreateCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	name, err := services.GetRequiredString(args, "name")
+	if err != nil {
+		return nil, err
+	}
+
+	service := services.GetOptionalString(args, "default_service", "")
+	bucket := services.GetOptionalString(args, "default_backend_bucket", "")
+	if (service == "") == (bucket == "") {
+		return nil, fmt.Errorf("exactly one of default_service or default_backend_bucket is required")
+	}
+
+	cmd := base.Executor.Command("compute", "url-maps", "create", name).
+		WithFlag("default-service", service).
+		WithFlag("default-backend-bucket", bucket).
+		WithProject(services.GetOptionalString(args, "project", ""))
+
+	applyGlobalOrRegion(cmd, args)
+	if description := services.GetOptionalString(args, "description", ""); description != "" {
+		cmd.WithFlag("description", description)
+	}
+	return cmd, nil
+}
+
+// backendServiceCreateCommand builds the `compute backend-services create`
+// command.
+func backendServiceCreateCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	name, err := services.GetRequiredString(args, "name")
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := base.Executor.Command("compute", "backend-services", "create", name).
+		WithProject(services.GetOptionalString(args, "project", ""))
+
+	applyGlobalOrRegion(cmd, args)
+	if protocol := services.GetOptionalString(args, "protocol", ""); protocol != "" {
+		cmd.WithFlag("protocol", protocol)
+	}
+	if healthChecks := services.GetOptionalStringArray(args, "health_checks"); len(healthChecks) > 0 {
+		cmd.WithFlag("health-checks", strings.Join(healthChecks, ","))
+	}
+	if portName := services.GetOptionalString(args, "port_name", ""); portName != "" {
+		cmd.WithFlag("port-name", portName)
+	}
+	if scheme := services.GetOptionalString(args, "load_balancing_scheme", ""); scheme != "" {
+		cmd.WithFlag("load-balancing-scheme", scheme)
+	}
+	if timeout := services.GetOptionalInt(args, "timeout_seconds", 0); timeout > 0 {
+		cmd.WithFlag("timeout", fmt.Sprintf("%ds", timeout))
+	}
+	if services.GetOptionalBool(args, "enable_cdn", false) {
+		cmd.WithBoolFlag("enable-cdn")
+	}
+	if description := services.GetOptionalString(args, "description", ""); description != "" {
+		cmd.WithFlag("description", description)
+	}
+	return cmd, nil
+}
+
+// backendBucketCreateCommand builds the `compute backend-buckets create`
+// command.
+func backendBucketCreateCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	name, err := services.GetRequiredString(args, "backend_bucket")
+	if err != nil {
+		return nil, err
+	}
+	bucket, err := services.GetRequiredString(args, "gcs_bucket_name")
+	if err != nil {
+		return nil, err
+	}
+	enableCDN := services.GetOptionalBool(args, "enable_cdn", false)
+	cacheMode := services.GetOptionalString(args, "cache_mode", "")
+	if cacheMode != "" && !enableCDN {
+		return nil, fmt.Errorf("cache_mode requires enable_cdn")
+	}
+
+	cmd := base.Executor.Command("compute", "backend-buckets", "create", name).
+		WithFlag("gcs-bucket-name", strings.TrimPrefix(bucket, "gs://")).
+		WithFlag("cache-mode", cacheMode).
+		WithFlag("description", services.GetOptionalString(args, "description", "")).
+		WithProject(services.GetOptionalString(args, "project", ""))
+	if enableCDN {
+		cmd.WithBoolFlag("enable-cdn")
+	}
+	return cmd, nil
+}
+
+// healthCheckCreateCommand builds the `compute health-checks create PROTOCOL`
+// command. request_path only applies to http and https checks.
+func healthCheckCreateCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	if err := services.RequireAll(args, "name", "protocol"); err != nil {
+		return nil, err
+	}
+	name, _ := services.GetRequiredString(args, "name")
+	protocol, _ := services.GetRequiredString(args, "protocol")
+	protocol = strings.ToLower(protocol)
+	requestPath := services.GetOptionalString(args, "request_path", "")
+
+	switch protocol {
+	case "http", "https":
+	case "tcp":
+		if requestPath != "" {
+			return nil, fmt.Errorf("request_path only applies to http and https health checks")
+		}
+	default:
+		return nil, fmt.Errorf("protocol must be http, https or tcp, got %q", protocol)
+	}
+
+	cmd := base.Executor.Command("compute", "health-checks", "create", protocol, name).
+		WithFlag("request-path", requestPath).
+		WithFlag("description", services.GetOptionalString(args, "description", "")).
+		WithProject(services.GetOptionalString(args, "project", ""))
+
+	applyGlobalOrRegion(cmd, args)
+	if port := services.GetOptionalInt(args, "port", 0); port > 0 {
+		cmd.WithFlag("port", fmt.Sprintf("%d", port))
+	}
+	if interval := services.GetOptionalInt(args, "check_interval_seconds", 0); interval > 0 {
+		cmd.WithFlag("check-interval", fmt.Sprintf("%ds", interval))
+	}
+	if timeout := services.GetOptionalInt(args, "timeout_seconds", 0); timeout > 0 {
+		cmd.WithFlag("timeout", fmt.Sprintf("%ds", timeout))
+	}
+	if threshold := services.GetOptionalInt(args, "healthy_threshold", 0); threshold > 0 {
+		cmd.WithFlag("healthy-threshold", fmt.Sprintf("%d", threshold))
+	}
+	if threshold := services.GetOptionalInt(args, "unhealthy_threshold", 0); threshold > 0 {
+		cmd.WithFlag("unhealthy-threshold", fmt.Sprintf("%d", threshold))
+	}
+	return cmd, nil
+}
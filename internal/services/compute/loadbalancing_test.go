@@ -0,0 +1,428 @@
+package compute
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"gcloud-go-mcp/internal/executor/executortest"
+)
+
+func TestForwardingRuleCreateCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		notWant []string
+		wantErr string
+	}{
+		{
+			name: "regional target pool",
+			args: map[string]any{
+				"name":        "www-rule",
+				"target":      "www-pool",
+				"region":      "us-east1",
+				"address":     "lb-ip",
+				"ports":       []any{"80"},
+				"ip_protocol": "TCP",
+			},
+			want: []string{
+				"--target-pool=www-pool", "--region=us-east1", "--address=lb-ip",
+				"--ports=80", "--ip-protocol=TCP", "--project=test-project",
+			},
+			notWant: []string{"--global"},
+		},
+		{
+			name: "region defaults to config",
+			args: map[string]any{"name": "www-rule", "target": "www-pool", "ports": []any{"8000-8100", "9000"}},
+			want: []string{"--region=us-central1", "--ports=8000-8100,9000"},
+		},
+		{
+			name: "global proxy",
+			args: map[string]any{
+				"name":        "web-rule",
+				"target":      "web-proxy",
+				"target_type": "target-https-proxy",
+				"global":      true,
+				"ports":       []any{"443"},
+			},
+			want:    []string{"--target-https-proxy=web-proxy", "--global", "--ports=443"},
+			notWant: []string{"--region=us-central1"},
+		},
+		{
+			name:    "global target pool",
+			args:    map[string]any{"name": "www-rule", "target": "www-pool", "global": true},
+			wantErr: "regional",
+		},
+		{
+			name:    "global with region",
+			args:    map[string]any{"name": "web-rule", "target": "web-proxy", "target_type": "target-http-proxy", "global": true, "region": "us-east1"},
+			wantErr: "mutually exclusive",
+		},
+		{
+			name:    "invalid target type",
+			args:    map[string]any{"name": "www-rule", "target": "www-pool", "target_type": "vpn-gateway"},
+			wantErr: "invalid target_type",
+		},
+		{
+			name:    "missing target",
+			args:    map[string]any{"name": "www-rule"},
+			wantErr: "target",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := forwardingRuleCreateCommand(newTestBase(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if !slices.Equal(args[:4], []string{"compute", "forwarding-rules", "create", tt.args["name"].(string)}) {
+				t.Errorf("unexpected command %v", args)
+			}
+			for _, w := range tt.want {
+				if !slices.Contains(args, w) {
+					t.Errorf("expected %q in args, got %v", w, args)
+				}
+			}
+			for _, nw := range tt.notWant {
+				if slices.Contains(args, nw) {
+					t.Errorf("did not expect %q in args, got %v", nw, args)
+				}
+			}
+		})
+	}
+}
+
+func TestForwardingRuleCreateCommand_NoRegion(t *testing.T) {
+	base := newTestBase()
+	base.Config.Region = ""
+
+	_, err := forwardingRuleCreateCommand(base, map[string]any{"name": "www-rule", "target": "www-pool"})
+	if err == nil || !strings.Contains(err.Error(), "region is required") {
+		t.Errorf("expected region error, got %v", err)
+	}
+}
+
+func TestTargetPoolCreateCommand(t *testing.T) {
+	cmd, err := targetPoolCreateCommand(newTestBase(), map[string]any{
+		"name":             "www-pool",
+		"region":           "us-east1",
+		"health_check":     "basic-check",
+		"session_affinity": "CLIENT_IP",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	args := cmd.Build()
+	if !slices.Equal(args[:4], []string{"compute", "target-pools", "create", "www-pool"}) {
+		t.Errorf("unexpected command %v", args)
+	}
+	for _, w := range []string{"--region=us-east1", "--http-health-check=basic-check", "--session-affinity=CLIENT_IP"} {
+		if !slices.Contains(args, w) {
+			t.Errorf("expected %q in args, got %v", w, args)
+		}
+	}
+}
+
+func TestScopedListCommand(t *testing.T) {
+	cmd, err := scopedListCommand(newTestBase(), "forwarding-rules", map[string]any{"region": "us-east1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if args := cmd.Build(); !slices.Contains(args, "--regions=us-east1") {
+		t.Errorf("expected --regions=us-east1 in %v", args)
+	}
+
+	cmd, err = scopedListCommand(newTestBase(), "forwarding-rules", map[string]any{"global": true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if args := cmd.Build(); !slices.Contains(args, "--global") {
+		t.Errorf("expected --global in %v", args)
+	}
+
+	if _, err := scopedListCommand(newTestBase(), "forwarding-rules", map[string]any{"global": true, "region": "us-east1"}); err == nil {
+		t.Error("expected error for region with global")
+	}
+}
+
+func TestSSLCertificateCreateCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		notWant []string
+		wantErr string
+	}{
+		{
+			name:    "managed",
+			args:    map[string]any{"name": "www-cert", "domains": []any{"example.com", "www.example.com"}},
+			want:    []string{"--domains=example.com,www.example.com", "--global"},
+			notWant: []string{"--certificate", "--private-key"},
+		},
+		{
+			name: "self-managed regional",
+			args: map[string]any{
+				"name":        "www-cert",
+				"certificate": "/certs/www.crt",
+				"private_key": "/certs/www.key",
+				"region":      "us-east1",
+			},
+			want:    []string{"--certificate=/certs/www.crt", "--private-key=/certs/www.key", "--region=us-east1"},
+			notWant: []string{"--global"},
+		},
+		{
+			name:    "managed regional",
+			args:    map[string]any{"name": "www-cert", "domains": []any{"example.com"}, "region": "us-east1"},
+			wantErr: "global only",
+		},
+		{
+			name:    "managed and self-managed",
+			args:    map[string]any{"name": "www-cert", "domains": []any{"example.com"}, "certificate": "/certs/www.crt"},
+			wantErr: "mutually exclusive",
+		},
+		{
+			name:    "certificate without key",
+			args:    map[string]any{"name": "www-cert", "certificate": "/certs/www.crt"},
+			wantErr: "required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := sslCertificateCreateCommand(newTestBase(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if !slices.Equal(args[:4], []string{"compute", "ssl-certificates", "create", "www-cert"}) {
+				t.Errorf("unexpected command %v", args)
+			}
+			for _, w := range tt.want {
+				if !slices.Contains(args, w) {
+					t.Errorf("expected %q in args, got %v", w, args)
+				}
+			}
+			for _, nw := range tt.notWant {
+				for _, arg := range args {
+					if strings.HasPrefix(arg, nw) {
+						t.Errorf("did not expect %q in args, got %v", nw, args)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestURLMapCreateCommand(t *testing.T) {
+	cmd, err := urlMapCreateCommand(newTestBase(), map[string]any{"name": "web-map", "default_service": "web-backend"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	args := cmd.Build()
+	for _, w := range []string{"--default-service=web-backend", "--global"} {
+		if !slices.Contains(args, w) {
+			t.Errorf("expected %q in args, got %v", w, args)
+		}
+	}
+
+	_, err = urlMapCreateCommand(newTestBase(), map[string]any{"name": "web-map"})
+	if err == nil || !strings.Contains(err.Error(), "exactly one") {
+		t.Errorf("expected missing default error, got %v", err)
+	}
+}
+
+func TestBackendServiceCreateCommand(t *testing.T) {
+	cmd, err := backendServiceCreateCommand(newTestBase(), map[string]any{
+		"name":                  "web-backend",
+		"protocol":              "HTTP",
+		"health_checks":         []any{"web-check"},
+		"port_name":             "http",
+		"load_balancing_scheme": "EXTERNAL_MANAGED",
+		"timeout_seconds":       float64(60),
+		"enable_cdn":            true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	args := cmd.Build()
+	if !slices.Equal(args[:4], []string{"compute", "backend-services", "create", "web-backend"}) {
+		t.Errorf("unexpected command %v", args)
+	}
+	for _, w := range []string{
+		"--global", "--protocol=HTTP", "--health-checks=web-check", "--port-name=http",
+		"--load-balancing-scheme=EXTERNAL_MANAGED", "--timeout=60s", "--enable-cdn",
+	} {
+		if !slices.Contains(args, w) {
+			t.Errorf("expected %q in args, got %v", w, args)
+		}
+	}
+}
+
+func TestBackendBucketCreateCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		notWant []string
+		wantErr string
+	}{
+		{
+			name:    "without CDN",
+			args:    map[string]any{"backend_bucket": "static", "gcs_bucket_name": "my-site"},
+			want:    []string{"--gcs-bucket-name=my-site", "--project=test-project"},
+			notWant: []string{"--enable-cdn", "--cache-mode=", "--global"},
+		},
+		{
+			name: "with CDN",
+			args: map[string]any{
+				"backend_bucket":  "static",
+				"gcs_bucket_name": "gs://my-site",
+				"enable_cdn":      true,
+				"cache_mode":      "CACHE_ALL_STATIC",
+				"description":     "Static assets",
+			},
+			want: []string{"--gcs-bucket-name=my-site", "--enable-cdn", "--cache-mode=CACHE_ALL_STATIC", "--description=Static assets"},
+		},
+		{
+			name:    "cache mode without CDN",
+			args:    map[string]any{"backend_bucket": "static", "gcs_bucket_name": "my-site", "cache_mode": "FORCE_CACHE_ALL"},
+			wantErr: "cache_mode requires enable_cdn",
+		},
+		{
+			name:    "missing bucket",
+			args:    map[string]any{"backend_bucket": "static"},
+			wantErr: "gcs_bucket_name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := backendBucketCreateCommand(newTestBase(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if !slices.Equal(args[:4], []string{"compute", "backend-buckets", "create", "static"}) {
+				t.Errorf("unexpected command %v", args)
+			}
+			if missing := executortest.Missing(args, tt.want...); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, args)
+			}
+			for _, prefix := range tt.notWant {
+				for _, arg := range args {
+					if strings.HasPrefix(arg, prefix) {
+						t.Errorf("unexpected %s in %v", arg, args)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestHealthCheckCreateCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		notWant []string
+		wantErr string
+	}{
+		{
+			name: "http",
+			args: map[string]any{
+				"name":                   "web-check",
+				"protocol":               "http",
+				"port":                   float64(8080),
+				"request_path":           "/healthz",
+				"check_interval_seconds": float64(10),
+				"timeout_seconds":        float64(5),
+				"healthy_threshold":      float64(2),
+				"unhealthy_threshold":    float64(3),
+			},
+			want: []string{
+				"compute", "health-checks", "create", "http", "web-check",
+				"--global", "--port=8080", "--request-path=/healthz", "--check-interval=10s",
+				"--timeout=5s", "--healthy-threshold=2", "--unhealthy-threshold=3",
+			},
+		},
+		{
+			name:    "regional https",
+			args:    map[string]any{"name": "api-check", "protocol": "HTTPS", "region": "us-central1"},
+			want:    []string{"https", "api-check", "--region=us-central1"},
+			notWant: []string{"--global", "--port=", "--request-path="},
+		},
+		{
+			name: "tcp",
+			args: map[string]any{"name": "db-check", "protocol": "tcp", "port": float64(5432)},
+			want: []string{"tcp", "db-check", "--port=5432"},
+		},
+		{
+			name:    "tcp with request path",
+			args:    map[string]any{"name": "db-check", "protocol": "tcp", "request_path": "/"},
+			wantErr: "request_path only applies",
+		},
+		{
+			name:    "unsupported protocol",
+			args:    map[string]any{"name": "grpc-check", "protocol": "grpc"},
+			wantErr: "protocol must be http, https or tcp",
+		},
+		{
+			name:    "missing protocol",
+			args:    map[string]any{"name": "web-check"},
+			wantErr: "protocol",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := healthCheckCreateCommand(newTestBase(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if missing := executortest.Missing(args, tt.want...); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, args)
+			}
+			for _, prefix := range tt.notWant {
+				for _, arg := range args {
+					if strings.HasPrefix(arg, prefix) {
+						t.Errorf("unexpected %s in %v", arg, args)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"gcloud-go-mcp/internal/executor"
+	"gcloud-go-mcp/internal/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// registerOperationTools registers the tools that track zonal, regional and
+// global Compute Engine operations.
+func registerOperationTools(server *mcp.Server, base *services.BaseService) {
+	// List operations
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_compute_operations_list",
+			Description: "List Compute Engine operations, newest first, to follow the progress of asynchronous actions",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"zone": map[string]any{
+						"type":        "string",
+						"description": "Only list operations in this zone",
+					},
+					"region": map[string]any{
+						"type":        "string",
+						"description": "Only list operations in this region",
+					},
+					"global": map[string]any{
+						"type":        "boolean",
+						"description": "Only list global operations",
+						"default":     false,
+					},
+					"filter": map[string]any{
+						"type":        "string",
+						"description": "Filter expression (e.g., status!=DONE or targetLink~instances/web-1)",
+					},
+					"limit": map[string]any{
+						"type":        "integer",
+						"description": "Maximum number of operations to return",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := operationsListCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Describe operation
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_compute_operations_describe",
+			Description: "Get the status, progress and errors of a Compute Engine operation",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"operation"},
+				"properties": map[string]any{
+					"operation": map[string]any{
+						"type":        "string",
+						"description": "Operation name (e.g., operation-1700000000000-abc123)",
+					},
+					"zone": map[string]any{
+						"type":        "string",
+						"description": "Zone of a zonal operation (the default when no scope is given, falling back to GCLOUD_COMPUTE_ZONE, then GCLOUD_ZONE)",
+					},
+					"region": map[string]any{
+						"type":        "string",
+						"description": "Region of a regional operation",
+					},
+					"global": map[string]any{
+						"type":        "boolean",
+						"description": "Describe a global operation",
+						"default":     false,
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"soft_not_found": services.SoftNotFoundProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := operationDescribeCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.DescribeError(args, result, err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+}
+
+// operationScope returns the zone, region and global arguments, of which at
+// most one may be given.
+func operationScope(args map[string]any) (zone, region string, global bool, err error) {
+	zone = services.GetOptionalString(args, "zone", "")
+	region = services.GetOptionalString(args, "region", "")
+	global = services.GetOptionalBool(args, "global", false)
+
+	scopes := 0
+	for _, set := range []bool{zone != "", region != "", global} {
+		if set {
+			scopes++
+		}
+	}
+	if scopes > 1 {
+		return "", "", false, fmt.Errorf("zone, region and global are mutually exclusive")
+	}
+	return zone, region, global, nil
+}
+
+// operationsListCommand builds the `compute operations list` command, sorted
+// newest first and narrowed to the zone, region or global scope when given.
+func operationsListCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	zone, region, global, err := operationScope(args)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := base.Executor.Command("compute", "operations", "list").
+		WithFlag("zones", zone).
+		WithFlag("regions", region).
+		WithFlag("filter", services.GetOptionalString(args, "filter", "")).
+		WithFlag("sort-by", "~insertTime").
+		WithProject(services.GetOptionalString(args, "project", ""))
+	if global {
+		cmd.WithBoolFlag("global")
+	}
+	if limit := services.GetOptionalInt(args, "limit", 0); limit > 0 {
+		cmd.WithFlag("limit", strconv.Itoa(limit))
+	}
+	if err := services.ApplyListOutput(cmd, args, csvColumns["operations"]); err != nil {
+		return nil, err
+	}
+	return cmd, nil
+}
+
+// operationDescribeCommand builds the `compute operations describe` command.
+// Without a scope the operation is looked up in the default compute zone, as
+// instance and disk operations are zonal.
+func operationDescribeCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	operation, err := services.GetRequiredString(args, "operation")
+	if err != nil {
+		return nil, err
+	}
+	_, region, global, err := operationScope(args)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := base.Executor.Command("compute", "operations", "describe", operation).
+		WithProject(services.GetOptionalString(args, "project", ""))
+	switch {
+	case global:
+		cmd.WithBoolFlag("global")
+	case region != "":
+		cmd.WithFlag("region", region)
+	default:
+		zone, err := requiredZone(base, args)
+		if err != nil {
+			return nil, err
+		}
+		cmd.WithFlag("zone", zone)
+	}
+	return cmd, nil
+}
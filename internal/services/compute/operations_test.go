@@ -0,0 +1,147 @@
+package compute
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestOperationsListCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		notWant []string
+		wantErr string
+	}{
+		{
+			name:    "all scopes",
+			args:    map[string]any{},
+			want:    []string{"--sort-by=~insertTime"},
+			notWant: []string{"--zones=", "--regions=", "--global"},
+		},
+		{
+			name:    "zone",
+			args:    map[string]any{"zone": "us-central1-a", "limit": float64(20)},
+			want:    []string{"--zones=us-central1-a", "--limit=20"},
+			notWant: []string{"--regions=", "--global"},
+		},
+		{
+			name:    "region",
+			args:    map[string]any{"region": "us-central1", "filter": "status!=DONE"},
+			want:    []string{"--regions=us-central1", "--filter=status!=DONE"},
+			notWant: []string{"--zones=", "--global"},
+		},
+		{
+			name:    "global",
+			args:    map[string]any{"global": true},
+			want:    []string{"--global"},
+			notWant: []string{"--zones=", "--regions="},
+		},
+		{
+			name:    "zone and global",
+			args:    map[string]any{"zone": "us-central1-a", "global": true},
+			wantErr: "mutually exclusive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := operationsListCommand(newTestBase(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if !slices.Equal(args[:3], []string{"compute", "operations", "list"}) {
+				t.Errorf("unexpected command %v", args)
+			}
+			for _, w := range tt.want {
+				if !slices.Contains(args, w) {
+					t.Errorf("expected %q in args, got %v", w, args)
+				}
+			}
+			for _, prefix := range tt.notWant {
+				for _, arg := range args {
+					if strings.HasPrefix(arg, prefix) {
+						t.Errorf("unexpected %s in %v", arg, args)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestOperationDescribeCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    string
+		wantErr string
+	}{
+		{
+			name: "default zone",
+			args: map[string]any{},
+			want: "--zone=us-central1-a",
+		},
+		{
+			name: "zone",
+			args: map[string]any{"zone": "europe-west1-b"},
+			want: "--zone=europe-west1-b",
+		},
+		{
+			name: "region",
+			args: map[string]any{"region": "us-central1"},
+			want: "--region=us-central1",
+		},
+		{
+			name: "global",
+			args: map[string]any{"global": true},
+			want: "--global",
+		},
+		{
+			name:    "region and global",
+			args:    map[string]any{"region": "us-central1", "global": true},
+			wantErr: "mutually exclusive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.args["operation"] = "operation-1700000000000-abc123"
+			cmd, err := operationDescribeCommand(newTestBase(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if !slices.Equal(args[:4], []string{"compute", "operations", "describe", "operation-1700000000000-abc123"}) {
+				t.Errorf("unexpected command %v", args)
+			}
+			if !slices.Contains(args, tt.want) {
+				t.Errorf("expected %q in args, got %v", tt.want, args)
+			}
+			scopes := 0
+			for _, arg := range args {
+				if strings.HasPrefix(arg, "--zone=") || strings.HasPrefix(arg, "--region=") || arg == "--global" {
+					scopes++
+				}
+			}
+			if scopes != 1 {
+				t.Errorf("expected exactly one scope flag, got %v", args)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package compute
+
+// hoursPerMonth is the number of hours Google Cloud bills as one month.
+const hoursPerMonth = 730
+
+// priceTableNote describes what the estimates of
+// gcp_compute_instances_cost_estimate are based on.
+const priceTableNote = "Approximate on-demand list prices in us-central1 for vCPU and memory only. " +
+	"Other regions, Spot and preemptible VMs, committed and sustained use discounts, " +
+	"disks, GPUs, licenses and network traffic are not included."
+
+// hourlyPrices maps predefined machine types to their approximate on-demand
+// price in USD per hour in us-central1. The prices are a rough guide for
+// estimates, not a billing source; update them from
+// https://cloud.google.com/compute/vm-instance-pricing when they drift.
+var hourlyPrices = map[string]float64{
+	// Shared-core
+	"f1-micro": 0.0076,
+	"g1-small": 0.0257,
+	"e2-micro": 0.0084,
+	"e2-small": 0.0168,
+
+	// E2
+	"e2-medium":      0.0335,
+	"e2-standard-2":  0.0670,
+	"e2-standard-4":  0.1340,
+	"e2-standard-8":  0.2681,
+	"e2-standard-16": 0.5362,
+	"e2-standard-32": 1.0724,
+	"e2-highmem-2":   0.0904,
+	"e2-highmem-4":   0.1807,
+	"e2-highmem-8":   0.3614,
+	"e2-highmem-16":  0.7228,
+	"e2-highcpu-2":   0.0495,
+	"e2-highcpu-4":   0.0989,
+	"e2-highcpu-8":   0.1978,
+	"e2-highcpu-16":  0.3956,
+	"e2-highcpu-32":  0.7913,
+
+	// N1
+	"n1-standard-1":  0.0475,
+	"n1-standard-2":  0.0950,
+	"n1-standard-4":  0.1900,
+	"n1-standard-8":  0.3800,
+	"n1-standard-16": 0.7600,
+	"n1-standard-32": 1.5200,
+	"n1-standard-64": 3.0400,
+	"n1-standard-96": 4.5600,
+	"n1-highmem-2":   0.1184,
+	"n1-highmem-4":   0.2368,
+	"n1-highmem-8":   0.4736,
+	"n1-highmem-16":  0.9472,
+	"n1-highcpu-2":   0.0709,
+	"n1-highcpu-4":   0.1418,
+	"n1-highcpu-8":   0.2836,
+	"n1-highcpu-16":  0.5672,
+
+	// N2
+	"n2-standard-2":   0.0971,
+	"n2-standard-4":   0.1942,
+	"n2-standard-8":   0.3885,
+	"n2-standard-16":  0.7769,
+	"n2-standard-32":  1.5539,
+	"n2-standard-48":  2.3308,
+	"n2-standard-64":  3.1078,
+	"n2-standard-80":  3.8847,
+	"n2-standard-96":  4.6616,
+	"n2-standard-128": 6.2155,
+	"n2-highmem-2":    0.1310,
+	"n2-highmem-4":    0.2620,
+	"n2-highmem-8":    0.5241,
+	"n2-highmem-16":   1.0481,
+	"n2-highmem-32":   2.0962,
+	"n2-highcpu-2":    0.0717,
+	"n2-highcpu-4":    0.1434,
+	"n2-highcpu-8":    0.2868,
+	"n2-highcpu-16":   0.5736,
+	"n2-highcpu-32":   1.1471,
+
+	// N2D
+	"n2d-standard-2":  0.0845,
+	"n2d-standard-4":  0.1690,
+	"n2d-standard-8":  0.3380,
+	"n2d-standard-16": 0.6759,
+	"n2d-standard-32": 1.3519,
+
+	// T2D
+	"t2d-standard-1": 0.0422,
+	"t2d-standard-2": 0.0845,
+	"t2d-standard-4": 0.1690,
+	"t2d-standard-8": 0.3380,
+
+	// C2
+	"c2-standard-4":  0.2088,
+	"c2-standard-8":  0.4176,
+	"c2-standard-16": 0.8352,
+	"c2-standard-30": 1.5660,
+	"c2-standard-60": 3.1321,
+}
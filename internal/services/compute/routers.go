@@ -0,0 +1,252 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gcloud-go-mcp/internal/executor"
+	"gcloud-go-mcp/internal/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// registerRouterTools registers the Cloud Router and Cloud NAT tools.
+func registerRouterTools(server *mcp.Server, base *services.BaseService) {
+	// List routers
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_compute_routers_list",
+			Description: "List Cloud Routers",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"region": map[string]any{
+						"type":        "string",
+						"description": "Only list routers in this region",
+					},
+					"filter": map[string]any{
+						"type":        "string",
+						"description": "Filter expression",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := scopedListCommand(base, "routers", args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Create router
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_compute_routers_create",
+			Description: "Create a Cloud Router in a VPC network, e.g. to host a Cloud NAT gateway",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"name", "network"},
+				"properties": map[string]any{
+					"name": map[string]any{
+						"type":        "string",
+						"description": "Router name",
+					},
+					"network": map[string]any{
+						"type":        "string",
+						"description": "VPC network the router belongs to",
+					},
+					"region": map[string]any{
+						"type":        "string",
+						"description": "Region (defaults to GCLOUD_REGION)",
+					},
+					"asn": map[string]any{
+						"type":        "integer",
+						"description": "BGP autonomous system number (only needed for dynamic routing)",
+					},
+					"description": map[string]any{
+						"type":        "string",
+						"description": "Description",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := routerCreateCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Create NAT
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_compute_routers_nats_create",
+			Description: "Create a Cloud NAT gateway on a Cloud Router so instances without external IPs can reach the internet",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"router", "nat_name"},
+				"properties": map[string]any{
+					"router": map[string]any{
+						"type":        "string",
+						"description": "Cloud Router to add the NAT gateway to",
+					},
+					"nat_name": map[string]any{
+						"type":        "string",
+						"description": "NAT gateway name",
+					},
+					"region": map[string]any{
+						"type":        "string",
+						"description": "Region of the router (defaults to GCLOUD_REGION)",
+					},
+					"auto_allocate_nat_external_ips": map[string]any{
+						"type":        "boolean",
+						"description": "Let Google allocate the NAT external IPs. Used when nat_external_ip_pool is omitted",
+						"default":     true,
+					},
+					"nat_external_ip_pool": map[string]any{
+						"type":        "array",
+						"description": "Reserved external addresses to use for NAT instead of auto-allocated ones",
+						"items":       map[string]any{"type": "string"},
+					},
+					"nat_all_subnet_ip_ranges": map[string]any{
+						"type":        "boolean",
+						"description": "NAT all IP ranges of all subnets in the region. Used when nat_custom_subnet_ip_ranges is omitted",
+						"default":     true,
+					},
+					"nat_custom_subnet_ip_ranges": map[string]any{
+						"type":        "array",
+						"description": "Only NAT these subnets or subnet ranges (e.g., [\"subnet-a\", \"subnet-b:secondary-range\"])",
+						"items":       map[string]any{"type": "string"},
+					},
+					"enable_logging": map[string]any{
+						"type":        "boolean",
+						"description": "Log NAT translations",
+						"default":     false,
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := natCreateCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+}
+
+// routerCreateCommand builds the `compute routers create` command.
+func routerCreateCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	name, err := services.GetRequiredString(args, "name")
+	if err != nil {
+		return nil, err
+	}
+	network, err := services.GetRequiredString(args, "network")
+	if err != nil {
+		return nil, err
+	}
+	region, err := resourceRegion(base, args)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := base.Executor.Command("compute", "routers", "create", name).
+		WithFlag("network", network).
+		WithFlag("region", region).
+		WithProject(services.GetOptionalString(args, "project", ""))
+
+	if asn := services.GetOptionalInt(args, "asn", 0); asn > 0 {
+		cmd.WithFlag("asn", fmt.Sprintf("%d", asn))
+	}
+	if description := services.GetOptionalString(args, "description", ""); description != "" {
+		cmd.WithFlag("description", description)
+	}
+	return cmd, nil
+}
+
+// natCreateCommand builds the `compute routers nats create` command. gcloud
+// requires one source of NAT IPs and one set of subnet ranges, so the
+// automatic choices are used unless explicit addresses or ranges are given.
+func natCreateCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	router, err := services.GetRequiredString(args, "router")
+	if err != nil {
+		return nil, err
+	}
+	natName, err := services.GetRequiredString(args, "nat_name")
+	if err != nil {
+		return nil, err
+	}
+	region, err := resourceRegion(base, args)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := base.Executor.Command("compute", "routers", "nats", "create", natName).
+		WithFlag("router", router).
+		WithFlag("region", region).
+		WithProject(services.GetOptionalString(args, "project", ""))
+
+	if pool := services.GetOptionalStringArray(args, "nat_external_ip_pool"); len(pool) > 0 {
+		if services.GetOptionalBool(args, "auto_allocate_nat_external_ips", false) {
+			return nil, fmt.Errorf("auto_allocate_nat_external_ips and nat_external_ip_pool are mutually exclusive")
+		}
+		cmd.WithFlag("nat-external-ip-pool", strings.Join(pool, ","))
+	} else if services.GetOptionalBool(args, "auto_allocate_nat_external_ips", true) {
+		cmd.WithBoolFlag("auto-allocate-nat-external-ips")
+	} else {
+		return nil, fmt.Errorf("nat_external_ip_pool is required when auto_allocate_nat_external_ips is false")
+	}
+
+	if ranges := services.GetOptionalStringArray(args, "nat_custom_subnet_ip_ranges"); len(ranges) > 0 {
+		if services.GetOptionalBool(args, "nat_all_subnet_ip_ranges", false) {
+			return nil, fmt.Errorf("nat_all_subnet_ip_ranges and nat_custom_subnet_ip_ranges are mutually exclusive")
+		}
+		cmd.WithFlag("nat-custom-subnet-ip-ranges", strings.Join(ranges, ","))
+	} else if services.GetOptionalBool(args, "nat_all_subnet_ip_ranges", true) {
+		cmd.WithBoolFlag("nat-all-subnet-ip-ranges")
+	} else {
+		return nil, fmt.Errorf("nat_custom_subnet_ip_ranges is required when nat_all_subnet_ip_ranges is false")
+	}
+
+	if services.GetOptionalBool(args, "enable_logging", false) {
+		cmd.WithBoolFlag("enable-logging")
+	}
+	return cmd, nil
+}
@@ -0,0 +1,132 @@
+package compute
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNATCreateCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		notWant []string
+		wantErr string
+	}{
+		{
+			name: "defaults",
+			args: map[string]any{"router": "nat-router", "nat_name": "egress"},
+			want: []string{
+				"--router=nat-router", "--region=us-central1",
+				"--auto-allocate-nat-external-ips", "--nat-all-subnet-ip-ranges",
+			},
+			notWant: []string{"--enable-logging"},
+		},
+		{
+			name: "explicit",
+			args: map[string]any{
+				"router":                         "nat-router",
+				"nat_name":                       "egress",
+				"region":                         "us-east1",
+				"auto_allocate_nat_external_ips": true,
+				"nat_all_subnet_ip_ranges":       true,
+				"enable_logging":                 true,
+			},
+			want: []string{
+				"--region=us-east1", "--auto-allocate-nat-external-ips",
+				"--nat-all-subnet-ip-ranges", "--enable-logging",
+			},
+		},
+		{
+			name: "reserved addresses and custom ranges",
+			args: map[string]any{
+				"router":                      "nat-router",
+				"nat_name":                    "egress",
+				"nat_external_ip_pool":        []any{"nat-ip-1", "nat-ip-2"},
+				"nat_custom_subnet_ip_ranges": []any{"subnet-a", "subnet-b:pods"},
+			},
+			want: []string{
+				"--nat-external-ip-pool=nat-ip-1,nat-ip-2",
+				"--nat-custom-subnet-ip-ranges=subnet-a,subnet-b:pods",
+			},
+			notWant: []string{"--auto-allocate-nat-external-ips", "--nat-all-subnet-ip-ranges"},
+		},
+		{
+			name: "auto allocate with pool",
+			args: map[string]any{
+				"router":                         "nat-router",
+				"nat_name":                       "egress",
+				"auto_allocate_nat_external_ips": true,
+				"nat_external_ip_pool":           []any{"nat-ip-1"},
+			},
+			wantErr: "mutually exclusive",
+		},
+		{
+			name:    "no ip source",
+			args:    map[string]any{"router": "nat-router", "nat_name": "egress", "auto_allocate_nat_external_ips": false},
+			wantErr: "nat_external_ip_pool is required",
+		},
+		{
+			name:    "no subnet ranges",
+			args:    map[string]any{"router": "nat-router", "nat_name": "egress", "nat_all_subnet_ip_ranges": false},
+			wantErr: "nat_custom_subnet_ip_ranges is required",
+		},
+		{
+			name:    "missing router",
+			args:    map[string]any{"nat_name": "egress"},
+			wantErr: "router",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := natCreateCommand(newTestBase(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if !slices.Equal(args[:5], []string{"compute", "routers", "nats", "create", "egress"}) {
+				t.Errorf("unexpected command %v", args)
+			}
+			for _, w := range tt.want {
+				if !slices.Contains(args, w) {
+					t.Errorf("expected %q in args, got %v", w, args)
+				}
+			}
+			for _, nw := range tt.notWant {
+				if slices.Contains(args, nw) {
+					t.Errorf("did not expect %q in args, got %v", nw, args)
+				}
+			}
+		})
+	}
+}
+
+func TestRouterCreateCommand(t *testing.T) {
+	cmd, err := routerCreateCommand(newTestBase(), map[string]any{
+		"name":    "nat-router",
+		"network": "default",
+		"asn":     float64(64512),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	args := cmd.Build()
+	if !slices.Equal(args[:4], []string{"compute", "routers", "create", "nat-router"}) {
+		t.Errorf("unexpected command %v", args)
+	}
+	for _, w := range []string{"--network=default", "--region=us-central1", "--asn=64512"} {
+		if !slices.Contains(args, w) {
+			t.Errorf("expected %q in args, got %v", w, args)
+		}
+	}
+}
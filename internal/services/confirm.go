@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Destructive returns tool annotations declaring that a tool deletes or
+// overwrites resources. When confirmation is required by the configuration,
+// such tools only run when called with confirm: true.
+func Destructive() *mcp.ToolAnnotations {
+	destructive := true
+	return &mcp.ToolAnnotations{DestructiveHint: &destructive}
+}
+
+// IsDestructive reports whether tool has been explicitly declared destructive.
+// Tools without annotations are not gated, even though MCP clients assume
+// they may be destructive.
+func IsDestructive(tool *mcp.Tool) bool {
+	return tool.Annotations != nil && tool.Annotations.DestructiveHint != nil && *tool.Annotations.DestructiveHint
+}
+
+// requireConfirmation adds a confirm argument to the tool's schema and wraps
+// handler so that it only runs when confirm is true. Otherwise it returns a
+// message asking the caller to confirm by calling the tool again.
+func requireConfirmation(tool *mcp.Tool, handler mcp.ToolHandler) mcp.ToolHandler {
+	if schema, ok := tool.InputSchema.(map[string]any); ok {
+		if properties, ok := schema["properties"].(map[string]any); ok {
+			properties["confirm"] = map[string]any{
+				"type":        "boolean",
+				"description": "Set to true to confirm this destructive operation",
+				"default":     false,
+			}
+		}
+	}
+
+	return func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args map[string]any
+		if req.Params.Arguments != nil {
+			_ = json.Unmarshal(req.Params.Arguments, &args)
+		}
+		if !GetOptionalBool(args, "confirm", false) {
+			markUnconfirmed(ctx)
+			return ToolResult(fmt.Sprintf("%s is destructive and was not run. Check the arguments with the user, then call it again with confirm: true to proceed.", tool.Name)), nil
+		}
+		return handler(ctx, req)
+	}
+}
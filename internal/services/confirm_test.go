@@ -0,0 +1,46 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestIsDestructive(t *testing.T) {
+	readOnly := false
+	tests := []struct {
+		name string
+		tool *mcp.Tool
+		want bool
+	}{
+		{"declared", &mcp.Tool{Annotations: Destructive()}, true},
+		{"no annotations", &mcp.Tool{}, false},
+		{"hint unset", &mcp.Tool{Annotations: &mcp.ToolAnnotations{ReadOnlyHint: true}}, false},
+		{"hint false", &mcp.Tool{Annotations: &mcp.ToolAnnotations{DestructiveHint: &readOnly}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsDestructive(tt.tool); got != tt.want {
+				t.Errorf("IsDestructive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequireConfirmation_AddsConfirmProperty(t *testing.T) {
+	tool := &mcp.Tool{
+		Name:        "gcp_things_delete",
+		Annotations: Destructive(),
+		InputSchema: map[string]any{
+			"type":       "object",
+			"properties": map[string]any{},
+		},
+	}
+	requireConfirmation(tool, nil)
+
+	properties := tool.InputSchema.(map[string]any)["properties"].(map[string]any)
+	if _, ok := properties["confirm"]; !ok {
+		t.Errorf("expected confirm property, got %v", properties)
+	}
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+
+	"gcloud-go-mcp/internal/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Diagnostics describes the server's gcloud setup, as reported by
+// gcp_diagnostics.
+type Diagnostics struct {
+	GCloudPath         string `json:"gcloudPath"`
+	ResolvedGCloudPath string `json:"resolvedGCloudPath,omitempty"`
+	GCloudError        string `json:"gcloudError,omitempty"`
+	Project            string `json:"project,omitempty"`
+	Account            string `json:"account,omitempty"`
+	Configuration      string `json:"configuration,omitempty"`
+	Region             string `json:"region,omitempty"`
+	Zone               string `json:"zone,omitempty"`
+}
+
+// Diagnose looks up the configured gcloud binary and collects the defaults
+// the tools run with.
+func (b *BaseService) Diagnose() Diagnostics {
+	d := Diagnostics{
+		GCloudPath:    b.Config.GCloudPath,
+		Project:       b.Config.Project,
+		Account:       b.Config.Account,
+		Configuration: b.Config.Configuration,
+		Region:        b.Config.Region,
+		Zone:          b.Config.Zone,
+	}
+	defaults := b.Executor.Defaults()
+	if d.Project == "" {
+		d.Project = defaults.Project
+	}
+	if d.Account == "" {
+		d.Account = defaults.Account
+	}
+
+	resolved, err := config.LookupGCloud(b.Config.GCloudPath)
+	if err != nil {
+		d.GCloudError = err.Error()
+	}
+	d.ResolvedGCloudPath = resolved
+	return d
+}
+
+// RegisterDiagnosticsTool registers the gcp_diagnostics tool, which reports
+// where the gcloud binary was found and the defaults the tools use.
+func RegisterDiagnosticsTool(server *mcp.Server, base *BaseService) {
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_diagnostics",
+			Description: "Report the resolved gcloud binary and the default project, account, region and zone, to troubleshoot failing tools",
+			InputSchema: map[string]any{
+				"type":       "object",
+				"properties": map[string]any{},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			data, err := json.MarshalIndent(base.Diagnose(), "", "  ")
+			if err != nil {
+				return ToolError(err), nil
+			}
+			return ToolResult(string(data)), nil
+		},
+	)
+}
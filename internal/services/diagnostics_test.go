@@ -0,0 +1,45 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gcloud-go-mcp/internal/config"
+	"gcloud-go-mcp/internal/executor"
+)
+
+func TestDiagnose(t *testing.T) {
+	gcloud := filepath.Join(t.TempDir(), "gcloud")
+	if err := os.WriteFile(gcloud, []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatalf("failed to write fake gcloud: %v", err)
+	}
+
+	base := NewBaseService(&config.Config{GCloudPath: gcloud, Region: "us-central1"})
+	base.Executor.SetDefaults(executor.Defaults{Project: "resolved-project", Account: "dev@example.com"})
+
+	d := base.Diagnose()
+	if d.ResolvedGCloudPath != gcloud || d.GCloudError != "" {
+		t.Errorf("expected gcloud at %s, got %+v", gcloud, d)
+	}
+	if d.Project != "resolved-project" || d.Account != "dev@example.com" || d.Region != "us-central1" {
+		t.Errorf("expected the resolved defaults, got %+v", d)
+	}
+}
+
+func TestDiagnose_MissingGCloud(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "gcloud")
+	base := NewBaseService(&config.Config{GCloudPath: missing, Project: "configured-project"})
+	base.Executor.SetDefaults(executor.Defaults{Project: "resolved-project"})
+
+	d := base.Diagnose()
+	if d.ResolvedGCloudPath != "" || d.GCloudError == "" {
+		t.Errorf("expected a lookup error, got %+v", d)
+	}
+	if d.GCloudPath != missing {
+		t.Errorf("expected the configured path %s, got %q", missing, d.GCloudPath)
+	}
+	if d.Project != "configured-project" {
+		t.Errorf("expected the configured project to win, got %q", d.Project)
+	}
+}
@@ -0,0 +1,283 @@
+// Package eventarc provides MCP tools for Eventarc triggers.
+package eventarc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gcloud-go-mcp/internal/executor"
+	"gcloud-go-mcp/internal/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RegisterTools registers all Eventarc tools with the MCP server.
+func RegisterTools(server *mcp.Server, base *services.BaseService) {
+	// List triggers
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_eventarc_triggers_list",
+			Description: "List Eventarc triggers",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"location": map[string]any{
+						"type":        "string",
+						"description": "Location of the triggers (e.g., us-central1, global); all locations when empty",
+					},
+					"filter": map[string]any{
+						"type":        "string",
+						"description": "Filter expression",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd := base.Executor.Command("eventarc", "triggers", "list").
+				WithFlag("location", services.GetOptionalString(args, "location", "")).
+				WithFlag("filter", services.GetOptionalString(args, "filter", "")).
+				WithProject(services.GetOptionalString(args, "project", ""))
+			if err := services.ApplyListOutput(cmd, args, csvColumns["triggers"]); err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Describe trigger
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_eventarc_triggers_describe",
+			Description: "Get details of an Eventarc trigger, including its event filters, destination and transport",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"trigger"},
+				"properties": map[string]any{
+					"trigger": map[string]any{
+						"type":        "string",
+						"description": "Trigger ID",
+					},
+					"location": map[string]any{
+						"type":        "string",
+						"description": "Location of the trigger (defaults to GCLOUD_REGION)",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"soft_not_found": services.SoftNotFoundProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := triggerCommand(base, "describe", args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.DescribeError(args, result, err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Create trigger
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_eventarc_triggers_create",
+			Description: "Create an Eventarc trigger that routes matching events to a Cloud Run service",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"trigger", "destination_run_service", "event_filters"},
+				"properties": map[string]any{
+					"trigger": map[string]any{
+						"type":        "string",
+						"description": "Trigger ID",
+					},
+					"location": map[string]any{
+						"type":        "string",
+						"description": "Location of the trigger (defaults to GCLOUD_REGION); must match the location of the event source, or global",
+					},
+					"destination_run_service": map[string]any{
+						"type":        "string",
+						"description": "Cloud Run service that receives the events",
+					},
+					"destination_run_region": map[string]any{
+						"type":        "string",
+						"description": "Region of the Cloud Run service (defaults to the trigger location)",
+					},
+					"destination_run_path": map[string]any{
+						"type":        "string",
+						"description": "Path on the service that events are sent to (e.g., /events)",
+					},
+					"event_filters": map[string]any{
+						"type":        "array",
+						"items":       map[string]any{"type": "string"},
+						"description": "Event filters as attribute=value; one must be the event type (e.g., [\"type=google.cloud.storage.object.v1.finalized\", \"bucket=my-bucket\"])",
+					},
+					"service_account": map[string]any{
+						"type":        "string",
+						"description": "Service account the trigger invokes the destination as",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := triggerCreateCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.DeployError(result, err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Delete trigger
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_eventarc_triggers_delete",
+			Description: "Delete an Eventarc trigger. Events stop being delivered to its destination",
+			Annotations: services.Destructive(),
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"trigger"},
+				"properties": map[string]any{
+					"trigger": map[string]any{
+						"type":        "string",
+						"description": "Trigger ID",
+					},
+					"location": map[string]any{
+						"type":        "string",
+						"description": "Location of the trigger (defaults to GCLOUD_REGION)",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := triggerCommand(base, "delete", args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.WithBoolFlag("quiet").Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+}
+
+func parseArgs(req *mcp.CallToolRequest) map[string]any {
+	var args map[string]any
+	if req.Params.Arguments != nil {
+		_ = json.Unmarshal(req.Params.Arguments, &args)
+	}
+	if args == nil {
+		args = make(map[string]any)
+	}
+	return args
+}
+
+// csvColumns are the default column projections used when a list tool is
+// called with output=csv, keyed by resource.
+var csvColumns = map[string]string{
+	"triggers": "name.basename(),destination.cloudRun.service,eventFilters[0].value,createTime",
+}
+
+// triggerCommand builds an `eventarc triggers <action> TRIGGER` command. The
+// location falls back to the default region.
+func triggerCommand(base *services.BaseService, action string, args map[string]any) (*executor.CommandBuilder, error) {
+	trigger, err := services.GetRequiredString(args, "trigger")
+	if err != nil {
+		return nil, err
+	}
+	location := services.GetOptionalString(args, "location", base.Config.Region)
+	if location == "" {
+		return nil, fmt.Errorf("location is required (pass location or set GCLOUD_REGION)")
+	}
+
+	return base.Executor.Command("eventarc", "triggers", action, trigger).
+		WithFlag("location", location).
+		WithProject(services.GetOptionalString(args, "project", "")), nil
+}
+
+// triggerCreateCommand builds the `eventarc triggers create` command, passing
+// each event filter as a repeated --event-filters flag.
+func triggerCreateCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	service, err := services.GetRequiredString(args, "destination_run_service")
+	if err != nil {
+		return nil, err
+	}
+	filters, err := eventFilters(services.GetOptionalStringArray(args, "event_filters"))
+	if err != nil {
+		return nil, err
+	}
+	cmd, err := triggerCommand(base, "create", args)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd.WithFlag("destination-run-service", service).
+		WithFlag("destination-run-region", services.GetOptionalString(args, "destination_run_region", "")).
+		WithFlag("destination-run-path", services.GetOptionalString(args, "destination_run_path", "")).
+		WithFlag("service-account", services.GetOptionalString(args, "service_account", ""))
+	for _, filter := range filters {
+		cmd.WithArrayFlag("event-filters", filter)
+	}
+	return cmd, nil
+}
+
+// eventFilters validates attribute=value event filters. Eventarc requires one
+// of them to match the event type.
+func eventFilters(filters []string) ([]string, error) {
+	if len(filters) == 0 {
+		return nil, fmt.Errorf("event_filters is required")
+	}
+
+	hasType := false
+	for _, filter := range filters {
+		attribute, value, ok := strings.Cut(filter, "=")
+		attribute = strings.TrimSpace(attribute)
+		if !ok || attribute == "" || strings.TrimSpace(value) == "" {
+			return nil, fmt.Errorf("invalid event filter %q: must be attribute=value", filter)
+		}
+		if attribute == "type" {
+			hasType = true
+		}
+	}
+	if !hasType {
+		return nil, fmt.Errorf("event_filters must include the event type (e.g., type=google.cloud.pubsub.topic.v1.messagePublished)")
+	}
+	return filters, nil
+}
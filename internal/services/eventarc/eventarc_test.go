@@ -0,0 +1,157 @@
+package eventarc
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"gcloud-go-mcp/internal/executor/executortest"
+	"gcloud-go-mcp/internal/services"
+	"gcloud-go-mcp/internal/services/servicetest"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func newTestBase() *services.BaseService {
+	return services.NewBaseService(servicetest.NewConfig())
+}
+
+func TestRegisterTools(t *testing.T) {
+	server := mcp.NewServer(
+		&mcp.Implementation{
+			Name:    "test-server",
+			Version: "0.0.1",
+		},
+		&mcp.ServerOptions{},
+	)
+
+	// Should not panic
+	RegisterTools(server, newTestBase())
+}
+
+func TestTriggerCreateCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "storage event",
+			args: map[string]any{
+				"trigger":                 "on-upload",
+				"destination_run_service": "thumbnailer",
+				"event_filters":           []any{"type=google.cloud.storage.object.v1.finalized", "bucket=uploads"},
+				"service_account":         "eventarc@test-project.iam.gserviceaccount.com",
+			},
+			want: []string{
+				"--location=us-central1",
+				"--destination-run-service=thumbnailer",
+				"--event-filters=type=google.cloud.storage.object.v1.finalized",
+				"--event-filters=bucket=uploads",
+				"--service-account=eventarc@test-project.iam.gserviceaccount.com",
+				"--project=test-project",
+			},
+		},
+		{
+			name: "explicit location and destination",
+			args: map[string]any{
+				"trigger":                 "on-publish",
+				"location":                "europe-west1",
+				"destination_run_service": "worker",
+				"destination_run_region":  "europe-west4",
+				"destination_run_path":    "/events",
+				"event_filters":           []any{"type=google.cloud.pubsub.topic.v1.messagePublished"},
+			},
+			want: []string{
+				"--location=europe-west1",
+				"--destination-run-region=europe-west4",
+				"--destination-run-path=/events",
+				"--event-filters=type=google.cloud.pubsub.topic.v1.messagePublished",
+			},
+		},
+		{
+			name: "no filters",
+			args: map[string]any{
+				"trigger":                 "on-upload",
+				"destination_run_service": "thumbnailer",
+			},
+			wantErr: "event_filters is required",
+		},
+		{
+			name: "malformed filter",
+			args: map[string]any{
+				"trigger":                 "on-upload",
+				"destination_run_service": "thumbnailer",
+				"event_filters":           []any{"type=google.cloud.storage.object.v1.finalized", "bucket"},
+			},
+			wantErr: `invalid event filter "bucket"`,
+		},
+		{
+			name: "no type filter",
+			args: map[string]any{
+				"trigger":                 "on-upload",
+				"destination_run_service": "thumbnailer",
+				"event_filters":           []any{"bucket=uploads"},
+			},
+			wantErr: "must include the event type",
+		},
+		{
+			name: "missing destination",
+			args: map[string]any{
+				"trigger":       "on-upload",
+				"event_filters": []any{"type=google.cloud.storage.object.v1.finalized"},
+			},
+			wantErr: "destination_run_service",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := triggerCreateCommand(newTestBase(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if !slices.Equal(args[:4], []string{"eventarc", "triggers", "create", tt.args["trigger"].(string)}) {
+				t.Errorf("unexpected command %v", args)
+			}
+			if missing := executortest.Missing(args, tt.want...); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, args)
+			}
+		})
+	}
+}
+
+func TestTriggerCommand_NoLocation(t *testing.T) {
+	base := newTestBase()
+	base.Config.Region = ""
+	if _, err := triggerCommand(base, "describe", map[string]any{"trigger": "on-upload"}); err == nil || !strings.Contains(err.Error(), "location is required") {
+		t.Errorf("expected a location error, got %v", err)
+	}
+}
+
+func TestToolCall_TriggersDelete(t *testing.T) {
+	runner := &executortest.Runner{}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_eventarc_triggers_delete", map[string]any{
+		"trigger":  "on-upload",
+		"location": "us-east1",
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+
+	args := runner.LastArgs()
+	if !slices.Equal(args[:4], []string{"eventarc", "triggers", "delete", "on-upload"}) {
+		t.Errorf("unexpected command %v", args)
+	}
+	if missing := executortest.Missing(args, "--location=us-east1", "--quiet"); len(missing) > 0 {
+		t.Errorf("missing %v in %v", missing, args)
+	}
+}
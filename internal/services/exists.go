@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+
+	"gcloud-go-mcp/internal/executor"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Existing is the result returned by create tools called with if_not_exists
+// when the resource already exists.
+type Existing struct {
+	Created  bool   `json:"created"`
+	Message  string `json:"message"`
+	Resource any    `json:"resource"`
+}
+
+// IfNotExistsProperty returns the input schema for the if_not_exists argument
+// accepted by create tools.
+func IfNotExistsProperty() map[string]any {
+	return map[string]any{
+		"type":        "boolean",
+		"description": "Check whether the resource exists first and return it instead of failing, so retried creates are safe",
+		"default":     false,
+	}
+}
+
+// ExistingResource runs describe for a create tool called with if_not_exists.
+// It returns a result describing the existing resource, or nil when
+// if_not_exists isn't set or the resource doesn't exist and should be created.
+// Errors other than not found are returned.
+func (b *BaseService) ExistingResource(ctx context.Context, args map[string]any, describe *executor.CommandBuilder) (*mcp.CallToolResult, error) {
+	if !GetOptionalBool(args, "if_not_exists", false) {
+		return nil, nil
+	}
+
+	result, err := describe.Execute(ctx)
+	if err != nil {
+		if IsNotFound(result, err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(Existing{
+		Created:  false,
+		Message:  "Resource already exists; it was not modified",
+		Resource: result.AsStructured(),
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return ToolResult(string(data)), nil
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"gcloud-go-mcp/internal/config"
+	"gcloud-go-mcp/internal/executor"
+	"gcloud-go-mcp/internal/executor/executortest"
+)
+
+func newExistsTestBase(runner *executortest.Runner) *BaseService {
+	return NewBaseServiceWithRunner(&config.Config{GCloudPath: "gcloud", CommandTimeout: time.Minute}, runner)
+}
+
+func TestExistingResource(t *testing.T) {
+	ctx := context.Background()
+	args := map[string]any{"if_not_exists": true}
+
+	t.Run("exists", func(t *testing.T) {
+		runner := &executortest.Runner{Stdout: `{"name":"projects/p/topics/orders"}`}
+		base := newExistsTestBase(runner)
+
+		result, err := base.ExistingResource(ctx, args, base.Executor.Command("pubsub", "topics", "describe", "orders"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Fatalf("expected an existing resource result, got %+v", result)
+		}
+
+		var got struct {
+			Created  bool           `json:"created"`
+			Resource map[string]any `json:"resource"`
+		}
+		if err := json.Unmarshal([]byte(resultText(t, result)), &got); err != nil {
+			t.Fatalf("result is not JSON: %v", err)
+		}
+		if got.Created || got.Resource["name"] != "projects/p/topics/orders" {
+			t.Errorf("unexpected result %+v", got)
+		}
+	})
+
+	t.Run("does not exist", func(t *testing.T) {
+		runner := &executortest.Runner{
+			Stderr: "ERROR: (gcloud.pubsub.topics.describe) NOT_FOUND: Resource not found (resource=orders).",
+			Err:    errors.New("exit status 1"),
+		}
+		base := newExistsTestBase(runner)
+
+		result, err := base.ExistingResource(ctx, args, base.Executor.Command("pubsub", "topics", "describe", "orders"))
+		if err != nil || result != nil {
+			t.Errorf("expected to proceed with create, got %+v, %v", result, err)
+		}
+	})
+
+	t.Run("describe fails", func(t *testing.T) {
+		runner := &executortest.Runner{
+			Stderr: "ERROR: (gcloud.pubsub.topics.describe) PERMISSION_DENIED: Permission denied",
+			Err:    errors.New("exit status 1"),
+		}
+		base := newExistsTestBase(runner)
+
+		if _, err := base.ExistingResource(ctx, args, base.Executor.Command("pubsub", "topics", "describe", "orders")); err == nil {
+			t.Error("expected the describe error to be returned")
+		}
+	})
+
+	t.Run("not requested", func(t *testing.T) {
+		runner := &executortest.Runner{Handler: func([]string) (*executor.Result, error) {
+			t.Error("describe should not run without if_not_exists")
+			return &executor.Result{}, nil
+		}}
+		base := newExistsTestBase(runner)
+
+		result, err := base.ExistingResource(ctx, map[string]any{}, base.Executor.Command("pubsub", "topics", "describe", "orders"))
+		if err != nil || result != nil {
+			t.Errorf("expected to proceed with create, got %+v, %v", result, err)
+		}
+	})
+}
@@ -4,7 +4,10 @@ package firestore
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"regexp"
 
+	"gcloud-go-mcp/internal/executor"
 	"gcloud-go-mcp/internal/services"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -12,7 +15,7 @@ import (
 // RegisterTools registers all Firestore tools with the MCP server.
 func RegisterTools(server *mcp.Server, base *services.BaseService) {
 	// List databases
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_firestore_databases_list",
 			Description: "List Firestore databases",
@@ -23,25 +26,31 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "string",
 						"description": "GCP project ID",
 					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
 				},
 			},
 		},
 		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			args := parseArgs(req)
 
-			result, err := base.Executor.Command("firestore", "databases", "list").
-				WithProject(services.GetOptionalString(args, "project", "")).
-				Execute(ctx)
+			cmd := base.Executor.Command("firestore", "databases", "list").
+				WithProject(services.GetOptionalString(args, "project", ""))
+
+			if err := services.ApplyListOutput(cmd, args, csvColumns["databases"]); err != nil {
+				return services.ToolError(err), nil
+			}
 
+			result, err := cmd.Execute(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Create database
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_firestore_databases_create",
 			Description: "Create a new Firestore database",
@@ -90,12 +99,12 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Describe database
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_firestore_databases_describe",
 			Description: "Get details of a Firestore database",
@@ -111,6 +120,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "string",
 						"description": "GCP project ID",
 					},
+					"soft_not_found": services.SoftNotFoundProperty(),
 				},
 			},
 		},
@@ -126,18 +136,102 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 				WithProject(services.GetOptionalString(args, "project", "")).
 				Execute(ctx)
 
+			if err != nil {
+				return services.DescribeError(args, result, err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Update database
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_firestore_databases_update",
+			Description: "Update delete protection and point-in-time recovery of a Firestore database",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"database"},
+				"properties": map[string]any{
+					"database": map[string]any{
+						"type":        "string",
+						"description": "Database ID",
+					},
+					"delete_protection": map[string]any{
+						"type":        "boolean",
+						"description": "Enable (true) or disable (false) delete protection",
+					},
+					"enable_pitr": map[string]any{
+						"type":        "boolean",
+						"description": "Enable (true) or disable (false) point-in-time recovery",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := databaseUpdateCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Delete database
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_firestore_databases_delete",
+			Description: "Delete a Firestore database (delete protection must be disabled)",
+			Annotations: services.Destructive(),
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"database"},
+				"properties": map[string]any{
+					"database": map[string]any{
+						"type":        "string",
+						"description": "Database ID",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			database, err := services.GetRequiredString(args, "database")
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			_, err = base.Executor.Command("firestore", "databases", "delete").
+				WithFlag("database", database).
+				WithProject(services.GetOptionalString(args, "project", "")).
+				WithBoolFlag("quiet").
+				Execute(ctx)
+
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return services.ToolResult("Database deleted successfully"), nil
 		},
 	)
 
 	// Export database
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_firestore_export",
-			Description: "Export Firestore data to Cloud Storage",
+			Description: "Export Firestore data to Cloud Storage. Returns the operation name for tracking with gcp_firestore_operations_describe",
 			InputSchema: map[string]any{
 				"type":     "object",
 				"required": []string{"output_uri_prefix"},
@@ -156,6 +250,10 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"description": "Collection IDs to export (empty = all)",
 						"items":       map[string]any{"type": "string"},
 					},
+					"async": map[string]any{
+						"type":        "boolean",
+						"description": "Return immediately with the operation name instead of waiting for completion",
+					},
 					"project": map[string]any{
 						"type":        "string",
 						"description": "GCP project ID",
@@ -180,19 +278,24 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 				}
 			}
 
+			if services.GetOptionalBool(args, "async", false) {
+				cmd.WithBoolFlag("async")
+			}
+
 			result, err := cmd.Execute(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.TextResult(operationOutput(result)), nil
 		},
 	)
 
 	// Import database
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_firestore_import",
-			Description: "Import Firestore data from Cloud Storage",
+			Description: "Import Firestore data from Cloud Storage. Returns the operation name for tracking with gcp_firestore_operations_describe",
+			Annotations: services.Destructive(),
 			InputSchema: map[string]any{
 				"type":     "object",
 				"required": []string{"input_uri_prefix"},
@@ -211,6 +314,10 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"description": "Collection IDs to import (empty = all)",
 						"items":       map[string]any{"type": "string"},
 					},
+					"async": map[string]any{
+						"type":        "boolean",
+						"description": "Return immediately with the operation name instead of waiting for completion",
+					},
 					"project": map[string]any{
 						"type":        "string",
 						"description": "GCP project ID",
@@ -235,16 +342,114 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 				}
 			}
 
+			if services.GetOptionalBool(args, "async", false) {
+				cmd.WithBoolFlag("async")
+			}
+
 			result, err := cmd.Execute(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.TextResult(operationOutput(result)), nil
+		},
+	)
+
+	// List operations
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_firestore_operations_list",
+			Description: "List long-running Firestore operations such as exports and imports",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"database": map[string]any{
+						"type":        "string",
+						"description": "Database ID (default: (default))",
+						"default":     "(default)",
+					},
+					"filter": map[string]any{
+						"type":        "string",
+						"description": "Filter expression (e.g., 'done=false')",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+
+			cmd := base.Executor.Command("firestore", "operations", "list").
+				WithFlag("database", services.GetOptionalString(args, "database", "(default)")).
+				WithProject(services.GetOptionalString(args, "project", ""))
+
+			if filter := services.GetOptionalString(args, "filter", ""); filter != "" {
+				cmd.WithFlag("filter", filter)
+			}
+
+			if err := services.ApplyListOutput(cmd, args, csvColumns["operations"]); err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Describe operation
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_firestore_operations_describe",
+			Description: "Get the status and progress of a Firestore operation",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"operation"},
+				"properties": map[string]any{
+					"operation": map[string]any{
+						"type":        "string",
+						"description": "Operation ID or full name (projects/.../databases/.../operations/...)",
+					},
+					"database": map[string]any{
+						"type":        "string",
+						"description": "Database ID (default: (default))",
+						"default":     "(default)",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"soft_not_found": services.SoftNotFoundProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			operation, err := services.GetRequiredString(args, "operation")
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := base.Executor.Command("firestore", "operations", "describe", operation).
+				WithFlag("database", services.GetOptionalString(args, "database", "(default)")).
+				WithProject(services.GetOptionalString(args, "project", "")).
+				Execute(ctx)
+
+			if err != nil {
+				return services.DescribeError(args, result, err), nil
+			}
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// List indexes
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_firestore_indexes_list",
 			Description: "List Firestore indexes",
@@ -260,21 +465,27 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "string",
 						"description": "GCP project ID",
 					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
 				},
 			},
 		},
 		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			args := parseArgs(req)
 
-			result, err := base.Executor.Command("firestore", "indexes", "composite", "list").
+			cmd := base.Executor.Command("firestore", "indexes", "composite", "list").
 				WithFlag("database", services.GetOptionalString(args, "database", "(default)")).
-				WithProject(services.GetOptionalString(args, "project", "")).
-				Execute(ctx)
+				WithProject(services.GetOptionalString(args, "project", ""))
+
+			if err := services.ApplyListOutput(cmd, args, csvColumns["indexes"]); err != nil {
+				return services.ToolError(err), nil
+			}
 
+			result, err := cmd.Execute(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 }
@@ -289,3 +500,72 @@ func parseArgs(req *mcp.CallToolRequest) map[string]any {
 	}
 	return args
 }
+
+// csvColumns are the default column projections used when a list tool is
+// called with output=csv, keyed by resource.
+var csvColumns = map[string]string{
+	"databases":  "name,locationId,type",
+	"operations": "name,metadata.operationState,metadata.startTime,done",
+	"indexes":    "name,queryScope,state",
+}
+
+// databaseUpdateCommand builds the `firestore databases update` command.
+// Toggles are only passed when the corresponding argument is present.
+func databaseUpdateCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	database, err := services.GetRequiredString(args, "database")
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := base.Executor.Command("firestore", "databases", "update").
+		WithFlag("database", database).
+		WithProject(services.GetOptionalString(args, "project", ""))
+
+	updated := false
+	for _, toggle := range []struct{ arg, flag string }{
+		{"delete_protection", "delete-protection"},
+		{"enable_pitr", "enable-pitr"},
+	} {
+		if _, ok := args[toggle.arg]; !ok {
+			continue
+		}
+		if services.GetOptionalBool(args, toggle.arg, false) {
+			cmd.WithBoolFlag(toggle.flag)
+		} else {
+			cmd.WithBoolFlag("no-" + toggle.flag)
+		}
+		updated = true
+	}
+
+	if !updated {
+		return nil, fmt.Errorf("no updates specified")
+	}
+	return cmd, nil
+}
+
+// operationNamePattern matches a Firestore long-running operation resource name.
+var operationNamePattern = regexp.MustCompile(`projects/[^/\s"']+/databases/[^/\s"']+/operations/[^/\s"'\]]+`)
+
+// operationName extracts the long-running operation name from export or
+// import output, preferring the "name" field of the JSON operation.
+func operationName(result *executor.Result) string {
+	var op struct {
+		Name string `json:"name"`
+	}
+	if err := result.ParseJSON(&op); err == nil && op.Name != "" {
+		return op.Name
+	}
+	if name := operationNamePattern.FindString(result.Stdout); name != "" {
+		return name
+	}
+	return operationNamePattern.FindString(result.Stderr)
+}
+
+// operationOutput prefixes the command output with the operation name, if any.
+func operationOutput(result *executor.Result) string {
+	name := operationName(result)
+	if name == "" {
+		return result.ToJSONString()
+	}
+	return fmt.Sprintf("Operation: %s\n\n%s", name, result.ToJSONString())
+}
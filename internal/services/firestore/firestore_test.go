@@ -0,0 +1,165 @@
+package firestore
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"gcloud-go-mcp/internal/executor"
+	"gcloud-go-mcp/internal/executor/executortest"
+	"gcloud-go-mcp/internal/services"
+	"gcloud-go-mcp/internal/services/servicetest"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func newTestBase() *services.BaseService {
+	return services.NewBaseService(servicetest.NewConfig())
+}
+
+func TestRegisterTools(t *testing.T) {
+	server := mcp.NewServer(
+		&mcp.Implementation{
+			Name:    "test-server",
+			Version: "0.0.1",
+		},
+		&mcp.ServerOptions{},
+	)
+
+	// Should not panic
+	RegisterTools(server, newTestBase())
+}
+
+func TestDatabaseUpdateCommand_Toggles(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		notWant []string
+		wantErr string
+	}{
+		{
+			name:    "enable both",
+			args:    map[string]any{"database": "orders", "delete_protection": true, "enable_pitr": true},
+			want:    []string{"firestore", "databases", "update", "--database=orders", "--delete-protection", "--enable-pitr"},
+			notWant: []string{"--no-delete-protection", "--no-enable-pitr"},
+		},
+		{
+			name:    "disable both",
+			args:    map[string]any{"database": "orders", "delete_protection": false, "enable_pitr": false},
+			want:    []string{"--no-delete-protection", "--no-enable-pitr"},
+			notWant: []string{"--delete-protection", "--enable-pitr"},
+		},
+		{
+			name:    "only pitr",
+			args:    map[string]any{"database": "orders", "enable_pitr": true},
+			want:    []string{"--enable-pitr"},
+			notWant: []string{"--delete-protection", "--no-delete-protection"},
+		},
+		{
+			name:    "nothing to update",
+			args:    map[string]any{"database": "orders"},
+			wantErr: "no updates specified",
+		},
+		{
+			name:    "missing database",
+			args:    map[string]any{"enable_pitr": true},
+			wantErr: "missing required parameter: database",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := databaseUpdateCommand(newTestBase(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			args := cmd.Build()
+			for _, w := range tt.want {
+				if !slices.Contains(args, w) {
+					t.Errorf("expected %q in args, got %v", w, args)
+				}
+			}
+			for _, nw := range tt.notWant {
+				if slices.Contains(args, nw) {
+					t.Errorf("did not expect %q in args, got %v", nw, args)
+				}
+			}
+		})
+	}
+}
+
+func TestOperationName(t *testing.T) {
+	const name = "projects/test-project/databases/(default)/operations/ASA3NDEwOTg0NjExChp0bHVhZmVkBxJsYXJ0bmVjc3Utc2Jvai1uaW1kYRQKLRI"
+
+	tests := []struct {
+		name   string
+		result *executor.Result
+		want   string
+	}{
+		{
+			name: "json operation",
+			result: &executor.Result{
+				JSON: json.RawMessage(`{"name": "` + name + `", "metadata": {"operationState": "PROCESSING"}}`),
+			},
+			want: name,
+		},
+		{
+			name: "async text output",
+			result: &executor.Result{
+				Stderr: "Waiting for [" + name + "] to finish...\n",
+			},
+			want: name,
+		},
+		{
+			name:   "no operation",
+			result: &executor.Result{Stdout: "done"},
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := operationName(tt.result); got != tt.want {
+				t.Errorf("operationName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOperationOutput(t *testing.T) {
+	result := &executor.Result{
+		JSON: json.RawMessage(`{"name":"projects/p/databases/(default)/operations/op-1"}`),
+	}
+	out := operationOutput(result)
+	if !strings.HasPrefix(out, "Operation: projects/p/databases/(default)/operations/op-1\n") {
+		t.Errorf("expected operation name first, got %q", out)
+	}
+}
+
+func TestToolCall_DatabasesDelete(t *testing.T) {
+	runner := &executortest.Runner{}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_firestore_databases_delete", map[string]any{
+		"database": "orders",
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+
+	args := runner.LastArgs()
+	if len(args) < 3 || !slices.Equal(args[:3], []string{"firestore", "databases", "delete"}) {
+		t.Errorf("unexpected command %v", args)
+	}
+	if missing := executortest.Missing(args, "--database=orders", "--quiet"); len(missing) > 0 {
+		t.Errorf("missing %v in %v", missing, args)
+	}
+	if text := servicetest.Text(result); !strings.Contains(text, "Database deleted successfully") {
+		t.Errorf("expected %q in result, got %q", "Database deleted successfully", text)
+	}
+}
@@ -5,16 +5,21 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 
+	"gcloud-go-mcp/internal/executor"
 	"gcloud-go-mcp/internal/services"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
+// logLevels are the values accepted by `functions logs read --min-log-level`.
+var logLevels = []string{"DEBUG", "INFO", "WARNING", "ERROR"}
+
 // RegisterTools registers all Cloud Functions tools with the MCP server.
 func RegisterTools(server *mcp.Server, base *services.BaseService) {
 	// List functions
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_functions_list",
 			Description: "List Cloud Functions",
@@ -29,6 +34,8 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "string",
 						"description": "Region",
 					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
 				},
 			},
 		},
@@ -42,22 +49,26 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 				cmd.WithFlag("regions", region)
 			}
 
+			if err := services.ApplyListOutput(cmd, args, csvColumns["functions"]); err != nil {
+				return services.ToolError(err), nil
+			}
+
 			result, err := cmd.Execute(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Describe function
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_functions_describe",
-			Description: "Get details of a Cloud Function",
+			Description: "Get details of a Cloud Function. The result includes generation (gen1 or gen2), as the two generations are described in different shapes",
 			InputSchema: map[string]any{
 				"type":     "object",
-				"required": []string{"function", "region"},
+				"required": []string{"function"},
 				"properties": map[string]any{
 					"function": map[string]any{
 						"type":        "string",
@@ -65,46 +76,45 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 					},
 					"region": map[string]any{
 						"type":        "string",
-						"description": "Region",
+						"description": "Region (defaults to GCLOUD_FUNCTIONS_REGION, then GCLOUD_REGION)",
+					},
+					"gen": map[string]any{
+						"type":        "string",
+						"description": "Generation of the function, when known (passes --gen2 or --no-gen2)",
+						"enum":        []string{"gen1", "gen2"},
 					},
 					"project": map[string]any{
 						"type":        "string",
 						"description": "GCP project ID",
 					},
+					"soft_not_found": services.SoftNotFoundProperty(),
 				},
 			},
 		},
 		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			args := parseArgs(req)
-			function, err := services.GetRequiredString(args, "function")
+			cmd, err := describeCommand(base, args)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			region, err := services.GetRequiredString(args, "region")
-			if err != nil {
-				return services.ToolError(err), nil
-			}
-
-			result, err := base.Executor.Command("functions", "describe", function).
-				WithRegion(region).
-				WithProject(services.GetOptionalString(args, "project", "")).
-				ExecuteWithRegion(ctx)
 
+			result, err := cmd.ExecuteWithRegion(ctx)
 			if err != nil {
-				return services.ToolError(err), nil
+				return services.DescribeError(args, result, err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			addGeneration(result, services.GetOptionalString(args, "gen", ""))
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Deploy function
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_functions_deploy",
 			Description: "Deploy a Cloud Function",
 			InputSchema: map[string]any{
 				"type":     "object",
-				"required": []string{"function", "runtime", "region"},
+				"required": []string{"function", "runtime"},
 				"properties": map[string]any{
 					"function": map[string]any{
 						"type":        "string",
@@ -116,7 +126,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 					},
 					"region": map[string]any{
 						"type":        "string",
-						"description": "Region",
+						"description": "Region (defaults to GCLOUD_FUNCTIONS_REGION, then GCLOUD_REGION)",
 					},
 					"trigger_http": map[string]any{
 						"type":        "boolean",
@@ -150,6 +160,18 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "object",
 						"description": "Environment variables",
 					},
+					"build_env_vars": map[string]any{
+						"type":        "object",
+						"description": "Build-time environment variables as key-value pairs (e.g., GOOGLE_BUILDABLE)",
+					},
+					"set_secrets": map[string]any{
+						"type":        "object",
+						"description": "Secrets exposed as environment variables, mapping the variable name to SECRET or SECRET:VERSION (latest if omitted)",
+					},
+					"secret_volumes": map[string]any{
+						"type":        "object",
+						"description": "Secrets mounted as files, mapping an absolute mount path to SECRET or SECRET:VERSION (latest if omitted)",
+					},
 					"service_account": map[string]any{
 						"type":        "string",
 						"description": "Service account email",
@@ -172,81 +194,28 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 		},
 		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			args := parseArgs(req)
-			function, err := services.GetRequiredString(args, "function")
+			cmd, err := deployCommand(base, args)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			runtime, err := services.GetRequiredString(args, "runtime")
-			if err != nil {
-				return services.ToolError(err), nil
-			}
-			region, err := services.GetRequiredString(args, "region")
-			if err != nil {
-				return services.ToolError(err), nil
-			}
-
-			cmd := base.Executor.Command("functions", "deploy", function).
-				WithFlag("runtime", runtime).
-				WithRegion(region).
-				WithProject(services.GetOptionalString(args, "project", ""))
-
-			if services.GetOptionalBool(args, "gen2", true) {
-				cmd.WithBoolFlag("gen2")
-			}
-
-			if services.GetOptionalBool(args, "trigger_http", false) {
-				cmd.WithBoolFlag("trigger-http")
-			}
-			if topic := services.GetOptionalString(args, "trigger_topic", ""); topic != "" {
-				cmd.WithFlag("trigger-topic", topic)
-			}
-			if bucket := services.GetOptionalString(args, "trigger_bucket", ""); bucket != "" {
-				cmd.WithFlag("trigger-bucket", bucket)
-			}
-			if entryPoint := services.GetOptionalString(args, "entry_point", ""); entryPoint != "" {
-				cmd.WithFlag("entry-point", entryPoint)
-			}
-			if source := services.GetOptionalString(args, "source", ""); source != "" {
-				cmd.WithFlag("source", source)
-			}
-			if memory := services.GetOptionalString(args, "memory", ""); memory != "" {
-				cmd.WithFlag("memory", memory)
-			}
-			if timeout := services.GetOptionalString(args, "timeout", ""); timeout != "" {
-				cmd.WithFlag("timeout", timeout)
-			}
-			if sa := services.GetOptionalString(args, "service_account", ""); sa != "" {
-				cmd.WithFlag("service-account", sa)
-			}
-
-			if envVars := services.GetOptionalStringMap(args, "env_vars"); len(envVars) > 0 {
-				var pairs []string
-				for k, v := range envVars {
-					pairs = append(pairs, fmt.Sprintf("%s=%s", k, v))
-				}
-				cmd.WithFlag("set-env-vars", strings.Join(pairs, ","))
-			}
-
-			if services.GetOptionalBool(args, "allow_unauthenticated", false) {
-				cmd.WithBoolFlag("allow-unauthenticated")
-			}
 
 			result, err := cmd.ExecuteWithRegion(ctx)
 			if err != nil {
-				return services.ToolError(err), nil
+				return services.DeployError(result, err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Delete function
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_functions_delete",
 			Description: "Delete a Cloud Function",
+			Annotations: services.Destructive(),
 			InputSchema: map[string]any{
 				"type":     "object",
-				"required": []string{"function", "region"},
+				"required": []string{"function"},
 				"properties": map[string]any{
 					"function": map[string]any{
 						"type":        "string",
@@ -254,7 +223,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 					},
 					"region": map[string]any{
 						"type":        "string",
-						"description": "Region",
+						"description": "Region (defaults to GCLOUD_FUNCTIONS_REGION, then GCLOUD_REGION)",
 					},
 					"project": map[string]any{
 						"type":        "string",
@@ -269,7 +238,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			region, err := services.GetRequiredString(args, "region")
+			region, err := functionRegion(base, args)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
@@ -288,13 +257,13 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 	)
 
 	// Call function
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_functions_call",
 			Description: "Call a Cloud Function",
 			InputSchema: map[string]any{
 				"type":     "object",
-				"required": []string{"function", "region"},
+				"required": []string{"function"},
 				"properties": map[string]any{
 					"function": map[string]any{
 						"type":        "string",
@@ -302,7 +271,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 					},
 					"region": map[string]any{
 						"type":        "string",
-						"description": "Region",
+						"description": "Region (defaults to GCLOUD_FUNCTIONS_REGION, then GCLOUD_REGION)",
 					},
 					"data": map[string]any{
 						"type":        "string",
@@ -321,7 +290,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			region, err := services.GetRequiredString(args, "region")
+			region, err := functionRegion(base, args)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
@@ -338,18 +307,18 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.Stdout), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Read function logs
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_functions_logs_read",
 			Description: "Read logs for a Cloud Function",
 			InputSchema: map[string]any{
 				"type":     "object",
-				"required": []string{"function", "region"},
+				"required": []string{"function"},
 				"properties": map[string]any{
 					"function": map[string]any{
 						"type":        "string",
@@ -357,7 +326,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 					},
 					"region": map[string]any{
 						"type":        "string",
-						"description": "Region",
+						"description": "Region (defaults to GCLOUD_FUNCTIONS_REGION, then GCLOUD_REGION)",
 					},
 					"project": map[string]any{
 						"type":        "string",
@@ -371,36 +340,23 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 					"min_log_level": map[string]any{
 						"type":        "string",
 						"description": "Minimum log level",
-						"enum":        []string{"DEBUG", "INFO", "ERROR"},
+						"enum":        logLevels,
 					},
 				},
 			},
 		},
 		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			args := parseArgs(req)
-			function, err := services.GetRequiredString(args, "function")
-			if err != nil {
-				return services.ToolError(err), nil
-			}
-			region, err := services.GetRequiredString(args, "region")
+			cmd, err := logsReadCommand(base, args)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
 
-			cmd := base.Executor.Command("functions", "logs", "read", function).
-				WithRegion(region).
-				WithProject(services.GetOptionalString(args, "project", "")).
-				WithFlag("limit", fmt.Sprintf("%d", services.GetOptionalInt(args, "limit", 50)))
-
-			if minLevel := services.GetOptionalString(args, "min_log_level", ""); minLevel != "" {
-				cmd.WithFlag("min-log-level", minLevel)
-			}
-
-			result, err := cmd.Execute(ctx)
+			result, err := cmd.ExecuteWithRegion(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 }
@@ -415,3 +371,184 @@ func parseArgs(req *mcp.CallToolRequest) map[string]any {
 	}
 	return args
 }
+
+// csvColumns are the default column projections used when a list tool is
+// called with output=csv, keyed by resource.
+var csvColumns = map[string]string{
+	"functions": "name,state,environment,updateTime",
+}
+
+// logsReadCommand builds the `functions logs read` command, rejecting unknown
+// minimum log levels before they reach gcloud.
+func logsReadCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	function, err := services.GetRequiredString(args, "function")
+	if err != nil {
+		return nil, err
+	}
+	region, err := functionRegion(base, args)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := base.Executor.Command("functions", "logs", "read", function).
+		WithRegion(region).
+		WithProject(services.GetOptionalString(args, "project", "")).
+		WithFlag("limit", fmt.Sprintf("%d", services.GetOptionalInt(args, "limit", 50)))
+
+	if minLevel := services.GetOptionalString(args, "min_log_level", ""); minLevel != "" {
+		level := strings.ToUpper(minLevel)
+		if !slices.Contains(logLevels, level) {
+			return nil, fmt.Errorf("invalid min_log_level %q: must be one of %s", minLevel, strings.Join(logLevels, ", "))
+		}
+		cmd.WithFlag("min-log-level", level)
+	}
+	return cmd, nil
+}
+
+// functionRegion returns the region argument, falling back to the configured
+// Cloud Functions region (GCLOUD_FUNCTIONS_REGION) and then the default region.
+func functionRegion(base *services.BaseService, args map[string]any) (string, error) {
+	region := services.GetOptionalString(args, "region", base.Config.FunctionsRegion)
+	if region == "" {
+		region = base.Config.Region
+	}
+	if region == "" {
+		return "", fmt.Errorf("region is required (pass region or set GCLOUD_FUNCTIONS_REGION or GCLOUD_REGION)")
+	}
+	return region, nil
+}
+
+// describeCommand builds the `functions describe` command, selecting the
+// generation with --gen2 or --no-gen2 when the gen argument is given.
+func describeCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	function, err := services.GetRequiredString(args, "function")
+	if err != nil {
+		return nil, err
+	}
+	region, err := functionRegion(base, args)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := base.Executor.Command("functions", "describe", function).
+		WithRegion(region).
+		WithProject(services.GetOptionalString(args, "project", ""))
+
+	switch gen := services.GetOptionalString(args, "gen", ""); gen {
+	case "":
+	case "gen1":
+		cmd.WithBoolFlag("no-gen2")
+	case "gen2":
+		cmd.WithBoolFlag("gen2")
+	default:
+		return nil, fmt.Errorf("gen must be gen1 or gen2, got %q", gen)
+	}
+	return cmd, nil
+}
+
+// addGeneration adds a generation field to a described function. The
+// generation is read from the environment field, then inferred from the
+// shape of the function: gen2 functions have a serviceConfig, gen1 functions
+// a top-level runtime. When neither applies, hint is used. Output that is not
+// a JSON object is left as is.
+func addGeneration(result *executor.Result, hint string) {
+	var fields map[string]json.RawMessage
+	if err := result.ParseJSON(&fields); err != nil || fields == nil {
+		return
+	}
+
+	generation := hint
+	var environment string
+	_ = json.Unmarshal(fields["environment"], &environment)
+	switch {
+	case environment == "GEN_2":
+		generation = "gen2"
+	case environment == "GEN_1":
+		generation = "gen1"
+	case fields["serviceConfig"] != nil || fields["buildConfig"] != nil:
+		generation = "gen2"
+	case fields["runtime"] != nil:
+		generation = "gen1"
+	}
+	if generation == "" {
+		return
+	}
+
+	fields["generation"], _ = json.Marshal(generation)
+	if data, err := json.Marshal(fields); err == nil {
+		result.JSON = data
+	}
+}
+
+// deployCommand builds the `functions deploy` command.
+func deployCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	if err := services.RequireAll(args, "function", "runtime"); err != nil {
+		return nil, err
+	}
+	function, _ := services.GetRequiredString(args, "function")
+	runtime, _ := services.GetRequiredString(args, "runtime")
+	region, err := functionRegion(base, args)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := base.Executor.Command("functions", "deploy", function).
+		WithFlag("runtime", runtime).
+		WithRegion(region).
+		WithProject(services.GetOptionalString(args, "project", ""))
+
+	if services.GetOptionalBool(args, "gen2", true) {
+		cmd.WithBoolFlag("gen2")
+	}
+
+	if services.GetOptionalBool(args, "trigger_http", false) {
+		cmd.WithBoolFlag("trigger-http")
+	}
+	if topic := services.GetOptionalString(args, "trigger_topic", ""); topic != "" {
+		cmd.WithFlag("trigger-topic", topic)
+	}
+	if bucket := services.GetOptionalString(args, "trigger_bucket", ""); bucket != "" {
+		cmd.WithFlag("trigger-bucket", bucket)
+	}
+	if entryPoint := services.GetOptionalString(args, "entry_point", ""); entryPoint != "" {
+		cmd.WithFlag("entry-point", entryPoint)
+	}
+	if source := services.GetOptionalString(args, "source", ""); source != "" {
+		cmd.WithFlag("source", source)
+	}
+	if memory := services.GetOptionalString(args, "memory", ""); memory != "" {
+		cmd.WithFlag("memory", memory)
+	}
+	if timeout := services.GetOptionalString(args, "timeout", ""); timeout != "" {
+		cmd.WithFlag("timeout", timeout)
+	}
+	if sa := services.GetOptionalString(args, "service_account", ""); sa != "" {
+		cmd.WithFlag("service-account", sa)
+	}
+
+	if envVars := services.GetOptionalStringMap(args, "env_vars"); len(envVars) > 0 {
+		var pairs []string
+		for k, v := range envVars {
+			pairs = append(pairs, fmt.Sprintf("%s=%s", k, v))
+		}
+		cmd.WithFlag("set-env-vars", strings.Join(pairs, ","))
+	}
+
+	if buildEnvVars := services.GetOptionalStringMap(args, "build_env_vars"); len(buildEnvVars) > 0 {
+		cmd.WithFlag("set-build-env-vars", services.FormatLabels(buildEnvVars))
+	}
+
+	secrets, err := services.SecretMappings(
+		services.GetOptionalStringMap(args, "set_secrets"),
+		services.GetOptionalStringMap(args, "secret_volumes"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	cmd.WithFlag("set-secrets", secrets)
+
+	if services.GetOptionalBool(args, "allow_unauthenticated", false) {
+		cmd.WithBoolFlag("allow-unauthenticated")
+	}
+	return cmd, nil
+}
@@ -0,0 +1,320 @@
+package functions
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"gcloud-go-mcp/internal/executor/executortest"
+	"gcloud-go-mcp/internal/services"
+	"gcloud-go-mcp/internal/services/servicetest"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func newTestBase() *services.BaseService {
+	return services.NewBaseService(servicetest.NewConfig())
+}
+
+func TestRegisterTools(t *testing.T) {
+	server := mcp.NewServer(
+		&mcp.Implementation{
+			Name:    "test-server",
+			Version: "0.0.1",
+		},
+		&mcp.ServerOptions{},
+	)
+
+	// Should not panic
+	RegisterTools(server, newTestBase())
+}
+
+func TestLogsReadCommand_MinLogLevel(t *testing.T) {
+	tests := []struct {
+		level   string
+		want    string
+		wantErr bool
+	}{
+		{level: "DEBUG", want: "--min-log-level=DEBUG"},
+		{level: "INFO", want: "--min-log-level=INFO"},
+		{level: "WARNING", want: "--min-log-level=WARNING"},
+		{level: "warning", want: "--min-log-level=WARNING"},
+		{level: "ERROR", want: "--min-log-level=ERROR"},
+		{level: "WARN", wantErr: true},
+		{level: "FATAL", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			cmd, err := logsReadCommand(newTestBase(), map[string]any{
+				"function":      "handler",
+				"region":        "us-east1",
+				"min_log_level": tt.level,
+			})
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "invalid min_log_level") {
+					t.Fatalf("expected invalid level error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			args := cmd.Build()
+			if !slices.Contains(args, tt.want) {
+				t.Errorf("expected %q in args, got %v", tt.want, args)
+			}
+		})
+	}
+}
+
+func TestLogsReadCommand_NoLevel(t *testing.T) {
+	cmd, err := logsReadCommand(newTestBase(), map[string]any{"function": "handler", "region": "us-east1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, arg := range cmd.Build() {
+		if strings.HasPrefix(arg, "--min-log-level") {
+			t.Errorf("did not expect min-log-level flag, got %q", arg)
+		}
+	}
+	if cmd.GetRegion() != "us-east1" {
+		t.Errorf("expected region us-east1, got %q", cmd.GetRegion())
+	}
+}
+
+func TestToolCall_LogsRead(t *testing.T) {
+	runner := &executortest.Runner{}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_functions_logs_read", map[string]any{
+		"function":      "handler",
+		"region":        "us-east1",
+		"min_log_level": "warning",
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+
+	args := runner.LastArgs()
+	if len(args) < 4 || !slices.Equal(args[:4], []string{"functions", "logs", "read", "handler"}) {
+		t.Errorf("unexpected command %v", args)
+	}
+	if missing := executortest.Missing(args, "--region=us-east1", "--min-log-level=WARNING", "--limit=50"); len(missing) > 0 {
+		t.Errorf("missing %v in %v", missing, args)
+	}
+}
+
+func TestDeployCommand_Secrets(t *testing.T) {
+	cmd, err := deployCommand(newTestBase(), map[string]any{
+		"function":       "hello",
+		"runtime":        "go122",
+		"region":         "us-central1",
+		"build_env_vars": map[string]any{"GOOGLE_BUILDABLE": "./cmd/hello", "GOFLAGS": "-mod=vendor"},
+		"set_secrets":    map[string]any{"DB_PASSWORD": "db-password:3", "API_KEY": "api-key"},
+		"secret_volumes": map[string]any{"/etc/tls": "tls-cert"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	built := cmd.Build()
+	want := []string{
+		"--set-build-env-vars=GOFLAGS=-mod=vendor,GOOGLE_BUILDABLE=./cmd/hello",
+		"--set-secrets=API_KEY=api-key:latest,DB_PASSWORD=db-password:3,/etc/tls=tls-cert:latest",
+	}
+	if missing := executortest.Missing(built, want...); len(missing) > 0 {
+		t.Errorf("missing %v in %v", missing, built)
+	}
+}
+
+func TestDeployCommand_InvalidSecretVolume(t *testing.T) {
+	_, err := deployCommand(newTestBase(), map[string]any{
+		"function":       "hello",
+		"runtime":        "go122",
+		"region":         "us-central1",
+		"secret_volumes": map[string]any{"etc/tls": "tls-cert"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "must be absolute") {
+		t.Errorf("expected mount path error, got %v", err)
+	}
+}
+
+func TestFunctionRegion(t *testing.T) {
+	tests := []struct {
+		name            string
+		region          string
+		functionsRegion string
+		args            map[string]any
+		want            string
+		wantErr         bool
+	}{
+		{"default region", "us-central1", "", map[string]any{}, "us-central1", false},
+		{"functions region", "us-central1", "us-east1", map[string]any{}, "us-east1", false},
+		{"argument", "us-central1", "us-east1", map[string]any{"region": "europe-west1"}, "europe-west1", false},
+		{"none", "", "", map[string]any{}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := newTestBase()
+			base.Config.Region = tt.region
+			base.Config.FunctionsRegion = tt.functionsRegion
+
+			got, err := functionRegion(base, tt.args)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("functionRegion() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeployCommand_ReportsAllMissing(t *testing.T) {
+	_, err := deployCommand(newTestBase(), map[string]any{"region": "us-central1"})
+	if err == nil || !strings.Contains(err.Error(), "missing required parameters: function, runtime") {
+		t.Errorf("expected both missing parameters in one error, got %v", err)
+	}
+}
+
+func TestDescribeCommand_Gen(t *testing.T) {
+	tests := []struct {
+		name    string
+		gen     string
+		want    string
+		wantErr string
+	}{
+		{name: "gen1", gen: "gen1", want: "--no-gen2"},
+		{name: "gen2", gen: "gen2", want: "--gen2"},
+		{name: "unknown", gen: "gen3", wantErr: "gen1 or gen2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := describeCommand(newTestBase(), map[string]any{"function": "handler", "gen": tt.gen})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if missing := executortest.Missing(cmd.Build(), tt.want); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, cmd.Build())
+			}
+		})
+	}
+
+	cmd, err := describeCommand(newTestBase(), map[string]any{"function": "handler"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, arg := range cmd.Build() {
+		if arg == "--gen2" || arg == "--no-gen2" {
+			t.Errorf("expected no generation flag without gen, got %v", cmd.Build())
+		}
+	}
+}
+
+func TestToolCall_DescribeGeneration(t *testing.T) {
+	tests := []struct {
+		name   string
+		stdout string
+		gen    string
+		want   string
+	}{
+		{
+			name:   "gen2 environment",
+			stdout: `{"name": "projects/p/locations/us-central1/functions/handler", "environment": "GEN_2", "serviceConfig": {"uri": "https://handler-abc.a.run.app"}}`,
+			want:   "gen2",
+		},
+		{
+			name:   "gen1 environment",
+			stdout: `{"name": "projects/p/locations/us-central1/functions/handler", "environment": "GEN_1"}`,
+			want:   "gen1",
+		},
+		{
+			name:   "gen2 shape",
+			stdout: `{"name": "projects/p/locations/us-central1/functions/handler", "buildConfig": {"runtime": "go122"}}`,
+			want:   "gen2",
+		},
+		{
+			name:   "gen1 shape",
+			stdout: `{"name": "projects/p/locations/us-central1/functions/handler", "runtime": "python312", "httpsTrigger": {}}`,
+			want:   "gen1",
+		},
+		{
+			name:   "hint",
+			stdout: `{"name": "projects/p/locations/us-central1/functions/handler"}`,
+			gen:    "gen1",
+			want:   "gen1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &executortest.Runner{Stdout: tt.stdout}
+			args := map[string]any{"function": "handler"}
+			if tt.gen != "" {
+				args["gen"] = tt.gen
+			}
+			result := servicetest.CallTool(t, RegisterTools, runner, "gcp_functions_describe", args)
+			if result.IsError {
+				t.Fatalf("unexpected error: %s", servicetest.Text(result))
+			}
+
+			var described map[string]any
+			if err := json.Unmarshal([]byte(servicetest.Text(result)), &described); err != nil {
+				t.Fatalf("expected JSON output, got %s", servicetest.Text(result))
+			}
+			if described["generation"] != tt.want {
+				t.Errorf("expected generation %q, got %v", tt.want, described["generation"])
+			}
+			if described["name"] != "projects/p/locations/us-central1/functions/handler" {
+				t.Errorf("expected the function fields to be kept, got %v", described)
+			}
+		})
+	}
+}
+
+func TestToolCall_CallStructuredOutput(t *testing.T) {
+	tests := []struct {
+		name   string
+		stdout string
+		want   any
+	}{
+		{name: "json body", stdout: `{"status": "ok"}` + "\n", want: map[string]any{"status": "ok"}},
+		{name: "text body", stdout: "Hello World!", want: "Hello World!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := servicetest.NewConfig()
+			cfg.IncludeMetadata = true
+			runner := &executortest.Runner{Stdout: tt.stdout}
+			result := servicetest.CallToolWithConfig(t, cfg, RegisterTools, runner, "gcp_functions_call", map[string]any{
+				"function": "hello",
+			})
+			if result.IsError {
+				t.Fatalf("unexpected error: %s", servicetest.Text(result))
+			}
+
+			var envelope services.ResultEnvelope
+			if err := json.Unmarshal([]byte(servicetest.Text(result)), &envelope); err != nil {
+				t.Fatalf("result is not an envelope: %v", err)
+			}
+			if !reflect.DeepEqual(envelope.Result, tt.want) {
+				t.Errorf("result = %#v, want %#v", envelope.Result, tt.want)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"gcloud-go-mcp/internal/executor"
 	"gcloud-go-mcp/internal/services"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -13,7 +14,7 @@ import (
 // RegisterTools registers all GKE tools with the MCP server.
 func RegisterTools(server *mcp.Server, base *services.BaseService) {
 	// List clusters
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_gke_clusters_list",
 			Description: "List GKE clusters",
@@ -28,6 +29,8 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "string",
 						"description": "Region (leave empty for all regions)",
 					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
 				},
 			},
 		},
@@ -41,16 +44,20 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 				cmd.WithFlag("region", region)
 			}
 
+			if err := services.ApplyListOutput(cmd, args, csvColumns["clusters"]); err != nil {
+				return services.ToolError(err), nil
+			}
+
 			result, err := cmd.Execute(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Describe cluster
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_gke_clusters_describe",
 			Description: "Get details of a GKE cluster",
@@ -74,6 +81,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "string",
 						"description": "GCP project ID",
 					},
+					"soft_not_found": services.SoftNotFoundProperty(),
 				},
 			},
 		},
@@ -96,14 +104,14 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 
 			result, err := cmd.Execute(ctx)
 			if err != nil {
-				return services.ToolError(err), nil
+				return services.DescribeError(args, result, err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Create cluster
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_gke_clusters_create",
 			Description: "Create a GKE cluster",
@@ -184,17 +192,18 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 
 			result, err := cmd.Execute(ctx)
 			if err != nil {
-				return services.ToolError(err), nil
+				return services.DeployError(result, err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Delete cluster
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_gke_clusters_delete",
 			Description: "Delete a GKE cluster",
+			Annotations: services.Destructive(),
 			InputSchema: map[string]any{
 				"type":     "object",
 				"required": []string{"cluster"},
@@ -245,7 +254,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 	)
 
 	// Get credentials
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_gke_clusters_get_credentials",
 			Description: "Get kubeconfig credentials for a GKE cluster",
@@ -293,12 +302,12 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult("Credentials fetched successfully.\n" + result.Stderr), nil
+			return services.ToolResult("Credentials fetched successfully.\n" + executor.CleanStderr(result.Stderr)), nil
 		},
 	)
 
 	// List node pools
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_gke_node_pools_list",
 			Description: "List node pools in a GKE cluster",
@@ -322,6 +331,8 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "string",
 						"description": "GCP project ID",
 					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
 				},
 			},
 		},
@@ -343,11 +354,15 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 				cmd.WithFlag("zone", zone)
 			}
 
+			if err := services.ApplyListOutput(cmd, args, csvColumns["node-pools"]); err != nil {
+				return services.ToolError(err), nil
+			}
+
 			result, err := cmd.Execute(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 }
@@ -362,3 +377,10 @@ func parseArgs(req *mcp.CallToolRequest) map[string]any {
 	}
 	return args
 }
+
+// csvColumns are the default column projections used when a list tool is
+// called with output=csv, keyed by resource.
+var csvColumns = map[string]string{
+	"clusters":   "name,location,currentMasterVersion,currentNodeCount,status",
+	"node-pools": "name,config.machineType,initialNodeCount,version,status",
+}
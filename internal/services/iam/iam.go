@@ -4,7 +4,10 @@ package iam
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strings"
 
+	"gcloud-go-mcp/internal/executor"
 	"gcloud-go-mcp/internal/services"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -12,7 +15,7 @@ import (
 // RegisterTools registers all IAM tools with the MCP server.
 func RegisterTools(server *mcp.Server, base *services.BaseService) {
 	// List service accounts
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_iam_service_accounts_list",
 			Description: "List service accounts in a project",
@@ -23,24 +26,30 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "string",
 						"description": "GCP project ID",
 					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
 				},
 			},
 		},
 		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			args := parseArgs(req)
-			result, err := base.Executor.Command("iam", "service-accounts", "list").
-				WithProject(services.GetOptionalString(args, "project", "")).
-				Execute(ctx)
+			cmd := base.Executor.Command("iam", "service-accounts", "list").
+				WithProject(services.GetOptionalString(args, "project", ""))
 
+			if err := services.ApplyListOutput(cmd, args, csvColumns["service-accounts"]); err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Create service account
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_iam_service_accounts_create",
 			Description: "Create a service account",
@@ -88,15 +97,16 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Delete service account
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_iam_service_accounts_delete",
 			Description: "Delete a service account",
+			Annotations: services.Destructive(),
 			InputSchema: map[string]any{
 				"type":     "object",
 				"required": []string{"email"},
@@ -132,7 +142,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 	)
 
 	// Describe service account
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_iam_service_accounts_describe",
 			Description: "Get details of a service account",
@@ -148,6 +158,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "string",
 						"description": "GCP project ID",
 					},
+					"soft_not_found": services.SoftNotFoundProperty(),
 				},
 			},
 		},
@@ -163,14 +174,14 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 				Execute(ctx)
 
 			if err != nil {
-				return services.ToolError(err), nil
+				return services.DescribeError(args, result, err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// List service account keys
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_iam_service_accounts_keys_list",
 			Description: "List keys for a service account",
@@ -186,6 +197,8 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "string",
 						"description": "GCP project ID",
 					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
 				},
 			},
 		},
@@ -196,20 +209,24 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 				return services.ToolError(err), nil
 			}
 
-			result, err := base.Executor.Command("iam", "service-accounts", "keys", "list").
+			cmd := base.Executor.Command("iam", "service-accounts", "keys", "list").
 				WithFlag("iam-account", email).
-				WithProject(services.GetOptionalString(args, "project", "")).
-				Execute(ctx)
+				WithProject(services.GetOptionalString(args, "project", ""))
+
+			if err := services.ApplyListOutput(cmd, args, csvColumns["keys"]); err != nil {
+				return services.ToolError(err), nil
+			}
 
+			result, err := cmd.Execute(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Create service account key
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_iam_service_accounts_keys_create",
 			Description: "Create a new key for a service account (outputs to stdout)",
@@ -253,12 +270,98 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.Stdout), nil
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Add service account IAM policy binding
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_iam_service_accounts_add_iam_policy_binding",
+			Description: "Grant a member a role on a service account itself, such as roles/iam.serviceAccountUser to let it act as the service account",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"email", "member", "role"},
+				"properties": map[string]any{
+					"email": map[string]any{
+						"type":        "string",
+						"description": "Service account email",
+					},
+					"member": map[string]any{
+						"type":        "string",
+						"description": "Member to grant the role to (e.g., user:alice@example.com, serviceAccount:ci@project.iam.gserviceaccount.com)",
+					},
+					"role": map[string]any{
+						"type":        "string",
+						"description": "Role to grant (e.g., roles/iam.serviceAccountUser, roles/iam.serviceAccountTokenCreator)",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := serviceAccountIAMBindingCommand(base, "add-iam-policy-binding", args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Remove service account IAM policy binding
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_iam_service_accounts_remove_iam_policy_binding",
+			Description: "Revoke a role granted to a member on a service account",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"email", "member", "role"},
+				"properties": map[string]any{
+					"email": map[string]any{
+						"type":        "string",
+						"description": "Service account email",
+					},
+					"member": map[string]any{
+						"type":        "string",
+						"description": "Member to revoke the role from",
+					},
+					"role": map[string]any{
+						"type":        "string",
+						"description": "Role to revoke",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := serviceAccountIAMBindingCommand(base, "remove-iam-policy-binding", args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// List roles
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_iam_roles_list",
 			Description: "List IAM roles",
@@ -273,6 +376,8 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "boolean",
 						"description": "Include deleted roles",
 					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
 				},
 			},
 		},
@@ -287,16 +392,20 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 				cmd.WithBoolFlag("show-deleted")
 			}
 
+			if err := services.ApplyListOutput(cmd, args, csvColumns["roles"]); err != nil {
+				return services.ToolError(err), nil
+			}
+
 			result, err := cmd.Execute(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Describe role
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_iam_roles_describe",
 			Description: "Get details of an IAM role",
@@ -308,6 +417,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "string",
 						"description": "Role ID (e.g., roles/viewer or projects/PROJECT/roles/ROLE)",
 					},
+					"soft_not_found": services.SoftNotFoundProperty(),
 				},
 			},
 		},
@@ -322,14 +432,14 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 				Execute(ctx)
 
 			if err != nil {
-				return services.ToolError(err), nil
+				return services.DescribeError(args, result, err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Get project IAM policy
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_projects_get_iam_policy",
 			Description: "Get IAM policy for a project",
@@ -357,12 +467,12 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Add project IAM policy binding
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_projects_add_iam_policy_binding",
 			Description: "Add IAM policy binding to a project",
@@ -382,46 +492,42 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "string",
 						"description": "Role to grant (e.g., roles/viewer)",
 					},
-					"condition": map[string]any{
+					"condition_expression": map[string]any{
+						"type":        "string",
+						"description": "CEL expression of an IAM condition (e.g., request.time < timestamp('2025-01-01T00:00:00Z')). Requires condition_title",
+					},
+					"condition_title": map[string]any{
+						"type":        "string",
+						"description": "Title of the IAM condition",
+					},
+					"condition_description": map[string]any{
 						"type":        "string",
-						"description": "IAM condition expression",
+						"description": "Description of the IAM condition",
+					},
+					"no_condition": map[string]any{
+						"type":        "boolean",
+						"description": "Add the binding without a condition (--condition=None), needed when the policy already has conditional bindings",
 					},
 				},
 			},
 		},
 		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			args := parseArgs(req)
-			project, err := services.GetRequiredString(args, "project")
+			cmd, err := projectIAMBindingCommand(base, "add-iam-policy-binding", args)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			member, err := services.GetRequiredString(args, "member")
-			if err != nil {
-				return services.ToolError(err), nil
-			}
-			role, err := services.GetRequiredString(args, "role")
-			if err != nil {
-				return services.ToolError(err), nil
-			}
-
-			cmd := base.Executor.Command("projects", "add-iam-policy-binding", project).
-				WithFlag("member", member).
-				WithFlag("role", role)
-
-			if condition := services.GetOptionalString(args, "condition", ""); condition != "" {
-				cmd.WithFlag("condition", condition)
-			}
 
 			result, err := cmd.Execute(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Remove project IAM policy binding
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_projects_remove_iam_policy_binding",
 			Description: "Remove IAM policy binding from a project",
@@ -441,33 +547,126 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "string",
 						"description": "Role to revoke",
 					},
+					"condition_expression": map[string]any{
+						"type":        "string",
+						"description": "CEL expression of an IAM condition (e.g., request.time < timestamp('2025-01-01T00:00:00Z')). Requires condition_title",
+					},
+					"condition_title": map[string]any{
+						"type":        "string",
+						"description": "Title of the IAM condition",
+					},
+					"condition_description": map[string]any{
+						"type":        "string",
+						"description": "Description of the IAM condition",
+					},
+					"no_condition": map[string]any{
+						"type":        "boolean",
+						"description": "Remove the binding that has no condition (--condition=None)",
+					},
 				},
 			},
 		},
 		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			args := parseArgs(req)
-			project, err := services.GetRequiredString(args, "project")
+			cmd, err := projectIAMBindingCommand(base, "remove-iam-policy-binding", args)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			member, err := services.GetRequiredString(args, "member")
+
+			result, err := cmd.Execute(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			role, err := services.GetRequiredString(args, "role")
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// List deny policies
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_iam_policies_list",
+			Description: "List IAM deny policies attached to a project, folder or organization",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"attachment_point": attachmentPointProperty(),
+					"organization": map[string]any{
+						"type":        "string",
+						"description": "Organization ID whose deny policies to list",
+					},
+					"folder": map[string]any{
+						"type":        "string",
+						"description": "Folder ID whose deny policies to list",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "Project ID whose deny policies to list (defaults to the configured project)",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := denyPolicyListCommand(base, args)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
 
-			result, err := base.Executor.Command("projects", "remove-iam-policy-binding", project).
-				WithFlag("member", member).
-				WithFlag("role", role).
-				Execute(ctx)
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
 
+	// Create deny policy
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_iam_policies_create",
+			Description: "Create an IAM deny policy that denies permissions to principals regardless of their role grants",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"policy_id", "policy"},
+				"properties": map[string]any{
+					"policy_id": map[string]any{
+						"type":        "string",
+						"description": "ID of the deny policy (e.g., deny-project-deletion)",
+					},
+					"policy": map[string]any{
+						"type":        "object",
+						"description": "Deny policy with displayName and rules, e.g. {\"displayName\": \"...\", \"rules\": [{\"denyRule\": {\"deniedPrincipals\": [\"principalSet://goog/public:all\"], \"deniedPermissions\": [\"cloudresourcemanager.googleapis.com/projects.delete\"]}}]}",
+					},
+					"attachment_point": attachmentPointProperty(),
+					"organization": map[string]any{
+						"type":        "string",
+						"description": "Organization ID to attach the policy to",
+					},
+					"folder": map[string]any{
+						"type":        "string",
+						"description": "Folder ID to attach the policy to",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "Project ID to attach the policy to (defaults to the configured project)",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := denyPolicyCreateCommand(base, args)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
 		},
 	)
 }
@@ -482,3 +681,200 @@ func parseArgs(req *mcp.CallToolRequest) map[string]any {
 	}
 	return args
 }
+
+// csvColumns are the default column projections used when a list tool is
+// called with output=csv, keyed by resource.
+var csvColumns = map[string]string{
+	"service-accounts": "email,displayName,disabled",
+	"keys":             "name.basename(),keyType,validAfterTime,validBeforeTime",
+	"roles":            "name,title,stage",
+	"policies":         "name.basename(),displayName,createTime,updateTime",
+}
+
+// serviceAccountIAMBindingCommand builds an add- or remove-iam-policy-binding
+// command for the IAM policy of a service account.
+func serviceAccountIAMBindingCommand(base *services.BaseService, action string, args map[string]any) (*executor.CommandBuilder, error) {
+	email, err := services.GetRequiredString(args, "email")
+	if err != nil {
+		return nil, err
+	}
+	member, err := services.GetRequiredString(args, "member")
+	if err != nil {
+		return nil, err
+	}
+	role, err := services.GetRequiredString(args, "role")
+	if err != nil {
+		return nil, err
+	}
+
+	return base.Executor.Command("iam", "service-accounts", action, email).
+		WithFlag("member", member).
+		WithFlag("role", role).
+		WithProject(services.GetOptionalString(args, "project", "")), nil
+}
+
+// projectIAMBindingCommand builds an add- or remove-iam-policy-binding
+// command for the IAM policy of a project.
+func projectIAMBindingCommand(base *services.BaseService, action string, args map[string]any) (*executor.CommandBuilder, error) {
+	project, err := services.GetRequiredString(args, "project")
+	if err != nil {
+		return nil, err
+	}
+	member, err := services.GetRequiredString(args, "member")
+	if err != nil {
+		return nil, err
+	}
+	role, err := services.GetRequiredString(args, "role")
+	if err != nil {
+		return nil, err
+	}
+	condition, err := conditionFlag(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return base.Executor.Command("projects", action, project).
+		WithFlag("member", member).
+		WithFlag("role", role).
+		WithFlag("condition", condition), nil
+}
+
+// conditionDelimiters are the alternative delimiters tried, in order, when a
+// condition value contains a comma.
+var conditionDelimiters = []string{";", "|", "#", "~"}
+
+// conditionFlag returns the --condition value for the condition_* and
+// no_condition arguments, or an empty string when no condition is given.
+// gcloud splits the value on commas, so when a value contains one the
+// value is written with gcloud's ^DELIM^ alternative delimiter syntax.
+func conditionFlag(args map[string]any) (string, error) {
+	expression := services.GetOptionalString(args, "condition_expression", "")
+	title := services.GetOptionalString(args, "condition_title", "")
+	description := services.GetOptionalString(args, "condition_description", "")
+
+	if services.GetOptionalBool(args, "no_condition", false) {
+		if expression != "" || title != "" || description != "" {
+			return "", fmt.Errorf("no_condition cannot be combined with condition_expression, condition_title or condition_description")
+		}
+		return "None", nil
+	}
+	if expression == "" {
+		if title != "" || description != "" {
+			return "", fmt.Errorf("condition_title and condition_description require condition_expression")
+		}
+		return "", nil
+	}
+	if title == "" {
+		return "", fmt.Errorf("condition_title is required with condition_expression")
+	}
+
+	pairs := []string{"expression=" + expression, "title=" + title}
+	if description != "" {
+		pairs = append(pairs, "description="+description)
+	}
+	value := strings.Join(pairs, ",")
+	if strings.Count(value, ",") == len(pairs)-1 {
+		return value, nil
+	}
+
+	for _, delim := range conditionDelimiters {
+		if !strings.Contains(value, delim) {
+			return "^" + delim + "^" + strings.Join(pairs, delim), nil
+		}
+	}
+	return "", fmt.Errorf("condition values contain commas and every supported delimiter (%s)", strings.Join(conditionDelimiters, " "))
+}
+
+// attachmentPointProperty returns the schema of the attachment_point argument
+// of the deny policy tools.
+func attachmentPointProperty() map[string]any {
+	return map[string]any{
+		"type":        "string",
+		"description": "Full resource name the policy is attached to (e.g., cloudresourcemanager.googleapis.com/projects/my-project). Overrides organization, folder and project",
+	}
+}
+
+// attachmentPoint returns the --attachment-point value for the deny policy
+// tools: attachment_point when given, otherwise the resource named by
+// organization, folder or project, falling back to the configured project and
+// then the project of gcloud's configuration.
+func attachmentPoint(base *services.BaseService, args map[string]any) (string, error) {
+	if point := services.GetOptionalString(args, "attachment_point", ""); point != "" {
+		return point, nil
+	}
+
+	var points []string
+	if organization := services.GetOptionalString(args, "organization", ""); organization != "" {
+		points = append(points, "organizations/"+strings.TrimPrefix(organization, "organizations/"))
+	}
+	if folder := services.GetOptionalString(args, "folder", ""); folder != "" {
+		points = append(points, "folders/"+strings.TrimPrefix(folder, "folders/"))
+	}
+	if project := services.GetOptionalString(args, "project", ""); project != "" {
+		points = append(points, "projects/"+project)
+	}
+
+	switch len(points) {
+	case 0:
+		project := base.Config.Project
+		if project == "" {
+			project = base.Executor.Defaults().Project
+		}
+		if project == "" {
+			return "", fmt.Errorf("attachment point is required (pass attachment_point, organization, folder or project, or set GCLOUD_PROJECT)")
+		}
+		return "cloudresourcemanager.googleapis.com/projects/" + project, nil
+	case 1:
+		return "cloudresourcemanager.googleapis.com/" + points[0], nil
+	default:
+		return "", fmt.Errorf("only one of organization, folder and project can be given")
+	}
+}
+
+// denyPolicyCommand builds an `iam policies` command on the deny policies of
+// an attachment point.
+func denyPolicyCommand(base *services.BaseService, action string, args map[string]any) (*executor.CommandBuilder, error) {
+	point, err := attachmentPoint(base, args)
+	if err != nil {
+		return nil, err
+	}
+	return base.Executor.Command("iam", "policies", action).
+		WithFlag("attachment-point", point).
+		WithFlag("kind", "denypolicies"), nil
+}
+
+// denyPolicyListCommand builds the `iam policies list` command for the deny
+// policies of an attachment point.
+func denyPolicyListCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	cmd, err := denyPolicyCommand(base, "list", args)
+	if err != nil {
+		return nil, err
+	}
+	if err := services.ApplyListOutput(cmd, args, csvColumns["policies"]); err != nil {
+		return nil, err
+	}
+	return cmd, nil
+}
+
+// denyPolicyCreateCommand builds the `iam policies create` command. The
+// policy is passed as a JSON policy file.
+func denyPolicyCreateCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	policyID, err := services.GetRequiredString(args, "policy_id")
+	if err != nil {
+		return nil, err
+	}
+	policy, ok := args["policy"].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("policy must be an object")
+	}
+	if rules, ok := policy["rules"].([]any); !ok || len(rules) == 0 {
+		return nil, fmt.Errorf("policy must contain at least one rule")
+	}
+
+	cmd, err := denyPolicyCommand(base, "create", args)
+	if err != nil {
+		return nil, err
+	}
+	return cmd.WithPositional(policyID).
+		WithJSONInput("policy-file", policy), nil
+}
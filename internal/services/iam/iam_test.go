@@ -0,0 +1,354 @@
+package iam
+
+import (
+	"encoding/json"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"gcloud-go-mcp/internal/config"
+	"gcloud-go-mcp/internal/executor"
+	"gcloud-go-mcp/internal/executor/executortest"
+	"gcloud-go-mcp/internal/services"
+	"gcloud-go-mcp/internal/services/servicetest"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func newTestBase() *services.BaseService {
+	return services.NewBaseService(servicetest.NewConfig())
+}
+
+func TestRegisterTools(t *testing.T) {
+	server := mcp.NewServer(
+		&mcp.Implementation{
+			Name:    "test-server",
+			Version: "0.0.1",
+		},
+		&mcp.ServerOptions{},
+	)
+
+	// Should not panic
+	RegisterTools(server, newTestBase())
+}
+
+func TestToolCall_ServiceAccountsCreate(t *testing.T) {
+	runner := &executortest.Runner{}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_iam_service_accounts_create", map[string]any{
+		"name":         "deployer",
+		"display_name": "Deployer",
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+
+	args := runner.LastArgs()
+	if len(args) < 4 || !slices.Equal(args[:4], []string{"iam", "service-accounts", "create", "deployer"}) {
+		t.Errorf("unexpected command %v", args)
+	}
+	if missing := executortest.Missing(args, "--display-name=Deployer"); len(missing) > 0 {
+		t.Errorf("missing %v in %v", missing, args)
+	}
+}
+
+func TestServiceAccountIAMBindingCommand(t *testing.T) {
+	args := map[string]any{
+		"email":  "deployer@test-project.iam.gserviceaccount.com",
+		"member": "user:alice@example.com",
+		"role":   "roles/iam.serviceAccountUser",
+	}
+
+	for _, action := range []string{"add-iam-policy-binding", "remove-iam-policy-binding"} {
+		t.Run(action, func(t *testing.T) {
+			cmd, err := serviceAccountIAMBindingCommand(newTestBase(), action, args)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			built := cmd.Build()
+			if !slices.Equal(built[:4], []string{"iam", "service-accounts", action, "deployer@test-project.iam.gserviceaccount.com"}) {
+				t.Errorf("expected command on the service account, got %v", built)
+			}
+			if missing := executortest.Missing(built, "--member=user:alice@example.com", "--role=roles/iam.serviceAccountUser"); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, built)
+			}
+		})
+	}
+}
+
+func TestServiceAccountIAMBindingCommand_MissingParams(t *testing.T) {
+	for _, missing := range []string{"email", "member", "role"} {
+		args := map[string]any{
+			"email":  "deployer@test-project.iam.gserviceaccount.com",
+			"member": "user:alice@example.com",
+			"role":   "roles/iam.serviceAccountUser",
+		}
+		delete(args, missing)
+
+		_, err := serviceAccountIAMBindingCommand(newTestBase(), "add-iam-policy-binding", args)
+		if err == nil || !strings.Contains(err.Error(), missing) {
+			t.Errorf("expected error mentioning %q, got %v", missing, err)
+		}
+	}
+}
+
+func TestConditionFlag(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "no condition",
+			args: map[string]any{},
+			want: "",
+		},
+		{
+			name: "expression and title",
+			args: map[string]any{
+				"condition_expression": "resource.name.startsWith('projects/_/buckets/logs')",
+				"condition_title":      "logs-only",
+			},
+			want: "expression=resource.name.startsWith('projects/_/buckets/logs'),title=logs-only",
+		},
+		{
+			name: "with description",
+			args: map[string]any{
+				"condition_expression":  "request.time < timestamp('2025-01-01T00:00:00Z')",
+				"condition_title":       "expires-2025",
+				"condition_description": "Temporary access",
+			},
+			want: "expression=request.time < timestamp('2025-01-01T00:00:00Z'),title=expires-2025,description=Temporary access",
+		},
+		{
+			name: "comma in value uses alternative delimiter",
+			args: map[string]any{
+				"condition_expression":  "resource.type in ['a', 'b']",
+				"condition_title":       "types",
+				"condition_description": "Types a, b",
+			},
+			want: "^;^expression=resource.type in ['a', 'b'];title=types;description=Types a, b",
+		},
+		{
+			name: "comma and semicolon in value",
+			args: map[string]any{
+				"condition_expression": "resource.type in ['a', 'b']",
+				"condition_title":      "a;b",
+			},
+			want: "^|^expression=resource.type in ['a', 'b']|title=a;b",
+		},
+		{
+			name: "no_condition",
+			args: map[string]any{"no_condition": true},
+			want: "None",
+		},
+		{
+			name: "expression without title",
+			args: map[string]any{
+				"condition_expression": "request.time < timestamp('2025-01-01T00:00:00Z')",
+			},
+			wantErr: true,
+		},
+		{
+			name:    "title without expression",
+			args:    map[string]any{"condition_title": "expires-2025"},
+			wantErr: true,
+		},
+		{
+			name: "no_condition with expression",
+			args: map[string]any{
+				"no_condition":         true,
+				"condition_expression": "request.time < timestamp('2025-01-01T00:00:00Z')",
+				"condition_title":      "expires-2025",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := conditionFlag(tt.args)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("conditionFlag() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProjectIAMBindingCommand_Condition(t *testing.T) {
+	cmd, err := projectIAMBindingCommand(newTestBase(), "remove-iam-policy-binding", map[string]any{
+		"project":              "test-project",
+		"member":               "user:alice@example.com",
+		"role":                 "roles/viewer",
+		"condition_expression": "request.time < timestamp('2025-01-01T00:00:00Z')",
+		"condition_title":      "expires-2025",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	built := cmd.Build()
+	if !slices.Equal(built[:3], []string{"projects", "remove-iam-policy-binding", "test-project"}) {
+		t.Errorf("unexpected command %v", built)
+	}
+	want := "--condition=expression=request.time < timestamp('2025-01-01T00:00:00Z'),title=expires-2025"
+	if missing := executortest.Missing(built, "--member=user:alice@example.com", "--role=roles/viewer", want); len(missing) > 0 {
+		t.Errorf("missing %v in %v", missing, built)
+	}
+}
+
+func TestDenyPolicyCommand_AttachmentPoint(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    string
+		wantErr string
+	}{
+		{
+			name: "configured project",
+			args: map[string]any{},
+			want: "--attachment-point=cloudresourcemanager.googleapis.com/projects/test-project",
+		},
+		{
+			name: "project",
+			args: map[string]any{"project": "prod-project"},
+			want: "--attachment-point=cloudresourcemanager.googleapis.com/projects/prod-project",
+		},
+		{
+			name: "folder",
+			args: map[string]any{"folder": "folders/1234567890"},
+			want: "--attachment-point=cloudresourcemanager.googleapis.com/folders/1234567890",
+		},
+		{
+			name: "organization",
+			args: map[string]any{"organization": "987654321"},
+			want: "--attachment-point=cloudresourcemanager.googleapis.com/organizations/987654321",
+		},
+		{
+			name: "explicit attachment point",
+			args: map[string]any{"attachment_point": "cloudresourcemanager.googleapis.com/projects/other", "project": "prod-project"},
+			want: "--attachment-point=cloudresourcemanager.googleapis.com/projects/other",
+		},
+		{
+			name:    "folder and project",
+			args:    map[string]any{"folder": "1234567890", "project": "prod-project"},
+			wantErr: "only one of",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := denyPolicyCommand(newTestBase(), "list", tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if !slices.Equal(args[:3], []string{"iam", "policies", "list"}) {
+				t.Errorf("unexpected command %v", args)
+			}
+			if missing := executortest.Missing(args, tt.want, "--kind=denypolicies"); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, args)
+			}
+		})
+	}
+}
+
+func TestDenyPolicyCommand_ResolvedProject(t *testing.T) {
+	base := services.NewBaseService(&config.Config{GCloudPath: "gcloud", CommandTimeout: 5 * time.Minute})
+	if _, err := denyPolicyCommand(base, "list", map[string]any{}); err == nil || !strings.Contains(err.Error(), "attachment point is required") {
+		t.Fatalf("expected attachment point error, got %v", err)
+	}
+
+	base.Executor.SetDefaults(executor.Defaults{Project: "resolved-project"})
+	cmd, err := denyPolicyCommand(base, "list", map[string]any{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "--attachment-point=cloudresourcemanager.googleapis.com/projects/resolved-project"; !slices.Contains(cmd.Build(), want) {
+		t.Errorf("expected %s in %v", want, cmd.Build())
+	}
+}
+
+func TestDenyPolicyListCommand_Output(t *testing.T) {
+	cmd, err := denyPolicyListCommand(newTestBase(), map[string]any{"output": "csv"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	args := cmd.Build()
+	if want := "--format=csv(" + csvColumns["policies"] + ")"; !slices.Contains(args, want) {
+		t.Errorf("expected %s in %v", want, args)
+	}
+}
+
+func TestToolCall_DenyPoliciesCreate(t *testing.T) {
+	var policy map[string]any
+	runner := &executortest.Runner{Handler: func(args []string) (*executor.Result, error) {
+		for _, arg := range args {
+			if path, ok := strings.CutPrefix(arg, "--policy-file="); ok {
+				data, err := os.ReadFile(path)
+				if err != nil {
+					return nil, err
+				}
+				if err := json.Unmarshal(data, &policy); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return &executor.Result{Stdout: "{}"}, nil
+	}}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_iam_policies_create", map[string]any{
+		"policy_id": "deny-project-deletion",
+		"policy": map[string]any{
+			"displayName": "Deny project deletion",
+			"rules": []any{map[string]any{"denyRule": map[string]any{
+				"deniedPrincipals":  []any{"principalSet://goog/public:all"},
+				"deniedPermissions": []any{"cloudresourcemanager.googleapis.com/projects.delete"},
+			}}},
+		},
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+
+	args := runner.LastArgs()
+	if !slices.Equal(args[:4], []string{"iam", "policies", "create", "deny-project-deletion"}) {
+		t.Errorf("unexpected command %v", args)
+	}
+	if missing := executortest.Missing(args, "--attachment-point=cloudresourcemanager.googleapis.com/projects/test-project", "--kind=denypolicies"); len(missing) > 0 {
+		t.Errorf("missing %v in %v", missing, args)
+	}
+	if policy["displayName"] != "Deny project deletion" {
+		t.Errorf("expected the policy in the policy file, got %v", policy)
+	}
+}
+
+func TestToolCall_DenyPoliciesCreateWithoutRules(t *testing.T) {
+	runner := &executortest.Runner{}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_iam_policies_create", map[string]any{
+		"policy_id": "empty",
+		"policy":    map[string]any{"displayName": "Empty"},
+	})
+	if !result.IsError || !strings.Contains(servicetest.Text(result), "at least one rule") {
+		t.Errorf("expected a missing rules error, got %s", servicetest.Text(result))
+	}
+	if len(runner.Calls()) > 0 {
+		t.Errorf("expected gcloud not to run, got %v", runner.Calls())
+	}
+}
@@ -0,0 +1,531 @@
+// Package kms provides MCP tools for Cloud Key Management Service.
+package kms
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+
+	"gcloud-go-mcp/internal/executor"
+	"gcloud-go-mcp/internal/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// keyPurpose describes how a purpose argument maps to gcloud flags.
+type keyPurpose struct {
+	// Purpose is the --purpose value.
+	Purpose string
+
+	// DefaultAlgorithm is the --default-algorithm used when none is given.
+	// Symmetric encryption keys don't need one.
+	DefaultAlgorithm string
+}
+
+// keyPurposes maps the purpose argument of key create to gcloud's purposes.
+var keyPurposes = map[string]keyPurpose{
+	"encryption":            {Purpose: "encryption"},
+	"signing":               {Purpose: "asymmetric-signing", DefaultAlgorithm: "ec-sign-p256-sha256"},
+	"asymmetric-encryption": {Purpose: "asymmetric-encryption", DefaultAlgorithm: "rsa-decrypt-oaep-3072-sha256"},
+	"mac":                   {Purpose: "mac", DefaultAlgorithm: "hmac-sha256"},
+}
+
+// RegisterTools registers all Cloud KMS tools with the MCP server.
+func RegisterTools(server *mcp.Server, base *services.BaseService) {
+	// List key rings
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_kms_keyrings_list",
+			Description: "List key rings in a location",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"location": map[string]any{
+						"type":        "string",
+						"description": "KMS location (e.g., global, us-central1)",
+						"default":     "global",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+
+			cmd := base.Executor.Command("kms", "keyrings", "list").
+				WithFlag("location", services.GetOptionalString(args, "location", "global")).
+				WithProject(services.GetOptionalString(args, "project", ""))
+
+			if err := services.ApplyListOutput(cmd, args, csvColumns["keyrings"]); err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Create key ring
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_kms_keyrings_create",
+			Description: "Create a key ring. Key rings can't be deleted",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"keyring"},
+				"properties": map[string]any{
+					"keyring": map[string]any{
+						"type":        "string",
+						"description": "Key ring name",
+					},
+					"location": map[string]any{
+						"type":        "string",
+						"description": "KMS location (e.g., global, us-central1)",
+						"default":     "global",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"if_not_exists": services.IfNotExistsProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			keyring, err := services.GetRequiredString(args, "keyring")
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			existing, err := base.ExistingResource(ctx, args, base.Executor.Command("kms", "keyrings", "describe", keyring).
+				WithFlag("location", services.GetOptionalString(args, "location", "global")).
+				WithProject(services.GetOptionalString(args, "project", "")))
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			if existing != nil {
+				return existing, nil
+			}
+
+			result, err := base.Executor.Command("kms", "keyrings", "create", keyring).
+				WithFlag("location", services.GetOptionalString(args, "location", "global")).
+				WithProject(services.GetOptionalString(args, "project", "")).
+				Execute(ctx)
+
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			if result.Stdout == "" {
+				return services.ToolResult("Key ring " + keyring + " created successfully"), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// List keys
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_kms_keys_list",
+			Description: "List keys in a key ring",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"keyring"},
+				"properties": map[string]any{
+					"keyring": map[string]any{
+						"type":        "string",
+						"description": "Key ring name",
+					},
+					"location": map[string]any{
+						"type":        "string",
+						"description": "KMS location",
+						"default":     "global",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			keyring, err := services.GetRequiredString(args, "keyring")
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			cmd := base.Executor.Command("kms", "keys", "list").
+				WithFlag("keyring", keyring).
+				WithFlag("location", services.GetOptionalString(args, "location", "global")).
+				WithProject(services.GetOptionalString(args, "project", ""))
+
+			if err := services.ApplyListOutput(cmd, args, csvColumns["keys"]); err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Create key
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_kms_keys_create",
+			Description: "Create a key in a key ring",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"key", "keyring"},
+				"properties": map[string]any{
+					"key": map[string]any{
+						"type":        "string",
+						"description": "Key name",
+					},
+					"keyring": map[string]any{
+						"type":        "string",
+						"description": "Key ring name",
+					},
+					"location": map[string]any{
+						"type":        "string",
+						"description": "KMS location",
+						"default":     "global",
+					},
+					"purpose": map[string]any{
+						"type":        "string",
+						"description": "What the key is used for",
+						"default":     "encryption",
+						"enum":        []string{"encryption", "signing", "asymmetric-encryption", "mac"},
+					},
+					"algorithm": map[string]any{
+						"type":        "string",
+						"description": "Default algorithm (e.g., ec-sign-p384-sha384). Defaults to a common algorithm for the purpose",
+					},
+					"protection_level": map[string]any{
+						"type":        "string",
+						"description": "Where key material is stored",
+						"enum":        []string{"software", "hsm"},
+					},
+					"rotation_period": map[string]any{
+						"type":        "string",
+						"description": "Automatic rotation period for encryption keys (e.g., 90d)",
+					},
+					"next_rotation_time": map[string]any{
+						"type":        "string",
+						"description": "Time of the first automatic rotation (e.g., 2025-01-01T00:00:00Z)",
+					},
+					"labels": map[string]any{
+						"type":        "object",
+						"description": "Labels (key-value pairs)",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"if_not_exists": services.IfNotExistsProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := keyCreateCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			existing, err := base.ExistingResource(ctx, args, base.Executor.Command("kms", "keys", "describe", services.GetOptionalString(args, "key", "")).
+				WithFlag("keyring", services.GetOptionalString(args, "keyring", "")).
+				WithFlag("location", services.GetOptionalString(args, "location", "global")).
+				WithProject(services.GetOptionalString(args, "project", "")))
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			if existing != nil {
+				return existing, nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Encrypt
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_kms_encrypt",
+			Description: "Encrypt data with a symmetric encryption key. Returns base64-encoded ciphertext unless ciphertext_file is set",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"key", "keyring"},
+				"properties": map[string]any{
+					"key": map[string]any{
+						"type":        "string",
+						"description": "Key name",
+					},
+					"keyring": map[string]any{
+						"type":        "string",
+						"description": "Key ring name",
+					},
+					"location": map[string]any{
+						"type":        "string",
+						"description": "KMS location",
+						"default":     "global",
+					},
+					"plaintext": map[string]any{
+						"type":        "string",
+						"description": "Text to encrypt (passed on stdin). Mutually exclusive with plaintext_file",
+					},
+					"plaintext_file": map[string]any{
+						"type":        "string",
+						"description": "Local file to encrypt",
+					},
+					"ciphertext_file": map[string]any{
+						"type":        "string",
+						"description": "Local file to write the ciphertext to",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := encryptCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			if path := services.GetOptionalString(args, "ciphertext_file", ""); path != "" {
+				return services.ToolResult("Ciphertext written to " + path), nil
+			}
+			// Ciphertext is binary, so it is never parsed as structured output.
+			return services.ToolResult(base64.StdEncoding.EncodeToString([]byte(result.Stdout))), nil
+		},
+	)
+
+	// Decrypt
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_kms_decrypt",
+			Description: "Decrypt ciphertext produced by a symmetric encryption key",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"key", "keyring"},
+				"properties": map[string]any{
+					"key": map[string]any{
+						"type":        "string",
+						"description": "Key name",
+					},
+					"keyring": map[string]any{
+						"type":        "string",
+						"description": "Key ring name",
+					},
+					"location": map[string]any{
+						"type":        "string",
+						"description": "KMS location",
+						"default":     "global",
+					},
+					"ciphertext": map[string]any{
+						"type":        "string",
+						"description": "Base64-encoded ciphertext (passed on stdin). Mutually exclusive with ciphertext_file",
+					},
+					"ciphertext_file": map[string]any{
+						"type":        "string",
+						"description": "Local file containing the ciphertext",
+					},
+					"plaintext_file": map[string]any{
+						"type":        "string",
+						"description": "Local file to write the plaintext to",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := decryptCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			if path := services.GetOptionalString(args, "plaintext_file", ""); path != "" {
+				return services.ToolResult("Plaintext written to " + path), nil
+			}
+			if !utf8.ValidString(result.Stdout) {
+				return services.ToolResult("Plaintext is binary; base64-encoded:\n" + base64.StdEncoding.EncodeToString([]byte(result.Stdout))), nil
+			}
+			// The plaintext is returned byte for byte rather than through
+			// AsStructured, which would trim it when it happens to be JSON.
+			return services.ToolResult(result.Stdout), nil
+		},
+	)
+}
+
+func parseArgs(req *mcp.CallToolRequest) map[string]any {
+	var args map[string]any
+	if req.Params.Arguments != nil {
+		_ = json.Unmarshal(req.Params.Arguments, &args)
+	}
+	if args == nil {
+		args = make(map[string]any)
+	}
+	return args
+}
+
+// csvColumns are the default column projections used when a list tool is
+// called with output=csv, keyed by resource.
+var csvColumns = map[string]string{
+	"keyrings": "name.basename(),createTime",
+	"keys":     "name.basename(),purpose,primary.state,versionTemplate.algorithm,rotationPeriod",
+}
+
+// keyCommand starts a command that operates on the key named in args.
+func keyCommand(base *services.BaseService, action string, args map[string]any) (*executor.CommandBuilder, error) {
+	key, err := services.GetRequiredString(args, "key")
+	if err != nil {
+		return nil, err
+	}
+	keyring, err := services.GetRequiredString(args, "keyring")
+	if err != nil {
+		return nil, err
+	}
+
+	return base.Executor.Command("kms", action).
+		WithFlag("key", key).
+		WithFlag("keyring", keyring).
+		WithFlag("location", services.GetOptionalString(args, "location", "global")).
+		WithProject(services.GetOptionalString(args, "project", "")), nil
+}
+
+// keyCreateCommand builds the `kms keys create` command, mapping purpose to
+// gcloud's purpose and a default algorithm for asymmetric and MAC keys.
+func keyCreateCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	key, err := services.GetRequiredString(args, "key")
+	if err != nil {
+		return nil, err
+	}
+	keyring, err := services.GetRequiredString(args, "keyring")
+	if err != nil {
+		return nil, err
+	}
+	name := services.GetOptionalString(args, "purpose", "encryption")
+	purpose, ok := keyPurposes[name]
+	if !ok {
+		return nil, fmt.Errorf("invalid purpose %q: must be encryption, signing, asymmetric-encryption or mac", name)
+	}
+
+	cmd := base.Executor.Command("kms", "keys", "create", key).
+		WithFlag("keyring", keyring).
+		WithFlag("location", services.GetOptionalString(args, "location", "global")).
+		WithFlag("purpose", purpose.Purpose).
+		WithProject(services.GetOptionalString(args, "project", ""))
+
+	if algorithm := services.GetOptionalString(args, "algorithm", purpose.DefaultAlgorithm); algorithm != "" {
+		cmd.WithFlag("default-algorithm", algorithm)
+	}
+	if level := services.GetOptionalString(args, "protection_level", ""); level != "" {
+		cmd.WithFlag("protection-level", level)
+	}
+
+	rotationPeriod := services.GetOptionalString(args, "rotation_period", "")
+	nextRotation := services.GetOptionalString(args, "next_rotation_time", "")
+	if (rotationPeriod != "" || nextRotation != "") && purpose.Purpose != "encryption" {
+		return nil, fmt.Errorf("automatic rotation is only supported for encryption keys")
+	}
+	if rotationPeriod != "" {
+		cmd.WithFlag("rotation-period", rotationPeriod)
+	}
+	if nextRotation != "" {
+		cmd.WithFlag("next-rotation-time", nextRotation)
+	}
+	if labels := base.Labels(args); labels != "" {
+		cmd.WithFlag("labels", labels)
+	}
+	return cmd, nil
+}
+
+// encryptCommand builds a `kms encrypt` command. Plaintext given inline is
+// passed on stdin and the ciphertext is written to stdout unless
+// ciphertext_file is set.
+func encryptCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	cmd, err := keyCommand(base, "encrypt", args)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, hasPlaintext := args["plaintext"].(string)
+	plaintextFile := services.GetOptionalString(args, "plaintext_file", "")
+	switch {
+	case hasPlaintext && plaintextFile != "":
+		return nil, fmt.Errorf("plaintext and plaintext_file are mutually exclusive")
+	case hasPlaintext:
+		cmd.WithFlag("plaintext-file", "-").WithStdin([]byte(plaintext))
+	case plaintextFile != "":
+		cmd.WithFlag("plaintext-file", plaintextFile)
+	default:
+		return nil, fmt.Errorf("one of plaintext or plaintext_file is required")
+	}
+
+	cmd.WithFlag("ciphertext-file", services.GetOptionalString(args, "ciphertext_file", "-"))
+	return cmd.WithTextFormat(), nil
+}
+
+// decryptCommand builds a `kms decrypt` command. Base64 ciphertext given
+// inline is decoded and passed on stdin, and the plaintext is written to
+// stdout unless plaintext_file is set.
+func decryptCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	cmd, err := keyCommand(base, "decrypt", args)
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext := services.GetOptionalString(args, "ciphertext", "")
+	ciphertextFile := services.GetOptionalString(args, "ciphertext_file", "")
+	switch {
+	case ciphertext != "" && ciphertextFile != "":
+		return nil, fmt.Errorf("ciphertext and ciphertext_file are mutually exclusive")
+	case ciphertext != "":
+		data, err := base64.StdEncoding.DecodeString(ciphertext)
+		if err != nil {
+			return nil, fmt.Errorf("ciphertext must be base64-encoded: %w", err)
+		}
+		cmd.WithFlag("ciphertext-file", "-").WithStdin(data)
+	case ciphertextFile != "":
+		cmd.WithFlag("ciphertext-file", ciphertextFile)
+	default:
+		return nil, fmt.Errorf("one of ciphertext or ciphertext_file is required")
+	}
+
+	cmd.WithFlag("plaintext-file", services.GetOptionalString(args, "plaintext_file", "-"))
+	return cmd.WithTextFormat(), nil
+}
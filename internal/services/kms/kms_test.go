@@ -0,0 +1,192 @@
+package kms
+
+import (
+	"encoding/base64"
+	"slices"
+	"strings"
+	"testing"
+
+	"gcloud-go-mcp/internal/executor/executortest"
+	"gcloud-go-mcp/internal/services"
+	"gcloud-go-mcp/internal/services/servicetest"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func newTestBase() *services.BaseService {
+	return services.NewBaseService(servicetest.NewConfig())
+}
+
+func TestRegisterTools(t *testing.T) {
+	server := mcp.NewServer(
+		&mcp.Implementation{
+			Name:    "test-server",
+			Version: "0.0.1",
+		},
+		&mcp.ServerOptions{},
+	)
+
+	// Should not panic
+	RegisterTools(server, newTestBase())
+}
+
+func TestKeyCreateCommand_Purpose(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        map[string]any
+		want        []string
+		noAlgorithm bool
+		wantErr     string
+	}{
+		{
+			name:        "default symmetric encryption",
+			args:        map[string]any{},
+			want:        []string{"--purpose=encryption", "--location=global"},
+			noAlgorithm: true,
+		},
+		{
+			name: "signing",
+			args: map[string]any{"purpose": "signing"},
+			want: []string{"--purpose=asymmetric-signing", "--default-algorithm=ec-sign-p256-sha256"},
+		},
+		{
+			name: "signing with algorithm",
+			args: map[string]any{"purpose": "signing", "algorithm": "rsa-sign-pss-2048-sha256"},
+			want: []string{"--purpose=asymmetric-signing", "--default-algorithm=rsa-sign-pss-2048-sha256"},
+		},
+		{
+			name: "asymmetric encryption",
+			args: map[string]any{"purpose": "asymmetric-encryption"},
+			want: []string{"--purpose=asymmetric-encryption", "--default-algorithm=rsa-decrypt-oaep-3072-sha256"},
+		},
+		{
+			name: "mac",
+			args: map[string]any{"purpose": "mac", "protection_level": "hsm"},
+			want: []string{"--purpose=mac", "--default-algorithm=hmac-sha256", "--protection-level=hsm"},
+		},
+		{
+			name: "encryption with rotation",
+			args: map[string]any{"rotation_period": "90d", "next_rotation_time": "2025-01-01T00:00:00Z", "location": "us-central1"},
+			want: []string{"--rotation-period=90d", "--next-rotation-time=2025-01-01T00:00:00Z", "--location=us-central1"},
+		},
+		{
+			name:    "rotation on signing key",
+			args:    map[string]any{"purpose": "signing", "rotation_period": "90d"},
+			wantErr: "only supported for encryption keys",
+		},
+		{
+			name:    "unknown purpose",
+			args:    map[string]any{"purpose": "hashing"},
+			wantErr: "invalid purpose",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.args["key"] = "app-key"
+			tt.args["keyring"] = "app-ring"
+
+			cmd, err := keyCreateCommand(newTestBase(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if !slices.Equal(args[:4], []string{"kms", "keys", "create", "app-key"}) {
+				t.Errorf("unexpected command %v", args)
+			}
+			if missing := executortest.Missing(args, append(tt.want, "--keyring=app-ring")...); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, args)
+			}
+			if tt.noAlgorithm && slices.ContainsFunc(args, func(a string) bool { return strings.HasPrefix(a, "--default-algorithm") }) {
+				t.Errorf("did not expect a default algorithm, got %v", args)
+			}
+		})
+	}
+}
+
+func TestEncryptCommand_InputValidation(t *testing.T) {
+	base := map[string]any{"key": "k", "keyring": "r"}
+	for name, extra := range map[string]map[string]any{
+		"neither": {},
+		"both":    {"plaintext": "hi", "plaintext_file": "/tmp/in"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			args := map[string]any{}
+			for k, v := range base {
+				args[k] = v
+			}
+			for k, v := range extra {
+				args[k] = v
+			}
+			if _, err := encryptCommand(newTestBase(), args); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestToolCall_Encrypt(t *testing.T) {
+	runner := &executortest.Runner{Stdout: "\x0a\x24binary"}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_kms_encrypt", map[string]any{
+		"key":       "app-key",
+		"keyring":   "app-ring",
+		"plaintext": "hello",
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+
+	args := runner.LastArgs()
+	if missing := executortest.Missing(args, "--plaintext-file=-", "--ciphertext-file=-", "--key=app-key", "--keyring=app-ring", "--location=global"); len(missing) > 0 {
+		t.Errorf("missing %v in %v", missing, args)
+	}
+	if got := string(runner.LastStdin()); got != "hello" {
+		t.Errorf("expected plaintext on stdin, got %q", got)
+	}
+	if got, want := servicetest.Text(result), base64.StdEncoding.EncodeToString([]byte("\x0a\x24binary")); got != want {
+		t.Errorf("expected base64 ciphertext %q, got %q", want, got)
+	}
+}
+
+func TestToolCall_Decrypt(t *testing.T) {
+	runner := &executortest.Runner{Stdout: "hello"}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_kms_decrypt", map[string]any{
+		"key":        "app-key",
+		"keyring":    "app-ring",
+		"ciphertext": base64.StdEncoding.EncodeToString([]byte("\x0a\x24binary")),
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+
+	if missing := executortest.Missing(runner.LastArgs(), "--ciphertext-file=-", "--plaintext-file=-"); len(missing) > 0 {
+		t.Errorf("missing %v in %v", missing, runner.LastArgs())
+	}
+	if got := string(runner.LastStdin()); got != "\x0a\x24binary" {
+		t.Errorf("expected decoded ciphertext on stdin, got %q", got)
+	}
+	if got := servicetest.Text(result); got != "hello" {
+		t.Errorf("expected plaintext, got %q", got)
+	}
+}
+
+func TestToolCall_DecryptInvalidBase64(t *testing.T) {
+	runner := &executortest.Runner{}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_kms_decrypt", map[string]any{
+		"key":        "app-key",
+		"keyring":    "app-ring",
+		"ciphertext": "not base64!",
+	})
+	if !result.IsError {
+		t.Fatal("expected an error result")
+	}
+	if len(runner.Calls()) != 0 {
+		t.Errorf("expected no gcloud calls, got %v", runner.Calls())
+	}
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MergeLabels returns the union of defaults and labels. Keys present in
+// labels take precedence over the defaults.
+func MergeLabels(defaults, labels map[string]string) map[string]string {
+	if len(defaults) == 0 && len(labels) == 0 {
+		return nil
+	}
+	merged := make(map[string]string, len(defaults)+len(labels))
+	for k, v := range defaults {
+		merged[k] = v
+	}
+	for k, v := range labels {
+		merged[k] = v
+	}
+	return merged
+}
+
+// FormatLabels renders labels as a sorted key=value,... list suitable for --labels.
+func FormatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// Labels merges the configured default labels with the "labels" argument and
+// returns the --labels value, or an empty string when there are none.
+func (b *BaseService) Labels(args map[string]any) string {
+	return FormatLabels(MergeLabels(b.Config.DefaultLabels, GetOptionalStringMap(args, "labels")))
+}
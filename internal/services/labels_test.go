@@ -0,0 +1,82 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"gcloud-go-mcp/internal/config"
+)
+
+func TestMergeLabels_UserLabelsTakePrecedence(t *testing.T) {
+	defaults := map[string]string{"created-by": "mcp", "env": "dev"}
+	labels := map[string]string{"env": "prod", "team": "data"}
+
+	merged := MergeLabels(defaults, labels)
+
+	want := map[string]string{"created-by": "mcp", "env": "prod", "team": "data"}
+	if len(merged) != len(want) {
+		t.Fatalf("expected %d labels, got %v", len(want), merged)
+	}
+	for k, v := range want {
+		if merged[k] != v {
+			t.Errorf("expected %s=%s, got %s=%s", k, v, k, merged[k])
+		}
+	}
+	if defaults["env"] != "dev" {
+		t.Error("expected defaults to be left unmodified")
+	}
+}
+
+func TestMergeLabels_Empty(t *testing.T) {
+	if merged := MergeLabels(nil, nil); merged != nil {
+		t.Errorf("expected nil, got %v", merged)
+	}
+}
+
+func TestFormatLabels(t *testing.T) {
+	got := FormatLabels(map[string]string{"team": "data", "env": "prod"})
+	if got != "env=prod,team=data" {
+		t.Errorf("expected sorted labels, got %q", got)
+	}
+	if got := FormatLabels(nil); got != "" {
+		t.Errorf("expected empty string, got %q", got)
+	}
+}
+
+func TestBaseService_Labels(t *testing.T) {
+	base := NewBaseService(&config.Config{
+		GCloudPath:     "gcloud",
+		CommandTimeout: time.Minute,
+		DefaultLabels:  map[string]string{"created-by": "mcp"},
+	})
+
+	tests := []struct {
+		name string
+		args map[string]any
+		want string
+	}{
+		{
+			name: "defaults only",
+			args: map[string]any{},
+			want: "created-by=mcp",
+		},
+		{
+			name: "user labels merged",
+			args: map[string]any{"labels": map[string]any{"env": "prod"}},
+			want: "created-by=mcp,env=prod",
+		},
+		{
+			name: "user label overrides default",
+			args: map[string]any{"labels": map[string]any{"created-by": "alice"}},
+			want: "created-by=alice",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := base.Labels(tt.args); got != tt.want {
+				t.Errorf("Labels() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
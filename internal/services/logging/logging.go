@@ -5,8 +5,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
+	"gcloud-go-mcp/internal/executor"
 	"gcloud-go-mcp/internal/services"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -14,7 +19,7 @@ import (
 // RegisterTools registers all Cloud Logging tools with the MCP server.
 func RegisterTools(server *mcp.Server, base *services.BaseService) {
 	// Read logs
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_logging_read",
 			Description: "Read log entries with optional filtering",
@@ -33,6 +38,10 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "string",
 						"description": "Resource type (e.g., cloud_run_revision, gce_instance, cloud_function)",
 					},
+					"resource_labels": map[string]any{
+						"type":        "object",
+						"description": "Resource labels to match exactly (e.g., {\"instance_id\": \"123\", \"function_name\": \"handler\"})",
+					},
 					"log_name": map[string]any{
 						"type":        "string",
 						"description": "Specific log name to read from",
@@ -49,8 +58,15 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 					},
 					"freshness": map[string]any{
 						"type":        "string",
-						"description": "How far back to read (e.g., 1h, 30m, 1d)",
-						"default":     "1h",
+						"description": "How far back to read (e.g., 1h, 30m, 1d). Defaults to 1h when no start_time or end_time is given, and cannot be combined with them",
+					},
+					"start_time": map[string]any{
+						"type":        "string",
+						"description": "Only return entries at or after this RFC3339 timestamp (e.g., 2024-01-02T15:04:05Z)",
+					},
+					"end_time": map[string]any{
+						"type":        "string",
+						"description": "Only return entries at or before this RFC3339 timestamp",
 					},
 					"order": map[string]any{
 						"type":        "string",
@@ -58,61 +74,174 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"default":     "desc",
 						"enum":        []string{"asc", "desc"},
 					},
+					"payload_only": map[string]any{
+						"type":        "boolean",
+						"description": "Return only the timestamp, severity and payload (textPayload, jsonPayload or protoPayload) of each entry",
+						"default":     false,
+					},
 				},
 			},
 		},
 		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			args := parseArgs(req)
+			cmd, err := readCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
 
-			// Build filter parts
-			var filterParts []string
-
-			if filter := services.GetOptionalString(args, "filter", ""); filter != "" {
-				filterParts = append(filterParts, filter)
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
 			}
-			if resourceType := services.GetOptionalString(args, "resource_type", ""); resourceType != "" {
-				filterParts = append(filterParts, fmt.Sprintf("resource.type=%s", resourceType))
+			if noEntries(result) {
+				return services.ToolResult(noEntriesMessage), nil
 			}
-			if logName := services.GetOptionalString(args, "log_name", ""); logName != "" {
-				filterParts = append(filterParts, fmt.Sprintf("logName:%s", logName))
+			if services.GetOptionalBool(args, "payload_only", false) {
+				entries, err := payloadEntries(result)
+				if err != nil {
+					return services.ToolError(err), nil
+				}
+				data, _ := json.MarshalIndent(entries, "", "  ")
+				return base.TextResult(string(data)), nil
 			}
-			if severity := services.GetOptionalString(args, "severity", ""); severity != "" {
-				filterParts = append(filterParts, fmt.Sprintf("severity>=%s", severity))
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// List logs
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_logging_logs_list",
+			Description: "List available logs in a project",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+
+			result, err := base.Executor.Command("logging", "logs", "list").
+				WithProject(services.GetOptionalString(args, "project", "")).
+				Execute(ctx)
+
+			if err != nil {
+				return services.ToolError(err), nil
 			}
+			return base.CommandResult(result), nil
+		},
+	)
 
-			cmd := base.Executor.Command("logging", "read")
+	// List log-based metrics
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_logging_metrics_list",
+			Description: "List log-based metrics in a project",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+
+			cmd := base.Executor.Command("logging", "metrics", "list").
+				WithProject(services.GetOptionalString(args, "project", ""))
 
-			// Add filter as positional argument if present
-			if len(filterParts) > 0 {
-				filterStr := strings.Join(filterParts, " AND ")
-				cmd = base.Executor.Command("logging", "read", filterStr)
+			if err := services.ApplyListOutput(cmd, args, csvColumns["metrics"]); err != nil {
+				return services.ToolError(err), nil
 			}
 
-			cmd.WithProject(services.GetOptionalString(args, "project", "")).
-				WithFlag("limit", fmt.Sprintf("%d", services.GetOptionalInt(args, "limit", 50))).
-				WithFlag("freshness", services.GetOptionalString(args, "freshness", "1h"))
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Create log-based metric
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_logging_metrics_create",
+			Description: "Create a counter log-based metric that counts log entries matching a filter",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"metric", "filter"},
+				"properties": map[string]any{
+					"metric": map[string]any{
+						"type":        "string",
+						"description": "Metric name",
+					},
+					"description": map[string]any{
+						"type":        "string",
+						"description": "Metric description",
+					},
+					"filter": map[string]any{
+						"type":        "string",
+						"description": "Log filter selecting the entries to count (e.g., 'resource.type=cloud_run_revision AND severity>=ERROR')",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"if_not_exists": services.IfNotExistsProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := metricCreateCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
 
-			order := services.GetOptionalString(args, "order", "desc")
-			if order == "asc" {
-				cmd.WithFlag("order", "asc")
+			existing, err := base.ExistingResource(ctx, args, base.Executor.Command("logging", "metrics", "describe", services.GetOptionalString(args, "metric", "")).
+				WithProject(services.GetOptionalString(args, "project", "")))
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			if existing != nil {
+				return existing, nil
 			}
 
 			result, err := cmd.Execute(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			if result.Stdout == "" {
+				return services.ToolResult("Metric created successfully"), nil
+			}
+			return base.CommandResult(result), nil
 		},
 	)
 
-	// List logs
-	server.AddTool(
+	// Delete log-based metric
+	base.AddTool(server,
 		&mcp.Tool{
-			Name:        "gcp_logging_logs_list",
-			Description: "List available logs in a project",
+			Name:        "gcp_logging_metrics_delete",
+			Description: "Delete a log-based metric",
+			Annotations: services.Destructive(),
 			InputSchema: map[string]any{
-				"type": "object",
+				"type":     "object",
+				"required": []string{"metric"},
 				"properties": map[string]any{
+					"metric": map[string]any{
+						"type":        "string",
+						"description": "Metric name",
+					},
 					"project": map[string]any{
 						"type":        "string",
 						"description": "GCP project ID",
@@ -122,20 +251,25 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 		},
 		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			args := parseArgs(req)
+			metric, err := services.GetRequiredString(args, "metric")
+			if err != nil {
+				return services.ToolError(err), nil
+			}
 
-			result, err := base.Executor.Command("logging", "logs", "list").
+			_, err = base.Executor.Command("logging", "metrics", "delete", metric).
 				WithProject(services.GetOptionalString(args, "project", "")).
+				WithBoolFlag("quiet").
 				Execute(ctx)
 
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return services.ToolResult("Metric deleted successfully"), nil
 		},
 	)
 
 	// Write log
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_logging_write",
 			Description: "Write a log entry",
@@ -190,7 +324,198 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if result.Stdout == "" {
 				return services.ToolResult("Log entry written successfully"), nil
 			}
-			return services.ToolResult(result.Stdout), nil
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// List log buckets
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_logging_buckets_list",
+			Description: "List log storage buckets with their location and retention",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"location": map[string]any{
+						"type":        "string",
+						"description": "Only list buckets in this location (e.g., global, us-central1). Lists every location by default",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+
+			cmd := base.Executor.Command("logging", "buckets", "list").
+				WithFlag("location", services.GetOptionalString(args, "location", "")).
+				WithProject(services.GetOptionalString(args, "project", ""))
+
+			if err := services.ApplyListOutput(cmd, args, csvColumns["buckets"]); err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Create log bucket
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_logging_buckets_create",
+			Description: "Create a log storage bucket in a location, e.g. to keep logs in a region or retain them longer",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"bucket", "location"},
+				"properties": map[string]any{
+					"bucket": map[string]any{
+						"type":        "string",
+						"description": "Bucket ID",
+					},
+					"location": map[string]any{
+						"type":        "string",
+						"description": "Location of the bucket (e.g., global, us-central1, eu). Cannot be changed later",
+					},
+					"retention_days": map[string]any{
+						"type":        "integer",
+						"description": "Days to retain log entries (1-3650, default 30)",
+					},
+					"description": map[string]any{
+						"type":        "string",
+						"description": "Bucket description",
+					},
+					"enable_analytics": map[string]any{
+						"type":        "boolean",
+						"description": "Upgrade the bucket to use Log Analytics",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := bucketCommand(base, "create", args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Update log bucket
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_logging_buckets_update",
+			Description: "Update the retention or description of a log storage bucket",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"bucket", "location"},
+				"properties": map[string]any{
+					"bucket": map[string]any{
+						"type":        "string",
+						"description": "Bucket ID (e.g., _Default)",
+					},
+					"location": map[string]any{
+						"type":        "string",
+						"description": "Location of the bucket (e.g., global)",
+					},
+					"retention_days": map[string]any{
+						"type":        "integer",
+						"description": "Days to retain log entries (1-3650)",
+					},
+					"description": map[string]any{
+						"type":        "string",
+						"description": "Bucket description",
+					},
+					"enable_analytics": map[string]any{
+						"type":        "boolean",
+						"description": "Upgrade the bucket to use Log Analytics",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := bucketCommand(base, "update", args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// List log views
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_logging_views_list",
+			Description: "List the log views of a log storage bucket",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"bucket", "location"},
+				"properties": map[string]any{
+					"bucket": map[string]any{
+						"type":        "string",
+						"description": "Bucket ID",
+					},
+					"location": map[string]any{
+						"type":        "string",
+						"description": "Location of the bucket (e.g., global)",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			if err := services.RequireAll(args, "bucket", "location"); err != nil {
+				return services.ToolError(err), nil
+			}
+
+			cmd := base.Executor.Command("logging", "views", "list").
+				WithFlag("bucket", services.GetOptionalString(args, "bucket", "")).
+				WithFlag("location", services.GetOptionalString(args, "location", "")).
+				WithProject(services.GetOptionalString(args, "project", ""))
+
+			if err := services.ApplyListOutput(cmd, args, csvColumns["views"]); err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
 		},
 	)
 }
@@ -205,3 +530,209 @@ func parseArgs(req *mcp.CallToolRequest) map[string]any {
 	}
 	return args
 }
+
+// csvColumns are the default column projections used when a list tool is
+// called with output=csv, keyed by resource.
+var csvColumns = map[string]string{
+	"metrics": "name,description,filter",
+	"buckets": "name.basename(),name.segment(3):label=LOCATION,retentionDays,lifecycleState,locked",
+	"views":   "name.basename(),description,filter",
+}
+
+// payloadEntry is a log entry reduced to its timestamp, severity and
+// whichever payload it has.
+type payloadEntry struct {
+	Timestamp    string          `json:"timestamp"`
+	Severity     string          `json:"severity,omitempty"`
+	TextPayload  string          `json:"textPayload,omitempty"`
+	JSONPayload  json.RawMessage `json:"jsonPayload,omitempty"`
+	ProtoPayload json.RawMessage `json:"protoPayload,omitempty"`
+}
+
+// noEntriesMessage is returned by gcp_logging_read instead of an empty array,
+// so that callers can tell a read that matched nothing from a failed one.
+const noEntriesMessage = "No log entries matched. Try a longer freshness, a wider time range or a looser filter."
+
+// noEntries reports whether a logging read returned no entries.
+func noEntries(result *executor.Result) bool {
+	if result.JSON == nil {
+		return strings.TrimSpace(result.Stdout) == ""
+	}
+	var entries []json.RawMessage
+	if err := result.ParseJSON(&entries); err != nil {
+		return false
+	}
+	return len(entries) == 0
+}
+
+// payloadEntries reduces the entries returned by `logging read` to
+// payloadEntry values. No output means no entries.
+func payloadEntries(result *executor.Result) ([]payloadEntry, error) {
+	entries := []payloadEntry{}
+	if result.JSON == nil {
+		return entries, nil
+	}
+	if err := result.ParseJSON(&entries); err != nil {
+		return nil, fmt.Errorf("failed to parse log entries: %w", err)
+	}
+	return entries, nil
+}
+
+// metricCreateCommand builds the `logging metrics create` command.
+func metricCreateCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	metric, err := services.GetRequiredString(args, "metric")
+	if err != nil {
+		return nil, err
+	}
+	filter, err := services.GetRequiredString(args, "filter")
+	if err != nil {
+		return nil, err
+	}
+
+	return base.Executor.Command("logging", "metrics", "create", metric).
+		WithFlag("description", services.GetOptionalString(args, "description", "")).
+		WithFlag("log-filter", filter).
+		WithProject(services.GetOptionalString(args, "project", "")), nil
+}
+
+// bucketCommand builds a `logging buckets create` or `logging buckets update`
+// command. An update must change at least one setting.
+func bucketCommand(base *services.BaseService, action string, args map[string]any) (*executor.CommandBuilder, error) {
+	if err := services.RequireAll(args, "bucket", "location"); err != nil {
+		return nil, err
+	}
+	bucket, _ := services.GetRequiredString(args, "bucket")
+	location, _ := services.GetRequiredString(args, "location")
+
+	cmd := base.Executor.Command("logging", "buckets", action, bucket).
+		WithFlag("location", location).
+		WithProject(services.GetOptionalString(args, "project", ""))
+
+	updated := false
+	if raw, ok := args["retention_days"]; ok {
+		if days, ok := raw.(float64); ok && days != math.Trunc(days) {
+			return nil, fmt.Errorf("retention_days must be a whole number of days, got %v", days)
+		}
+		days := services.GetOptionalInt(args, "retention_days", 0)
+		if days < 1 || days > 3650 {
+			return nil, fmt.Errorf("retention_days must be between 1 and 3650, got %d", days)
+		}
+		cmd.WithFlag("retention-days", strconv.Itoa(days))
+		updated = true
+	}
+	if description := services.GetOptionalString(args, "description", ""); description != "" {
+		cmd.WithFlag("description", description)
+		updated = true
+	}
+	if services.GetOptionalBool(args, "enable_analytics", false) {
+		cmd.WithBoolFlag("enable-analytics")
+		updated = true
+	}
+	if action == "update" && !updated {
+		return nil, fmt.Errorf("no updates specified")
+	}
+	return cmd, nil
+}
+
+// readCommand builds the `logging read` command, passing the combined filter
+// as its positional argument.
+func readCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	filterParts, timeRange, err := readFilterParts(args)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := base.Executor.Command("logging", "read").
+		WithPositional(strings.Join(filterParts, " AND ")).
+		WithProject(services.GetOptionalString(args, "project", "")).
+		WithFlag("limit", fmt.Sprintf("%d", services.GetOptionalInt(args, "limit", 50)))
+
+	// An explicit time range replaces the freshness window
+	if !timeRange {
+		cmd.WithFlag("freshness", services.GetOptionalString(args, "freshness", "1h"))
+	}
+	if services.GetOptionalString(args, "order", "desc") == "asc" {
+		cmd.WithFlag("order", "asc")
+	}
+	return cmd, nil
+}
+
+// timeRangeFilter returns filter clauses for the start_time and end_time
+// arguments. Both must be RFC3339 timestamps and cannot be combined with
+// freshness.
+func timeRangeFilter(args map[string]any) ([]string, error) {
+	start := services.GetOptionalString(args, "start_time", "")
+	end := services.GetOptionalString(args, "end_time", "")
+	if start == "" && end == "" {
+		return nil, nil
+	}
+	if services.GetOptionalString(args, "freshness", "") != "" {
+		return nil, fmt.Errorf("freshness cannot be combined with start_time or end_time")
+	}
+
+	var clauses []string
+	var startTime time.Time
+	if start != "" {
+		t, err := time.Parse(time.RFC3339, start)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start_time %q: must be an RFC3339 timestamp", start)
+		}
+		startTime = t
+		clauses = append(clauses, fmt.Sprintf("timestamp>=%q", start))
+	}
+	if end != "" {
+		t, err := time.Parse(time.RFC3339, end)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end_time %q: must be an RFC3339 timestamp", end)
+		}
+		if !startTime.IsZero() && t.Before(startTime) {
+			return nil, fmt.Errorf("end_time must not be before start_time")
+		}
+		clauses = append(clauses, fmt.Sprintf("timestamp<=%q", end))
+	}
+	return clauses, nil
+}
+
+// readFilterParts returns the AND-combined clauses of the logging read filter
+// and whether they include an explicit time range.
+func readFilterParts(args map[string]any) ([]string, bool, error) {
+	var filterParts []string
+
+	if filter := services.GetOptionalString(args, "filter", ""); filter != "" {
+		filterParts = append(filterParts, filter)
+	}
+	if resourceType := services.GetOptionalString(args, "resource_type", ""); resourceType != "" {
+		filterParts = append(filterParts, fmt.Sprintf("resource.type=%s", resourceType))
+	}
+	filterParts = append(filterParts, resourceLabelFilter(services.GetOptionalStringMap(args, "resource_labels"))...)
+	if logName := services.GetOptionalString(args, "log_name", ""); logName != "" {
+		filterParts = append(filterParts, fmt.Sprintf("logName:%s", logName))
+	}
+	if severity := services.GetOptionalString(args, "severity", ""); severity != "" {
+		filterParts = append(filterParts, fmt.Sprintf("severity>=%s", severity))
+	}
+
+	timeParts, err := timeRangeFilter(args)
+	if err != nil {
+		return nil, false, err
+	}
+	filterParts = append(filterParts, timeParts...)
+
+	return filterParts, len(timeParts) > 0, nil
+}
+
+// resourceLabelFilter returns a resource.labels clause for each label, sorted
+// by key, with values quoted.
+func resourceLabelFilter(labels map[string]string) []string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	clauses := make([]string, 0, len(keys))
+	for _, k := range keys {
+		clauses = append(clauses, fmt.Sprintf("resource.labels.%s=%s", k, strconv.Quote(labels[k])))
+	}
+	return clauses
+}
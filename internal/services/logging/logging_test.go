@@ -0,0 +1,483 @@
+package logging
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"gcloud-go-mcp/internal/executor/executortest"
+	"gcloud-go-mcp/internal/services"
+	"gcloud-go-mcp/internal/services/servicetest"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func newTestBase() *services.BaseService {
+	return services.NewBaseService(servicetest.NewConfig())
+}
+
+func TestRegisterTools(t *testing.T) {
+	server := mcp.NewServer(
+		&mcp.Implementation{
+			Name:    "test-server",
+			Version: "0.0.1",
+		},
+		&mcp.ServerOptions{},
+	)
+
+	// Should not panic
+	RegisterTools(server, newTestBase())
+}
+
+func TestTimeRangeFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "no range",
+			args: map[string]any{"freshness": "2h"},
+			want: nil,
+		},
+		{
+			name: "start and end",
+			args: map[string]any{
+				"start_time": "2024-01-02T15:04:05Z",
+				"end_time":   "2024-01-02T16:00:00Z",
+			},
+			want: []string{`timestamp>="2024-01-02T15:04:05Z"`, `timestamp<="2024-01-02T16:00:00Z"`},
+		},
+		{
+			name: "start only with offset",
+			args: map[string]any{"start_time": "2024-01-02T15:04:05+02:00"},
+			want: []string{`timestamp>="2024-01-02T15:04:05+02:00"`},
+		},
+		{
+			name:    "freshness is mutually exclusive",
+			args:    map[string]any{"start_time": "2024-01-02T15:04:05Z", "freshness": "1h"},
+			wantErr: "freshness cannot be combined",
+		},
+		{
+			name:    "invalid start",
+			args:    map[string]any{"start_time": "yesterday"},
+			wantErr: "invalid start_time",
+		},
+		{
+			name:    "invalid end",
+			args:    map[string]any{"end_time": "2024-01-02 16:00"},
+			wantErr: "invalid end_time",
+		},
+		{
+			name: "end before start",
+			args: map[string]any{
+				"start_time": "2024-01-02T16:00:00Z",
+				"end_time":   "2024-01-02T15:00:00Z",
+			},
+			wantErr: "end_time must not be before start_time",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := timeRangeFilter(tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("timeRangeFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadFilterParts_ResourceLabels(t *testing.T) {
+	tests := []struct {
+		name string
+		args map[string]any
+		want string
+	}{
+		{
+			name: "single label",
+			args: map[string]any{
+				"resource_type":   "gce_instance",
+				"resource_labels": map[string]any{"instance_id": "1234567890"},
+			},
+			want: `resource.type=gce_instance AND resource.labels.instance_id="1234567890"`,
+		},
+		{
+			name: "multiple labels sorted by key",
+			args: map[string]any{
+				"resource_type": "cloud_function",
+				"resource_labels": map[string]any{
+					"region":        "us-central1",
+					"function_name": "handler",
+				},
+				"severity": "ERROR",
+			},
+			want: `resource.type=cloud_function AND resource.labels.function_name="handler" AND resource.labels.region="us-central1" AND severity>=ERROR`,
+		},
+		{
+			name: "values are quoted and escaped",
+			args: map[string]any{
+				"resource_labels": map[string]any{"service_name": `my "svc"`},
+			},
+			want: `resource.labels.service_name="my \"svc\""`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parts, _, err := readFilterParts(tt.args)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := strings.Join(parts, " AND "); got != tt.want {
+				t.Errorf("filter = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToolCall_Read(t *testing.T) {
+	runner := &executortest.Runner{}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_logging_read", map[string]any{
+		"resource_type": "gce_instance",
+		"limit":         float64(10),
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+
+	args := runner.LastArgs()
+	if len(args) < 3 || !slices.Equal(args[:3], []string{"logging", "read", "resource.type=gce_instance"}) {
+		t.Errorf("unexpected command %v", args)
+	}
+	if missing := executortest.Missing(args, "--limit=10", "--freshness=1h"); len(missing) > 0 {
+		t.Errorf("missing %v in %v", missing, args)
+	}
+}
+
+func TestToolCall_ReadPayloadOnly(t *testing.T) {
+	runner := &executortest.Runner{Stdout: `[
+  {"insertId": "a", "timestamp": "2024-05-01T10:00:00Z", "severity": "ERROR", "logName": "projects/p/logs/app",
+   "resource": {"type": "cloud_run_revision"}, "jsonPayload": {"message": "boom", "code": 500}},
+  {"insertId": "b", "timestamp": "2024-05-01T10:00:01Z", "severity": "INFO", "textPayload": "started"},
+  {"insertId": "c", "timestamp": "2024-05-01T10:00:02Z", "severity": "NOTICE",
+   "protoPayload": {"@type": "type.googleapis.com/google.cloud.audit.AuditLog", "methodName": "SetIamPolicy"}}
+]`}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_logging_read", map[string]any{
+		"payload_only": true,
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+
+	var got []map[string]any
+	if err := json.Unmarshal([]byte(servicetest.Text(result)), &got); err != nil {
+		t.Fatalf("result is not JSON: %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("got %d entries, want 3", len(got))
+	}
+	for _, entry := range got {
+		if _, ok := entry["insertId"]; ok {
+			t.Errorf("entry kept insertId: %v", entry)
+		}
+		if _, ok := entry["resource"]; ok {
+			t.Errorf("entry kept resource: %v", entry)
+		}
+	}
+	if payload, _ := got[0]["jsonPayload"].(map[string]any); payload["message"] != "boom" {
+		t.Errorf("jsonPayload = %v", got[0]["jsonPayload"])
+	}
+	if got[0]["severity"] != "ERROR" || got[0]["timestamp"] != "2024-05-01T10:00:00Z" {
+		t.Errorf("entry 0 = %v", got[0])
+	}
+	if got[1]["textPayload"] != "started" {
+		t.Errorf("textPayload = %v", got[1]["textPayload"])
+	}
+	if payload, _ := got[2]["protoPayload"].(map[string]any); payload["methodName"] != "SetIamPolicy" {
+		t.Errorf("protoPayload = %v", got[2]["protoPayload"])
+	}
+}
+
+func TestToolCall_ReadPayloadOnlyTruncated(t *testing.T) {
+	runner := &executortest.Runner{Stdout: `[{"severity": "INFO", "textPayload": "` + strings.Repeat("x", 500) + `"}]`}
+	cfg := servicetest.NewConfig()
+	cfg.MaxOutputBytes = 100
+	result := servicetest.CallToolWithConfig(t, cfg, RegisterTools, runner, "gcp_logging_read", map[string]any{
+		"payload_only": true,
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+	if got := servicetest.Text(result); !strings.Contains(got, "[Output truncated: showing 100 of") {
+		t.Errorf("expected truncated output, got %q", got)
+	}
+}
+
+func TestToolCall_ReadPayloadOnlyNoEntries(t *testing.T) {
+	runner := &executortest.Runner{}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_logging_read", map[string]any{
+		"payload_only": true,
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+	if got := servicetest.Text(result); got != noEntriesMessage {
+		t.Errorf("result = %q, want %q", got, noEntriesMessage)
+	}
+}
+
+func TestToolCall_ReadEmptyVsEntries(t *testing.T) {
+	tests := []struct {
+		name   string
+		stdout string
+		empty  bool
+	}{
+		{name: "no output", stdout: "", empty: true},
+		{name: "empty array", stdout: "[]\n", empty: true},
+		{name: "entries", stdout: `[{"insertId": "a", "textPayload": "started"}]`, empty: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &executortest.Runner{Stdout: tt.stdout}
+			result := servicetest.CallTool(t, RegisterTools, runner, "gcp_logging_read", map[string]any{})
+			if result.IsError {
+				t.Fatalf("unexpected error: %s", servicetest.Text(result))
+			}
+
+			text := servicetest.Text(result)
+			if tt.empty && text != noEntriesMessage {
+				t.Errorf("result = %q, want %q", text, noEntriesMessage)
+			}
+			if !tt.empty && !strings.Contains(text, `"textPayload": "started"`) {
+				t.Errorf("expected the entries, got %q", text)
+			}
+		})
+	}
+}
+
+func TestMetricCreateCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		notWant []string
+		wantErr string
+	}{
+		{
+			name: "all fields",
+			args: map[string]any{
+				"metric":      "run-errors",
+				"description": "Cloud Run errors",
+				"filter":      "resource.type=cloud_run_revision AND severity>=ERROR",
+			},
+			want: []string{
+				"--description=Cloud Run errors",
+				"--log-filter=resource.type=cloud_run_revision AND severity>=ERROR",
+			},
+		},
+		{
+			name:    "without description",
+			args:    map[string]any{"metric": "run-errors", "filter": "severity>=ERROR"},
+			want:    []string{"--log-filter=severity>=ERROR"},
+			notWant: []string{"--description="},
+		},
+		{
+			name:    "missing filter",
+			args:    map[string]any{"metric": "run-errors"},
+			wantErr: "filter",
+		},
+		{
+			name:    "missing metric",
+			args:    map[string]any{"filter": "severity>=ERROR"},
+			wantErr: "metric",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := metricCreateCommand(newTestBase(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if !slices.Equal(args[:4], []string{"logging", "metrics", "create", "run-errors"}) {
+				t.Errorf("unexpected command %v", args)
+			}
+			if missing := executortest.Missing(args, tt.want...); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, args)
+			}
+			for _, arg := range args {
+				for _, nw := range tt.notWant {
+					if strings.HasPrefix(arg, nw) {
+						t.Errorf("unexpected %q in %v", arg, args)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestReadCommand_FlagsWithFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		notWant string
+	}{
+		{
+			name: "filter with all flags",
+			args: map[string]any{
+				"filter":    "severity>=ERROR",
+				"project":   "other-project",
+				"limit":     float64(5),
+				"freshness": "2h",
+				"order":     "asc",
+			},
+			want: []string{"logging", "read", "severity>=ERROR", "--project=other-project", "--limit=5", "--freshness=2h", "--order=asc"},
+		},
+		{
+			name: "multi-clause filter with defaults",
+			args: map[string]any{"resource_type": "cloud_run_revision", "severity": "WARNING"},
+			want: []string{"logging", "read", "resource.type=cloud_run_revision AND severity>=WARNING", "--project=test-project", "--limit=50", "--freshness=1h"},
+		},
+		{
+			name:    "time range replaces freshness",
+			args:    map[string]any{"start_time": "2024-01-02T15:04:05Z"},
+			want:    []string{"logging", "read", `timestamp>="2024-01-02T15:04:05Z"`, "--project=test-project", "--limit=50"},
+			notWant: "--freshness=",
+		},
+		{
+			name: "no filter",
+			args: map[string]any{},
+			want: []string{"logging", "read", "--project=test-project", "--limit=50", "--freshness=1h"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := readCommand(newTestBase(), tt.args)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if !slices.Equal(args[:2], tt.want[:2]) {
+				t.Errorf("unexpected command %v", args)
+			}
+			if missing := executortest.Missing(args, tt.want[2:]...); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, args)
+			}
+			if tt.notWant != "" {
+				for _, arg := range args {
+					if strings.HasPrefix(arg, tt.notWant) {
+						t.Errorf("unexpected %q in %v", arg, args)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestBucketCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		action  string
+		args    map[string]any
+		want    []string
+		notWant []string
+		wantErr string
+	}{
+		{
+			name:   "create with retention",
+			action: "create",
+			args:   map[string]any{"bucket": "audit-eu", "location": "europe-west1", "retention_days": float64(400)},
+			want:   []string{"--location=europe-west1", "--retention-days=400"},
+		},
+		{
+			name:    "create with default retention",
+			action:  "create",
+			args:    map[string]any{"bucket": "audit-eu", "location": "europe-west1"},
+			want:    []string{"--location=europe-west1"},
+			notWant: []string{"--retention-days="},
+		},
+		{
+			name:    "retention out of range",
+			action:  "create",
+			args:    map[string]any{"bucket": "audit-eu", "location": "europe-west1", "retention_days": float64(0)},
+			wantErr: "between 1 and 3650",
+		},
+		{
+			name:    "missing location",
+			action:  "create",
+			args:    map[string]any{"bucket": "audit-eu"},
+			wantErr: "location",
+		},
+		{
+			name:   "update retention",
+			action: "update",
+			args:   map[string]any{"bucket": "audit-eu", "location": "europe-west1", "retention_days": float64(3650)},
+			want:   []string{"--retention-days=3650"},
+		},
+		{
+			name:    "fractional retention",
+			action:  "update",
+			args:    map[string]any{"bucket": "audit-eu", "location": "europe-west1", "retention_days": 30.7},
+			wantErr: "whole number of days",
+		},
+		{
+			name:    "update without changes",
+			action:  "update",
+			args:    map[string]any{"bucket": "audit-eu", "location": "europe-west1"},
+			wantErr: "no updates specified",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := bucketCommand(newTestBase(), tt.action, tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if !slices.Equal(args[:4], []string{"logging", "buckets", tt.action, "audit-eu"}) {
+				t.Errorf("unexpected command %v", args)
+			}
+			if missing := executortest.Missing(args, tt.want...); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, args)
+			}
+			for _, arg := range args {
+				for _, nw := range tt.notWant {
+					if strings.HasPrefix(arg, nw) {
+						t.Errorf("unexpected %q in %v", arg, args)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+// Package monitoring provides MCP tools for Cloud Monitoring uptime checks and
+// alerting policies.
+package monitoring
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gcloud-go-mcp/internal/executor"
+	"gcloud-go-mcp/internal/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RegisterTools registers all Cloud Monitoring tools with the MCP server.
+func RegisterTools(server *mcp.Server, base *services.BaseService) {
+	// List uptime checks
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_monitoring_uptime_checks_list",
+			Description: "List uptime check configurations",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"filter": map[string]any{
+						"type":        "string",
+						"description": "Filter expression",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd := base.Executor.Command("monitoring", "uptime", "list-configs").
+				WithFlag("filter", services.GetOptionalString(args, "filter", "")).
+				WithProject(services.GetOptionalString(args, "project", ""))
+			if err := services.ApplyListOutput(cmd, args, csvColumns["uptime-checks"]); err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Create uptime check
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_monitoring_uptime_checks_create",
+			Description: "Create an uptime check that probes a URL or resource from locations around the world",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"display_name"},
+				"properties": map[string]any{
+					"display_name": map[string]any{
+						"type":        "string",
+						"description": "Display name of the uptime check",
+					},
+					"resource_type": map[string]any{
+						"type":        "string",
+						"description": "Type of the monitored resource",
+						"default":     "uptime-url",
+						"enum":        []string{"uptime-url", "gce-instance", "gae-app", "aws-elb-load-balancer", "k8s-service", "servicedirectory-service", "cloud-run-revision"},
+					},
+					"host": map[string]any{
+						"type":        "string",
+						"description": "Hostname or IP address to check (required for uptime-url)",
+					},
+					"path": map[string]any{
+						"type":        "string",
+						"description": "Request path for HTTP and HTTPS checks (e.g., /healthz)",
+					},
+					"resource_labels": map[string]any{
+						"type":        "object",
+						"description": "Labels identifying the monitored resource for resource types other than uptime-url (e.g., {\"instance_id\": \"123\", \"zone\": \"us-central1-a\"})",
+					},
+					"protocol": map[string]any{
+						"type":        "string",
+						"description": "Protocol of the check",
+						"enum":        []string{"http", "https", "tcp"},
+					},
+					"port": map[string]any{
+						"type":        "integer",
+						"description": "Port to check (defaults to the protocol's port)",
+					},
+					"period_minutes": map[string]any{
+						"type":        "integer",
+						"description": "How often to run the check",
+						"enum":        []int{1, 5, 10, 15},
+					},
+					"timeout_seconds": map[string]any{
+						"type":        "integer",
+						"description": "Seconds to wait for a response (1-60)",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := uptimeCheckCreateCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// List alert policies
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_monitoring_alert_policies_list",
+			Description: "List alerting policies",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"filter": map[string]any{
+						"type":        "string",
+						"description": "Filter expression",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd := base.Executor.Command("beta", "monitoring", "policies", "list").
+				WithFlag("filter", services.GetOptionalString(args, "filter", "")).
+				WithProject(services.GetOptionalString(args, "project", ""))
+			if err := services.ApplyListOutput(cmd, args, csvColumns["alert-policies"]); err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+}
+
+func parseArgs(req *mcp.CallToolRequest) map[string]any {
+	var args map[string]any
+	if req.Params.Arguments != nil {
+		_ = json.Unmarshal(req.Params.Arguments, &args)
+	}
+	if args == nil {
+		args = make(map[string]any)
+	}
+	return args
+}
+
+// csvColumns are the default column projections used when a list tool is
+// called with output=csv, keyed by resource.
+var csvColumns = map[string]string{
+	"uptime-checks":  "displayName,monitoredResource.type,monitoredResource.labels.host,httpCheck.path,period",
+	"alert-policies": "displayName,enabled,combiner,conditions[0].displayName",
+}
+
+// uptimeCheckCreateCommand builds the `monitoring uptime create` command.
+// For uptime-url checks the resource labels are built from host and the
+// project; other resource types take them from resource_labels.
+func uptimeCheckCreateCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	displayName, err := services.GetRequiredString(args, "display_name")
+	if err != nil {
+		return nil, err
+	}
+	project := services.GetOptionalString(args, "project", base.Config.Project)
+	resourceType := services.GetOptionalString(args, "resource_type", "uptime-url")
+
+	labels := services.GetOptionalStringMap(args, "resource_labels")
+	if resourceType == "uptime-url" {
+		host, err := services.GetRequiredString(args, "host")
+		if err != nil {
+			return nil, err
+		}
+		if project == "" {
+			return nil, fmt.Errorf("project is required for uptime-url checks (pass project or set GCLOUD_PROJECT)")
+		}
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels["host"] = host
+		labels["project_id"] = project
+	} else if len(labels) == 0 {
+		return nil, fmt.Errorf("resource_labels is required for %s checks", resourceType)
+	}
+
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, k+"="+labels[k])
+	}
+
+	cmd := base.Executor.Command("monitoring", "uptime", "create", displayName).
+		WithFlag("resource-type", resourceType).
+		WithFlag("resource-labels", strings.Join(pairs, ",")).
+		WithFlag("path", services.GetOptionalString(args, "path", "")).
+		WithFlag("protocol", services.GetOptionalString(args, "protocol", "")).
+		WithProject(project)
+
+	if port := services.GetOptionalInt(args, "port", 0); port > 0 {
+		cmd.WithFlag("port", strconv.Itoa(port))
+	}
+	if period := services.GetOptionalInt(args, "period_minutes", 0); period > 0 {
+		cmd.WithFlag("period", strconv.Itoa(period))
+	}
+	if timeout := services.GetOptionalInt(args, "timeout_seconds", 0); timeout > 0 {
+		cmd.WithFlag("timeout", strconv.Itoa(timeout))
+	}
+	return cmd, nil
+}
@@ -0,0 +1,137 @@
+package monitoring
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"gcloud-go-mcp/internal/executor/executortest"
+	"gcloud-go-mcp/internal/services"
+	"gcloud-go-mcp/internal/services/servicetest"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func newTestBase() *services.BaseService {
+	return services.NewBaseService(servicetest.NewConfig())
+}
+
+func TestRegisterTools(t *testing.T) {
+	server := mcp.NewServer(
+		&mcp.Implementation{
+			Name:    "test-server",
+			Version: "0.0.1",
+		},
+		&mcp.ServerOptions{},
+	)
+
+	// Should not panic
+	RegisterTools(server, newTestBase())
+}
+
+func TestUptimeCheckCreateCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		notWant []string
+		wantErr string
+	}{
+		{
+			name: "url check",
+			args: map[string]any{
+				"display_name": "homepage",
+				"host":         "www.example.com",
+				"path":         "/healthz",
+				"protocol":     "https",
+			},
+			want: []string{
+				"--resource-type=uptime-url",
+				"--resource-labels=host=www.example.com,project_id=test-project",
+				"--path=/healthz", "--protocol=https", "--project=test-project",
+			},
+			notWant: []string{"--port=", "--period=", "--timeout="},
+		},
+		{
+			name: "project argument becomes the project_id label",
+			args: map[string]any{
+				"display_name":    "homepage",
+				"host":            "www.example.com",
+				"project":         "other-project",
+				"port":            float64(8443),
+				"period_minutes":  float64(1),
+				"timeout_seconds": float64(10),
+			},
+			want: []string{
+				"--resource-labels=host=www.example.com,project_id=other-project",
+				"--project=other-project", "--port=8443", "--period=1", "--timeout=10",
+			},
+		},
+		{
+			name: "instance check",
+			args: map[string]any{
+				"display_name":    "web-vm",
+				"resource_type":   "gce-instance",
+				"resource_labels": map[string]any{"zone": "us-central1-a", "instance_id": "123"},
+				"protocol":        "tcp",
+				"port":            float64(22),
+			},
+			want:    []string{"--resource-type=gce-instance", "--resource-labels=instance_id=123,zone=us-central1-a", "--port=22"},
+			notWant: []string{"--path="},
+		},
+		{
+			name:    "url check without host",
+			args:    map[string]any{"display_name": "homepage"},
+			wantErr: "host",
+		},
+		{
+			name:    "instance check without labels",
+			args:    map[string]any{"display_name": "web-vm", "resource_type": "gce-instance"},
+			wantErr: "resource_labels",
+		},
+		{
+			name:    "missing display name",
+			args:    map[string]any{"host": "www.example.com"},
+			wantErr: "display_name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := uptimeCheckCreateCommand(newTestBase(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if !slices.Equal(args[:4], []string{"monitoring", "uptime", "create", tt.args["display_name"].(string)}) {
+				t.Errorf("unexpected command %v", args)
+			}
+			if missing := executortest.Missing(args, tt.want...); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, args)
+			}
+			for _, nw := range tt.notWant {
+				for _, arg := range args {
+					if strings.HasPrefix(arg, nw) {
+						t.Errorf("did not expect %q in %v", arg, args)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestUptimeCheckCreateCommand_NoProject(t *testing.T) {
+	base := newTestBase()
+	base.Config.Project = ""
+
+	_, err := uptimeCheckCreateCommand(base, map[string]any{"display_name": "homepage", "host": "www.example.com"})
+	if err == nil || !strings.Contains(err.Error(), "project is required") {
+		t.Errorf("expected project error, got %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"encoding/json"
+	"strings"
+
+	"gcloud-go-mcp/internal/executor"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// notFoundMarkers are fragments of gcloud error output that indicate the
+// requested resource doesn't exist.
+var notFoundMarkers = []string{"NOT_FOUND", "was not found", "could not be found", "HTTPError 404"}
+
+// NotFound is the result returned by describe tools called with
+// soft_not_found when the resource doesn't exist.
+type NotFound struct {
+	Exists  bool   `json:"exists"`
+	Message string `json:"message"`
+}
+
+// SoftNotFoundProperty returns the input schema for the soft_not_found
+// argument accepted by describe tools.
+func SoftNotFoundProperty() map[string]any {
+	return map[string]any{
+		"type":        "boolean",
+		"description": "Return {\"exists\": false} instead of an error when the resource doesn't exist",
+		"default":     false,
+	}
+}
+
+// IsNotFound reports whether a command failed because the resource it
+// targeted doesn't exist.
+func IsNotFound(result *executor.Result, err error) bool {
+	if err == nil {
+		return false
+	}
+	output := err.Error()
+	if result != nil {
+		output += "\n" + result.Stderr
+	}
+	for _, marker := range notFoundMarkers {
+		if strings.Contains(output, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// DescribeError creates the tool result for a failed describe command. When
+// soft_not_found is set and the resource doesn't exist, it returns a
+// successful {"exists": false} result instead of an error.
+func DescribeError(args map[string]any, result *executor.Result, err error) *mcp.CallToolResult {
+	if !GetOptionalBool(args, "soft_not_found", false) || !IsNotFound(result, err) {
+		return ToolError(err)
+	}
+
+	message := err.Error()
+	if result != nil {
+		if stderr := executor.CleanStderr(result.Stderr); stderr != "" {
+			message = stderr
+		}
+	}
+	data, _ := json.MarshalIndent(NotFound{Exists: false, Message: message}, "", "  ")
+	return ToolResult(string(data))
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"gcloud-go-mcp/internal/executor"
+)
+
+func TestIsNotFound(t *testing.T) {
+	tests := []struct {
+		name   string
+		stderr string
+		err    error
+		want   bool
+	}{
+		{"api not found", "ERROR: (gcloud.secrets.describe) NOT_FOUND: Secret [projects/1/secrets/x] not found or has no versions.", errors.New("exit status 1"), true},
+		{"was not found", "ERROR: (gcloud.compute.instances.describe) Could not fetch resource:\n - The resource 'projects/p/zones/z/instances/vm' was not found", errors.New("exit status 1"), true},
+		{"404 in error", "", errors.New("gcloud command failed: exit status 1\nstderr: HTTPError 404: bucket does not exist"), true},
+		{"permission denied", "ERROR: (gcloud.secrets.describe) PERMISSION_DENIED: Permission denied", errors.New("exit status 1"), false},
+		{"no error", "NOT_FOUND", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsNotFound(&executor.Result{Stderr: tt.stderr}, tt.err); got != tt.want {
+				t.Errorf("IsNotFound() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDescribeError(t *testing.T) {
+	stderr := "ERROR: (gcloud.pubsub.topics.describe) NOT_FOUND: Resource not found (resource=orders)."
+	result := &executor.Result{Stderr: stderr, ExitCode: 1}
+	err := errors.New("gcloud command failed: exit status 1")
+
+	t.Run("soft not found", func(t *testing.T) {
+		r := DescribeError(map[string]any{"soft_not_found": true}, result, err)
+		if r.IsError {
+			t.Fatal("expected a non-error result")
+		}
+		var got NotFound
+		if err := json.Unmarshal([]byte(resultText(t, r)), &got); err != nil {
+			t.Fatalf("result is not JSON: %v", err)
+		}
+		if got.Exists || got.Message != stderr {
+			t.Errorf("unexpected result %+v", got)
+		}
+	})
+
+	t.Run("opt-in required", func(t *testing.T) {
+		if r := DescribeError(map[string]any{}, result, err); !r.IsError {
+			t.Error("expected an error result without soft_not_found")
+		}
+	})
+
+	t.Run("other failures stay errors", func(t *testing.T) {
+		denied := &executor.Result{Stderr: "PERMISSION_DENIED"}
+		if r := DescribeError(map[string]any{"soft_not_found": true}, denied, err); !r.IsError {
+			t.Error("expected an error result for a non-not-found failure")
+		}
+	})
+}
@@ -0,0 +1,37 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"gcloud-go-mcp/internal/executor"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// operationIDPattern matches long-running operation identifiers printed by
+// gcloud, either as full resource names or as bare operation IDs.
+var operationIDPattern = regexp.MustCompile(`projects/[^\s'"\[\]]+/operations/[^\s'"\[\]]+|\boperation-[0-9]+-[0-9a-f-]+`)
+
+// OperationID returns the first operation identifier found in output, or an
+// empty string if there is none.
+func OperationID(output string) string {
+	return operationIDPattern.FindString(output)
+}
+
+// DeployError creates an error tool result for a failed deploy. When the
+// command timed out after gcloud had already started an operation, the
+// operation ID is reported so its status can still be checked.
+func DeployError(result *executor.Result, err error) *mcp.CallToolResult {
+	if result != nil && errors.Is(err, context.DeadlineExceeded) {
+		id := OperationID(result.Stderr)
+		if id == "" {
+			id = OperationID(result.Stdout)
+		}
+		if id != "" {
+			return ToolError(fmt.Errorf("deploy timed out but operation %s was started and may still be running; check its status before retrying: %w", id, err))
+		}
+	}
+	return ToolError(err)
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"gcloud-go-mcp/internal/config"
+	"gcloud-go-mcp/internal/executor"
+)
+
+func TestOperationID(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   string
+	}{
+		{
+			name:   "functions operation",
+			output: "Preparing function...done.\nDeploying function (may take a while - up to 2 minutes)...\nFor Cloud Build Logs, visit: https://console.cloud.google.com\n[projects/p1/locations/us-central1/operations/operation-1700000000000-abc] waiting",
+			want:   "projects/p1/locations/us-central1/operations/operation-1700000000000-abc",
+		},
+		{
+			name:   "gke operation",
+			output: "Creating cluster demo in us-central1... Operation [operation-1700000000000-5f1c2d3e-aa11]",
+			want:   "operation-1700000000000-5f1c2d3e-aa11",
+		},
+		{
+			name:   "no operation",
+			output: "Deploying container to Cloud Run service [hello]",
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := OperationID(tt.output); got != tt.want {
+				t.Errorf("OperationID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeployError_TimeoutReportsOperation(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a POSIX shell")
+	}
+
+	// Simulate a deploy that reports its operation and then hangs.
+	script := filepath.Join(t.TempDir(), "gcloud")
+	body := "#!/bin/sh\necho 'Waiting for operation [projects/p1/locations/us-central1/operations/operation-42-deadbeef] to complete...' >&2\nexec sleep 10\n"
+	if err := os.WriteFile(script, []byte(body), 0o755); err != nil {
+		t.Fatalf("failed to write fake gcloud: %v", err)
+	}
+
+	exec := executor.New(&config.Config{
+		GCloudPath:     script,
+		CommandTimeout: 200 * time.Millisecond,
+	})
+	result, err := exec.Command("functions", "deploy", "fn").Execute(context.Background())
+	if err == nil {
+		t.Fatal("expected timeout error")
+	}
+
+	toolResult := DeployError(result, err)
+	if !toolResult.IsError {
+		t.Error("expected an error result")
+	}
+	text := resultText(t, toolResult)
+	if !strings.Contains(text, "projects/p1/locations/us-central1/operations/operation-42-deadbeef") {
+		t.Errorf("expected operation ID in error, got %q", text)
+	}
+	if !strings.Contains(text, "timed out") {
+		t.Errorf("expected timeout to be reported, got %q", text)
+	}
+}
+
+func TestDeployError_PassesThroughOtherErrors(t *testing.T) {
+	result := &executor.Result{Stderr: "operation-1-abc failed"}
+	toolResult := DeployError(result, &testError{msg: "gcloud command failed: exit status 1"})
+
+	text := resultText(t, toolResult)
+	if text != "gcloud command failed: exit status 1" {
+		t.Errorf("expected original error, got %q", text)
+	}
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"gcloud-go-mcp/internal/executor"
+)
+
+// ListOutputProperty returns the input schema for the "output" argument
+// accepted by list tools.
+func ListOutputProperty() map[string]any {
+	return map[string]any{
+		"type":        "string",
+		"description": "Output format: json, or csv for spreadsheet export",
+		"default":     "json",
+		"enum":        []string{"json", "csv"},
+	}
+}
+
+// NamesOnlyProperty returns the input schema for the "names_only" argument
+// accepted by list tools.
+func NamesOnlyProperty() map[string]any {
+	return map[string]any{
+		"type":        "boolean",
+		"description": "Return only the resource names, one per line, e.g. to pass them to another tool",
+		"default":     false,
+	}
+}
+
+// ApplyListOutput sets the output format requested by the "output" and
+// "names_only" arguments. With output=csv the command returns gcloud's CSV
+// output projected to the given columns (e.g. "name,zone.basename(),status").
+// With names_only it returns the values of the first column, which names the
+// resource, one per line.
+func ApplyListOutput(cmd *executor.CommandBuilder, args map[string]any, columns string) error {
+	output := GetOptionalString(args, "output", "json")
+	if GetOptionalBool(args, "names_only", false) {
+		if output != "json" {
+			return fmt.Errorf("names_only cannot be combined with output=%s", output)
+		}
+		name, _, _ := strings.Cut(columns, ",")
+		cmd.WithFormat(fmt.Sprintf("value(%s)", name))
+		return nil
+	}
+
+	switch output {
+	case "json":
+		return nil
+	case "csv":
+		cmd.WithFormat(fmt.Sprintf("csv(%s)", columns))
+		return nil
+	default:
+		return fmt.Errorf("invalid output %q: must be json or csv", output)
+	}
+}
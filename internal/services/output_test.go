@@ -0,0 +1,54 @@
+package services
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"gcloud-go-mcp/internal/config"
+	"gcloud-go-mcp/internal/executor"
+)
+
+func TestApplyListOutput(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    string
+		wantErr bool
+	}{
+		{"default", map[string]any{}, "--format=json", false},
+		{"json", map[string]any{"output": "json"}, "--format=json", false},
+		{"csv", map[string]any{"output": "csv"}, "--format=csv(name,status)", false},
+		{"invalid", map[string]any{"output": "xml"}, "", true},
+		{"names only", map[string]any{"names_only": true}, "--format=value(name)", false},
+		{"names only json", map[string]any{"names_only": true, "output": "json"}, "--format=value(name)", false},
+		{"names only false", map[string]any{"names_only": false, "output": "csv"}, "--format=csv(name,status)", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := executor.New(&config.Config{GCloudPath: "gcloud"}).Command("things", "list")
+			err := ApplyListOutput(cmd, tt.args, "name,status")
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "must be json or csv") {
+					t.Fatalf("expected invalid output error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if args := cmd.Build(); !slices.Contains(args, tt.want) {
+				t.Errorf("expected %q in args, got %v", tt.want, args)
+			}
+		})
+	}
+}
+
+func TestApplyListOutput_NamesOnlyWithCSV(t *testing.T) {
+	cmd := executor.New(&config.Config{GCloudPath: "gcloud"}).Command("things", "list")
+	err := ApplyListOutput(cmd, map[string]any{"names_only": true, "output": "csv"}, "name,status")
+	if err == nil || !strings.Contains(err.Error(), "names_only cannot be combined with output=csv") {
+		t.Errorf("expected a conflict error, got %v", err)
+	}
+}
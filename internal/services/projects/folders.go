@@ -0,0 +1,177 @@
+package projects
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gcloud-go-mcp/internal/executor"
+	"gcloud-go-mcp/internal/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// registerHierarchyTools registers the folder and organization tools.
+func registerHierarchyTools(server *mcp.Server, base *services.BaseService) {
+	// List folders
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_resourcemanager_folders_list",
+			Description: "List the folders directly under an organization or folder",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"organization": map[string]any{
+						"type":        "string",
+						"description": "Organization ID to list folders under. Mutually exclusive with folder",
+					},
+					"folder": map[string]any{
+						"type":        "string",
+						"description": "Folder ID to list subfolders of. Mutually exclusive with organization",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := foldersListCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Create folder
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_resourcemanager_folders_create",
+			Description: "Create a folder under an organization or folder",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"display_name"},
+				"properties": map[string]any{
+					"display_name": map[string]any{
+						"type":        "string",
+						"description": "Folder display name, unique among its siblings",
+					},
+					"organization": map[string]any{
+						"type":        "string",
+						"description": "Organization ID to create the folder under. Mutually exclusive with folder",
+					},
+					"folder": map[string]any{
+						"type":        "string",
+						"description": "Parent folder ID. Mutually exclusive with organization",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := folderCreateCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// List organizations
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_resourcemanager_organizations_list",
+			Description: "List the organizations the active account can access",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"filter": map[string]any{
+						"type":        "string",
+						"description": "Filter expression (e.g., 'displayName:example.com')",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd := base.Executor.Command("organizations", "list").
+				WithoutProject().
+				WithFlag("filter", services.GetOptionalString(args, "filter", ""))
+
+			if err := services.ApplyListOutput(cmd, args, csvColumns["organizations"]); err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+}
+
+// parentFlag returns the gcloud flag and ID for the organization or folder
+// named in args. Exactly one of them must be set. IDs may be given with their
+// organizations/ or folders/ prefix.
+func parentFlag(args map[string]any) (string, string, error) {
+	organization := strings.TrimPrefix(services.GetOptionalString(args, "organization", ""), "organizations/")
+	folder := strings.TrimPrefix(services.GetOptionalString(args, "folder", ""), "folders/")
+
+	switch {
+	case organization != "" && folder != "":
+		return "", "", fmt.Errorf("organization and folder are mutually exclusive")
+	case organization != "":
+		return "organization", organization, nil
+	case folder != "":
+		return "folder", folder, nil
+	default:
+		return "", "", fmt.Errorf("one of organization or folder is required")
+	}
+}
+
+// foldersListCommand builds the `resource-manager folders list` command.
+func foldersListCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	flag, parent, err := parentFlag(args)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := base.Executor.Command("resource-manager", "folders", "list").
+		WithoutProject().
+		WithFlag(flag, parent)
+
+	if err := services.ApplyListOutput(cmd, args, csvColumns["folders"]); err != nil {
+		return nil, err
+	}
+	return cmd, nil
+}
+
+// folderCreateCommand builds the `resource-manager folders create` command.
+func folderCreateCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	displayName, err := services.GetRequiredString(args, "display_name")
+	if err != nil {
+		return nil, err
+	}
+	flag, parent, err := parentFlag(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return base.Executor.Command("resource-manager", "folders", "create").
+		WithoutProject().
+		WithFlag("display-name", displayName).
+		WithFlag(flag, parent), nil
+}
@@ -0,0 +1,99 @@
+package projects
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"gcloud-go-mcp/internal/executor/executortest"
+)
+
+func TestFolderCreateCommand_Parent(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		notWant []string
+		wantErr string
+	}{
+		{
+			name:    "organization parent",
+			args:    map[string]any{"display_name": "Engineering", "organization": "123456789"},
+			want:    []string{"--display-name=Engineering", "--organization=123456789"},
+			notWant: []string{"--folder="},
+		},
+		{
+			name:    "folder parent",
+			args:    map[string]any{"display_name": "Team A", "folder": "987654321"},
+			want:    []string{"--display-name=Team A", "--folder=987654321"},
+			notWant: []string{"--organization="},
+		},
+		{
+			name: "prefixed parent",
+			args: map[string]any{"display_name": "Team A", "folder": "folders/987654321"},
+			want: []string{"--folder=987654321"},
+		},
+		{
+			name:    "both parents",
+			args:    map[string]any{"display_name": "Team A", "organization": "123", "folder": "456"},
+			wantErr: "mutually exclusive",
+		},
+		{
+			name:    "no parent",
+			args:    map[string]any{"display_name": "Team A"},
+			wantErr: "one of organization or folder",
+		},
+		{
+			name:    "missing display name",
+			args:    map[string]any{"organization": "123"},
+			wantErr: "display_name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := folderCreateCommand(newTestBase(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if !slices.Equal(args[:3], []string{"resource-manager", "folders", "create"}) {
+				t.Errorf("unexpected command %v", args)
+			}
+			if missing := executortest.Missing(args, tt.want...); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, args)
+			}
+			for _, arg := range args {
+				if strings.HasPrefix(arg, "--project=") {
+					t.Errorf("folders are not project-scoped, got %v", args)
+				}
+				for _, nw := range tt.notWant {
+					if strings.HasPrefix(arg, nw) {
+						t.Errorf("unexpected %q in %v", arg, args)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestFoldersListCommand(t *testing.T) {
+	cmd, err := foldersListCommand(newTestBase(), map[string]any{"organization": "organizations/123"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if missing := executortest.Missing(cmd.Build(), "--organization=123"); len(missing) > 0 {
+		t.Errorf("missing %v in %v", missing, cmd.Build())
+	}
+
+	if _, err := foldersListCommand(newTestBase(), map[string]any{}); err == nil {
+		t.Error("expected error without a parent")
+	}
+}
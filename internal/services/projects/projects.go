@@ -4,7 +4,10 @@ package projects
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strings"
 
+	"gcloud-go-mcp/internal/executor"
 	"gcloud-go-mcp/internal/services"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -12,7 +15,7 @@ import (
 // RegisterTools registers all Projects tools with the MCP server.
 func RegisterTools(server *mcp.Server, base *services.BaseService) {
 	// List projects
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_projects_list",
 			Description: "List all GCP projects accessible by the active account",
@@ -23,6 +26,8 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "string",
 						"description": "Filter expression (e.g., 'name:my-project*' or 'lifecycleState:ACTIVE')",
 					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
 				},
 			},
 		},
@@ -34,16 +39,20 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 				cmd.WithFlag("filter", filter)
 			}
 
+			if err := services.ApplyListOutput(cmd, args, csvColumns["projects"]); err != nil {
+				return services.ToolError(err), nil
+			}
+
 			result, err := cmd.Execute(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Describe project
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_projects_describe",
 			Description: "Get metadata for a project",
@@ -55,6 +64,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "string",
 						"description": "Project ID",
 					},
+					"soft_not_found": services.SoftNotFoundProperty(),
 				},
 			},
 		},
@@ -69,14 +79,14 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 				Execute(ctx)
 
 			if err != nil {
-				return services.ToolError(err), nil
+				return services.DescribeError(args, result, err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Create project
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_projects_create",
 			Description: "Create a new GCP project",
@@ -143,15 +153,16 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Delete project
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_projects_delete",
 			Description: "Delete a project (moves to DELETE_REQUESTED state, can be restored within 30 days)",
+			Annotations: services.Destructive(),
 			InputSchema: map[string]any{
 				"type":     "object",
 				"required": []string{"project_id"},
@@ -182,7 +193,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 	)
 
 	// Update project
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_projects_update",
 			Description: "Update the name of a project",
@@ -219,12 +230,12 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Undelete project
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_projects_undelete",
 			Description: "Restore a project that was marked for deletion",
@@ -257,7 +268,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 	)
 
 	// Get ancestors
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_projects_get_ancestors",
 			Description: "Get the ancestors (folder and organization hierarchy) for a project",
@@ -285,9 +296,61 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Set default project
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_projects_set_default",
+			Description: "Set the default project in the gcloud configuration after checking that it exists",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"project_id"},
+				"properties": map[string]any{
+					"project_id": map[string]any{
+						"type":        "string",
+						"description": "Project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			projectID, err := services.GetRequiredString(args, "project_id")
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			message, err := setDefaultProject(ctx, base, projectID)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return services.ToolResult(message), nil
+		},
+	)
+
+	// Get default project
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_projects_get_default",
+			Description: "Get the default project from the gcloud configuration",
+			InputSchema: map[string]any{
+				"type":       "object",
+				"properties": map[string]any{},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			message, err := getDefaultProject(ctx, base)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return services.ToolResult(message), nil
 		},
 	)
+
+	registerHierarchyTools(server, base)
 }
 
 func parseArgs(req *mcp.CallToolRequest) map[string]any {
@@ -300,3 +363,56 @@ func parseArgs(req *mcp.CallToolRequest) map[string]any {
 	}
 	return args
 }
+
+// csvColumns are the default column projections used when a list tool is
+// called with output=csv, keyed by resource.
+var csvColumns = map[string]string{
+	"projects":      "projectId,name,projectNumber,lifecycleState",
+	"folders":       "name.basename(),displayName,state",
+	"organizations": "name.basename(),displayName,owner.directoryCustomerId",
+}
+
+// setDefaultProject verifies that the project exists and then makes it the
+// gcloud configuration default.
+func setDefaultProject(ctx context.Context, base *services.BaseService, projectID string) (string, error) {
+	if _, err := base.Executor.Command("projects", "describe", projectID).
+		WithoutProject().
+		Execute(ctx); err != nil {
+		return "", fmt.Errorf("project %s not found or not accessible: %w", projectID, err)
+	}
+
+	if _, err := base.Executor.Command("config", "set", "project", projectID).
+		WithoutProject().
+		WithTextFormat().
+		Execute(ctx); err != nil {
+		return "", err
+	}
+	// Later commands pass the resolved project explicitly, so refresh it unless
+	// a per-call configuration, which gcloud reads itself, was changed.
+	if name := executor.ConfigurationFromContext(ctx); name == "" || name == base.Config.Configuration {
+		base.Executor.SetDefaultProject(projectID)
+	}
+
+	message := fmt.Sprintf("Default project set to %s", projectID)
+	if configured := base.Config.Project; configured != "" && configured != projectID {
+		message += fmt.Sprintf("\nNote: GCLOUD_PROJECT=%s is configured for this server and still takes precedence for its commands", configured)
+	}
+	return message, nil
+}
+
+// getDefaultProject reads the default project from the gcloud configuration.
+func getDefaultProject(ctx context.Context, base *services.BaseService) (string, error) {
+	result, err := base.Executor.Command("config", "get-value", "project").
+		WithoutProject().
+		WithTextFormat().
+		Execute(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	project := strings.TrimSpace(result.Stdout)
+	if project == "" || project == "(unset)" {
+		return "No default project is set", nil
+	}
+	return project, nil
+}
@@ -0,0 +1,231 @@
+package projects
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gcloud-go-mcp/internal/config"
+	"gcloud-go-mcp/internal/executor"
+	"gcloud-go-mcp/internal/executor/executortest"
+	"gcloud-go-mcp/internal/services"
+	"gcloud-go-mcp/internal/services/servicetest"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func newTestBase() *services.BaseService {
+	return services.NewBaseService(servicetest.NewConfig())
+}
+
+func TestRegisterTools(t *testing.T) {
+	server := mcp.NewServer(
+		&mcp.Implementation{
+			Name:    "test-server",
+			Version: "0.0.1",
+		},
+		&mcp.ServerOptions{},
+	)
+
+	// Should not panic
+	RegisterTools(server, newTestBase())
+}
+
+// newFakeGCloudBase returns a BaseService whose gcloud binary is a shell
+// script that records its arguments and emulates the config commands. The
+// second return value reads the recorded invocations.
+func newFakeGCloudBase(t *testing.T, project string) (*services.BaseService, func() []string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "calls.log")
+	script := filepath.Join(dir, "gcloud")
+	body := `#!/bin/sh
+echo "$*" >> "` + logPath + `"
+case "$1 $2" in
+"projects describe")
+	if [ "$3" = "missing-project" ]; then
+		echo "ERROR: (gcloud.projects.describe) NOT_FOUND: project missing-project" >&2
+		exit 1
+	fi
+	echo "{\"projectId\": \"$3\"}"
+	;;
+"config set")
+	echo "Updated property [core/project]." >&2
+	;;
+"config get-value")
+	echo "current-project"
+	;;
+esac
+`
+	if err := os.WriteFile(script, []byte(body), 0o755); err != nil {
+		t.Fatalf("failed to write fake gcloud: %v", err)
+	}
+
+	base := services.NewBaseService(&config.Config{
+		Project:        project,
+		GCloudPath:     script,
+		CommandTimeout: 10 * time.Second,
+	})
+	calls := func() []string {
+		data, err := os.ReadFile(logPath)
+		if err != nil {
+			return nil
+		}
+		return strings.Split(strings.TrimSpace(string(data)), "\n")
+	}
+	return base, calls
+}
+
+func TestSetDefaultProject(t *testing.T) {
+	base, calls := newFakeGCloudBase(t, "")
+
+	message, err := setDefaultProject(context.Background(), base, "new-project")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if message != "Default project set to new-project" {
+		t.Errorf("unexpected message %q", message)
+	}
+
+	got := calls()
+	want := []string{
+		"projects describe new-project --format=json",
+		"config set project new-project",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected calls %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("call %d: expected %q, got %q", i, want[i], got[i])
+		}
+	}
+}
+
+func TestSetDefaultProject_UpdatesResolvedDefault(t *testing.T) {
+	base, _ := newFakeGCloudBase(t, "")
+	base.Executor.SetDefaults(executor.Defaults{Project: "old-project", Account: "dev@example.com"})
+
+	if _, err := setDefaultProject(context.Background(), base, "new-project"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	args := base.Executor.Command("compute", "instances", "list").Build()
+	if !slices.Contains(args, "--project=new-project") {
+		t.Errorf("expected later commands to use new-project, got %v", args)
+	}
+	if want := (executor.Defaults{Project: "new-project", Account: "dev@example.com"}); base.Executor.Defaults() != want {
+		t.Errorf("expected defaults %+v, got %+v", want, base.Executor.Defaults())
+	}
+}
+
+func TestSetDefaultProject_ConcurrentDiagnostics(t *testing.T) {
+	base, _ := newFakeGCloudBase(t, "")
+	base.Executor.SetDefaults(executor.Defaults{Project: "old-project"})
+
+	var wg sync.WaitGroup
+	for i := range 4 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if _, err := setDefaultProject(context.Background(), base, fmt.Sprintf("project-%d", i)); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if project := base.Diagnose().Project; project == "" {
+				t.Error("expected a resolved project in diagnostics")
+			}
+			base.Executor.Command("compute", "instances", "list").Build()
+		}()
+	}
+	wg.Wait()
+
+	if project := base.Diagnose().Project; !strings.HasPrefix(project, "project-") {
+		t.Errorf("expected diagnostics to report the new default project, got %q", project)
+	}
+}
+
+func TestSetDefaultProject_NamedConfiguration(t *testing.T) {
+	base, _ := newFakeGCloudBase(t, "")
+	base.Executor.SetDefaults(executor.Defaults{Project: "old-project"})
+
+	ctx := executor.WithConfiguration(context.Background(), "staging")
+	if _, err := setDefaultProject(ctx, base, "new-project"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := base.Executor.Defaults().Project; got != "old-project" {
+		t.Errorf("expected the server's default project to be kept, got %q", got)
+	}
+}
+
+func TestSetDefaultProject_MissingProject(t *testing.T) {
+	base, calls := newFakeGCloudBase(t, "")
+
+	_, err := setDefaultProject(context.Background(), base, "missing-project")
+	if err == nil || !strings.Contains(err.Error(), "project missing-project not found") {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+	for _, call := range calls() {
+		if strings.HasPrefix(call, "config set") {
+			t.Errorf("did not expect config to be changed, got call %q", call)
+		}
+	}
+}
+
+func TestSetDefaultProject_ServerOverrideNote(t *testing.T) {
+	base, _ := newFakeGCloudBase(t, "env-project")
+
+	message, err := setDefaultProject(context.Background(), base, "new-project")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(message, "GCLOUD_PROJECT=env-project") {
+		t.Errorf("expected precedence note, got %q", message)
+	}
+}
+
+func TestGetDefaultProject(t *testing.T) {
+	base, calls := newFakeGCloudBase(t, "env-project")
+
+	project, err := getDefaultProject(context.Background(), base)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if project != "current-project" {
+		t.Errorf("expected current-project, got %q", project)
+	}
+	if got := calls(); len(got) != 1 || got[0] != "config get-value project" {
+		t.Errorf("expected a single get-value call without --project, got %v", got)
+	}
+}
+
+func TestToolCall_Describe(t *testing.T) {
+	runner := &executortest.Runner{}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_projects_describe", map[string]any{
+		"project_id": "other-project",
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+
+	args := runner.LastArgs()
+	if len(args) < 3 || !slices.Equal(args[:3], []string{"projects", "describe", "other-project"}) {
+		t.Errorf("unexpected command %v", args)
+	}
+	if missing := executortest.Missing(args, "--format=json"); len(missing) > 0 {
+		t.Errorf("missing %v in %v", missing, args)
+	}
+}
@@ -5,7 +5,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 
+	"gcloud-go-mcp/internal/executor"
 	"gcloud-go-mcp/internal/services"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -13,7 +17,7 @@ import (
 // RegisterTools registers all Pub/Sub tools with the MCP server.
 func RegisterTools(server *mcp.Server, base *services.BaseService) {
 	// List topics
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_pubsub_topics_list",
 			Description: "List Pub/Sub topics",
@@ -24,25 +28,31 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "string",
 						"description": "GCP project ID",
 					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
 				},
 			},
 		},
 		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			args := parseArgs(req)
 
-			result, err := base.Executor.Command("pubsub", "topics", "list").
-				WithProject(services.GetOptionalString(args, "project", "")).
-				Execute(ctx)
+			cmd := base.Executor.Command("pubsub", "topics", "list").
+				WithProject(services.GetOptionalString(args, "project", ""))
+
+			if err := services.ApplyListOutput(cmd, args, csvColumns["topics"]); err != nil {
+				return services.ToolError(err), nil
+			}
 
+			result, err := cmd.Execute(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Create topic
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_pubsub_topics_create",
 			Description: "Create a Pub/Sub topic",
@@ -62,6 +72,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "object",
 						"description": "Labels for the topic",
 					},
+					"if_not_exists": services.IfNotExistsProperty(),
 				},
 			},
 		},
@@ -72,30 +83,36 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 				return services.ToolError(err), nil
 			}
 
+			existing, err := base.ExistingResource(ctx, args, base.Executor.Command("pubsub", "topics", "describe", topic).
+				WithProject(services.GetOptionalString(args, "project", "")))
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			if existing != nil {
+				return existing, nil
+			}
+
 			cmd := base.Executor.Command("pubsub", "topics", "create", topic).
 				WithProject(services.GetOptionalString(args, "project", ""))
 
-			if labels := services.GetOptionalStringMap(args, "labels"); len(labels) > 0 {
-				var pairs []string
-				for k, v := range labels {
-					pairs = append(pairs, fmt.Sprintf("%s=%s", k, v))
-				}
-				cmd.WithFlag("labels", fmt.Sprintf("%v", pairs))
+			if labels := base.Labels(args); labels != "" {
+				cmd.WithFlag("labels", labels)
 			}
 
 			result, err := cmd.Execute(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Delete topic
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_pubsub_topics_delete",
 			Description: "Delete a Pub/Sub topic",
+			Annotations: services.Destructive(),
 			InputSchema: map[string]any{
 				"type":     "object",
 				"required": []string{"topic"},
@@ -130,14 +147,109 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 		},
 	)
 
+	// Describe topic
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_pubsub_topics_describe",
+			Description: "Get details of a Pub/Sub topic",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"topic"},
+				"properties": map[string]any{
+					"topic": map[string]any{
+						"type":        "string",
+						"description": "Topic name",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"soft_not_found": services.SoftNotFoundProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			topic, err := services.GetRequiredString(args, "topic")
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := base.Executor.Command("pubsub", "topics", "describe", topic).
+				WithProject(services.GetOptionalString(args, "project", "")).
+				Execute(ctx)
+
+			if err != nil {
+				return services.DescribeError(args, result, err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Update topic
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_pubsub_topics_update",
+			Description: "Update message retention and labels of a Pub/Sub topic",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"topic"},
+				"properties": map[string]any{
+					"topic": map[string]any{
+						"type":        "string",
+						"description": "Topic name",
+					},
+					"message_retention_duration": map[string]any{
+						"type":        "string",
+						"description": "How long to retain messages (e.g., 10m, 1d, 7d; max 31d)",
+					},
+					"clear_message_retention_duration": map[string]any{
+						"type":        "boolean",
+						"description": "Disable topic message retention",
+					},
+					"update_labels": map[string]any{
+						"type":        "object",
+						"description": "Labels to add or update",
+					},
+					"remove_labels": map[string]any{
+						"type":        "array",
+						"items":       map[string]any{"type": "string"},
+						"description": "Label keys to remove",
+					},
+					"clear_labels": map[string]any{
+						"type":        "boolean",
+						"description": "Remove all labels (applied before update_labels)",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := topicUpdateCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
 	// Publish message
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_pubsub_topics_publish",
-			Description: "Publish a message to a Pub/Sub topic",
+			Description: "Publish one message, or a batch of messages, to a Pub/Sub topic",
 			InputSchema: map[string]any{
 				"type":     "object",
-				"required": []string{"topic", "message"},
+				"required": []string{"topic"},
 				"properties": map[string]any{
 					"topic": map[string]any{
 						"type":        "string",
@@ -145,11 +257,16 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 					},
 					"message": map[string]any{
 						"type":        "string",
-						"description": "Message to publish",
+						"description": "Message to publish. Mutually exclusive with messages",
+					},
+					"messages": map[string]any{
+						"type":        "array",
+						"description": fmt.Sprintf("Messages to publish, up to %d at a time. The message IDs are returned in the same order", maxBatchMessages),
+						"items":       map[string]any{"type": "string"},
 					},
 					"attributes": map[string]any{
 						"type":        "object",
-						"description": "Message attributes as key-value pairs",
+						"description": "Message attributes as key-value pairs, applied to every message",
 					},
 					"project": map[string]any{
 						"type":        "string",
@@ -164,31 +281,26 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			message, err := services.GetRequiredString(args, "message")
-			if err != nil {
-				return services.ToolError(err), nil
-			}
 
-			cmd := base.Executor.Command("pubsub", "topics", "publish", topic).
-				WithFlag("message", message).
-				WithProject(services.GetOptionalString(args, "project", ""))
-
-			if attrs := services.GetOptionalStringMap(args, "attributes"); len(attrs) > 0 {
-				for k, v := range attrs {
-					cmd.WithArrayFlag("attribute", fmt.Sprintf("%s=%s", k, v))
-				}
+			message := services.GetOptionalString(args, "message", "")
+			messages := services.GetOptionalStringArray(args, "messages")
+			if (message == "") == (len(messages) == 0) {
+				return services.ToolError(fmt.Errorf("exactly one of message or messages is required")), nil
+			}
+			if len(messages) > 0 {
+				return publishBatch(ctx, base, topic, messages, args)
 			}
 
-			result, err := cmd.Execute(ctx)
+			result, err := publishCommand(base, topic, message, args).Execute(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// List subscriptions
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_pubsub_subscriptions_list",
 			Description: "List Pub/Sub subscriptions",
@@ -199,25 +311,31 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "string",
 						"description": "GCP project ID",
 					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
 				},
 			},
 		},
 		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			args := parseArgs(req)
 
-			result, err := base.Executor.Command("pubsub", "subscriptions", "list").
-				WithProject(services.GetOptionalString(args, "project", "")).
-				Execute(ctx)
+			cmd := base.Executor.Command("pubsub", "subscriptions", "list").
+				WithProject(services.GetOptionalString(args, "project", ""))
 
+			if err := services.ApplyListOutput(cmd, args, csvColumns["subscriptions"]); err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Create subscription
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_pubsub_subscriptions_create",
 			Description: "Create a Pub/Sub subscription",
@@ -242,48 +360,119 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "string",
 						"description": "Push endpoint URL (for push subscriptions)",
 					},
+					"dead_letter_topic": map[string]any{
+						"type":        "string",
+						"description": "Topic to forward undeliverable messages to",
+					},
+					"max_delivery_attempts": map[string]any{
+						"type":        "number",
+						"description": "Delivery attempts before dead-lettering (5-100, requires dead_letter_topic)",
+					},
+					"min_retry_delay": map[string]any{
+						"type":        "string",
+						"description": "Minimum backoff before redelivery (e.g., 10s)",
+					},
+					"max_retry_delay": map[string]any{
+						"type":        "string",
+						"description": "Maximum backoff before redelivery (e.g., 600s)",
+					},
 					"project": map[string]any{
 						"type":        "string",
 						"description": "GCP project ID",
 					},
+					"if_not_exists": services.IfNotExistsProperty(),
 				},
 			},
 		},
 		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			args := parseArgs(req)
-			subscription, err := services.GetRequiredString(args, "subscription")
+			cmd, err := subscriptionCreateCommand(base, args)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			topic, err := services.GetRequiredString(args, "topic")
+
+			existing, err := base.ExistingResource(ctx, args, base.Executor.Command("pubsub", "subscriptions", "describe", services.GetOptionalString(args, "subscription", "")).
+				WithProject(services.GetOptionalString(args, "project", "")))
 			if err != nil {
 				return services.ToolError(err), nil
 			}
+			if existing != nil {
+				return existing, nil
+			}
 
-			cmd := base.Executor.Command("pubsub", "subscriptions", "create", subscription).
-				WithFlag("topic", topic).
-				WithProject(services.GetOptionalString(args, "project", ""))
-
-			if ackDeadline := services.GetOptionalInt(args, "ack_deadline", 10); ackDeadline > 0 {
-				cmd.WithFlag("ack-deadline", fmt.Sprintf("%d", ackDeadline))
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
 			}
-			if pushEndpoint := services.GetOptionalString(args, "push_endpoint", ""); pushEndpoint != "" {
-				cmd.WithFlag("push-endpoint", pushEndpoint)
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Update subscription
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_pubsub_subscriptions_update",
+			Description: "Update the configuration of a Pub/Sub subscription",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"subscription"},
+				"properties": map[string]any{
+					"subscription": map[string]any{
+						"type":        "string",
+						"description": "Subscription name",
+					},
+					"ack_deadline": map[string]any{
+						"type":        "number",
+						"description": "Acknowledgement deadline in seconds (10-600)",
+					},
+					"push_endpoint": map[string]any{
+						"type":        "string",
+						"description": "Push endpoint URL (empty string converts to a pull subscription)",
+					},
+					"message_retention_duration": map[string]any{
+						"type":        "string",
+						"description": "How long to retain unacknowledged messages (e.g., 10m, 1d, 7d)",
+					},
+					"dead_letter_topic": map[string]any{
+						"type":        "string",
+						"description": "Topic to forward undeliverable messages to",
+					},
+					"max_delivery_attempts": map[string]any{
+						"type":        "number",
+						"description": "Delivery attempts before dead-lettering (5-100, requires dead_letter_topic)",
+					},
+					"clear_dead_letter_policy": map[string]any{
+						"type":        "boolean",
+						"description": "Remove the dead-letter policy",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := subscriptionUpdateCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
 			}
 
 			result, err := cmd.Execute(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Delete subscription
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_pubsub_subscriptions_delete",
 			Description: "Delete a Pub/Sub subscription",
+			Annotations: services.Destructive(),
 			InputSchema: map[string]any{
 				"type":     "object",
 				"required": []string{"subscription"},
@@ -319,7 +508,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 	)
 
 	// Pull messages
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_pubsub_subscriptions_pull",
 			Description: "Pull messages from a Pub/Sub subscription",
@@ -367,7 +556,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 }
@@ -382,3 +571,281 @@ func parseArgs(req *mcp.CallToolRequest) map[string]any {
 	}
 	return args
 }
+
+// csvColumns are the default column projections used when a list tool is
+// called with output=csv, keyed by resource.
+var csvColumns = map[string]string{
+	"topics":        "name",
+	"subscriptions": "name,topic,ackDeadlineSeconds",
+}
+
+// maxBatchMessages is the most messages gcp_pubsub_topics_publish accepts in
+// one call, and publishConcurrency is how many of them are published at once.
+// gcloud publishes a single message per invocation.
+const (
+	maxBatchMessages   = 100
+	publishConcurrency = 8
+)
+
+// publishCommand builds a pubsub topics publish command for one message.
+func publishCommand(base *services.BaseService, topic, message string, args map[string]any) *executor.CommandBuilder {
+	cmd := base.Executor.Command("pubsub", "topics", "publish", topic).
+		WithFlag("message", message).
+		WithProject(services.GetOptionalString(args, "project", ""))
+
+	for k, v := range services.GetOptionalStringMap(args, "attributes") {
+		cmd.WithArrayFlag("attribute", fmt.Sprintf("%s=%s", k, v))
+	}
+	return cmd
+}
+
+// batchPublishResult is the result of publishing a batch of messages.
+// MessageIDs is in the order of the messages, with an empty ID for each
+// message that failed.
+type batchPublishResult struct {
+	MessageIDs []string         `json:"messageIds"`
+	Failed     []failedDelivery `json:"failed,omitempty"`
+}
+
+// failedDelivery records why the message at Index could not be published.
+type failedDelivery struct {
+	Index int    `json:"index"`
+	Error string `json:"error"`
+}
+
+// publishBatch publishes messages with at most publishConcurrency gcloud
+// processes at a time. The result is an error result if any message failed,
+// but still lists the IDs of the messages that were published. Once ctx is
+// done no more messages are started.
+func publishBatch(ctx context.Context, base *services.BaseService, topic string, messages []string, args map[string]any) (*mcp.CallToolResult, error) {
+	if len(messages) > maxBatchMessages {
+		return services.ToolError(fmt.Errorf("at most %d messages can be published at a time, got %d", maxBatchMessages, len(messages))), nil
+	}
+
+	ids := make([]string, len(messages))
+	errs := make([]error, len(messages))
+	sem := make(chan struct{}, publishConcurrency)
+	var wg sync.WaitGroup
+publish:
+	for i, message := range messages {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			// Messages not started yet are reported as failed, so the
+			// caller knows which to publish again.
+			for j := i; j < len(messages); j++ {
+				errs[j] = fmt.Errorf("not published: %w", ctx.Err())
+			}
+			break publish
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			ids[i], errs[i] = publishOne(ctx, publishCommand(base, topic, message, args))
+		}()
+	}
+	wg.Wait()
+
+	out := batchPublishResult{MessageIDs: ids}
+	for i, err := range errs {
+		if err != nil {
+			out.Failed = append(out.Failed, failedDelivery{Index: i, Error: err.Error()})
+		}
+	}
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return services.ToolError(err), nil
+	}
+	result := services.ToolResult(string(data))
+	result.IsError = len(out.Failed) > 0
+	return result, nil
+}
+
+// publishOne runs a publish command and returns the ID of the published
+// message.
+func publishOne(ctx context.Context, cmd *executor.CommandBuilder) (string, error) {
+	result, err := cmd.Execute(ctx)
+	if err != nil {
+		return "", err
+	}
+	var published struct {
+		MessageIDs []string `json:"messageIds"`
+	}
+	if err := result.ParseJSON(&published); err != nil {
+		return "", fmt.Errorf("failed to parse publish output: %w", err)
+	}
+	if len(published.MessageIDs) == 0 {
+		return "", fmt.Errorf("publish returned no message ID")
+	}
+	return published.MessageIDs[0], nil
+}
+
+// topicUpdateCommand builds the `pubsub topics update` command.
+func topicUpdateCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	topic, err := services.GetRequiredString(args, "topic")
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := base.Executor.Command("pubsub", "topics", "update", topic).
+		WithProject(services.GetOptionalString(args, "project", ""))
+
+	updated := false
+	retention := services.GetOptionalString(args, "message_retention_duration", "")
+	clearRetention := services.GetOptionalBool(args, "clear_message_retention_duration", false)
+	if retention != "" && clearRetention {
+		return nil, fmt.Errorf("message_retention_duration cannot be combined with clear_message_retention_duration")
+	}
+	if retention != "" {
+		cmd.WithFlag("message-retention-duration", retention)
+		updated = true
+	}
+	if clearRetention {
+		cmd.WithBoolFlag("clear-message-retention-duration")
+		updated = true
+	}
+
+	if services.GetOptionalBool(args, "clear_labels", false) {
+		cmd.WithBoolFlag("clear-labels")
+		updated = true
+	}
+	if labels := services.GetOptionalStringMap(args, "update_labels"); len(labels) > 0 {
+		cmd.WithFlag("update-labels", services.FormatLabels(labels))
+		updated = true
+	}
+	if keys := services.GetOptionalStringArray(args, "remove_labels"); len(keys) > 0 {
+		cmd.WithFlag("remove-labels", strings.Join(keys, ","))
+		updated = true
+	}
+
+	if !updated {
+		return nil, fmt.Errorf("no updates specified")
+	}
+	return cmd, nil
+}
+
+// subscriptionCreateCommand builds the `pubsub subscriptions create` command.
+func subscriptionCreateCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	subscription, err := services.GetRequiredString(args, "subscription")
+	if err != nil {
+		return nil, err
+	}
+	topic, err := services.GetRequiredString(args, "topic")
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := base.Executor.Command("pubsub", "subscriptions", "create", subscription).
+		WithFlag("topic", topic).
+		WithProject(services.GetOptionalString(args, "project", ""))
+
+	if ackDeadline := services.GetOptionalInt(args, "ack_deadline", 10); ackDeadline > 0 {
+		cmd.WithFlag("ack-deadline", fmt.Sprintf("%d", ackDeadline))
+	}
+	if pushEndpoint := services.GetOptionalString(args, "push_endpoint", ""); pushEndpoint != "" {
+		cmd.WithFlag("push-endpoint", pushEndpoint)
+	}
+	if _, err := applyDeadLetterPolicy(cmd, args); err != nil {
+		return nil, err
+	}
+	if err := applyRetryPolicy(cmd, args); err != nil {
+		return nil, err
+	}
+	return cmd, nil
+}
+
+// subscriptionUpdateCommand builds the `pubsub subscriptions update` command.
+func subscriptionUpdateCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	subscription, err := services.GetRequiredString(args, "subscription")
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := base.Executor.Command("pubsub", "subscriptions", "update", subscription).
+		WithProject(services.GetOptionalString(args, "project", ""))
+
+	updated := false
+	if ackDeadline := services.GetOptionalInt(args, "ack_deadline", 0); ackDeadline > 0 {
+		cmd.WithFlag("ack-deadline", fmt.Sprintf("%d", ackDeadline))
+		updated = true
+	}
+	if _, ok := args["push_endpoint"]; ok {
+		// An empty endpoint is passed as --push-endpoint= to convert the
+		// subscription to pull.
+		cmd.WithFlagAllowEmpty("push-endpoint", services.GetOptionalString(args, "push_endpoint", ""))
+		updated = true
+	}
+	if retention := services.GetOptionalString(args, "message_retention_duration", ""); retention != "" {
+		cmd.WithFlag("message-retention-duration", retention)
+		updated = true
+	}
+
+	deadLetter, err := applyDeadLetterPolicy(cmd, args)
+	if err != nil {
+		return nil, err
+	}
+	if services.GetOptionalBool(args, "clear_dead_letter_policy", false) {
+		if deadLetter {
+			return nil, fmt.Errorf("clear_dead_letter_policy cannot be combined with dead_letter_topic")
+		}
+		cmd.WithBoolFlag("clear-dead-letter-policy")
+		updated = true
+	}
+
+	if !updated && !deadLetter {
+		return nil, fmt.Errorf("no updates specified")
+	}
+	return cmd, nil
+}
+
+// applyDeadLetterPolicy adds the dead-letter flags to cmd and reports whether
+// a policy was set. Delivery attempts are only meaningful with a dead-letter topic.
+func applyDeadLetterPolicy(cmd *executor.CommandBuilder, args map[string]any) (bool, error) {
+	topic := services.GetOptionalString(args, "dead_letter_topic", "")
+	attempts := services.GetOptionalInt(args, "max_delivery_attempts", 0)
+
+	if topic == "" {
+		if attempts != 0 {
+			return false, fmt.Errorf("max_delivery_attempts requires dead_letter_topic")
+		}
+		return false, nil
+	}
+
+	cmd.WithFlag("dead-letter-topic", topic)
+	if attempts != 0 {
+		if attempts < 5 || attempts > 100 {
+			return false, fmt.Errorf("max_delivery_attempts must be between 5 and 100, got %d", attempts)
+		}
+		cmd.WithFlag("max-delivery-attempts", fmt.Sprintf("%d", attempts))
+	}
+	return true, nil
+}
+
+// applyRetryPolicy adds the exponential backoff retry flags to cmd.
+func applyRetryPolicy(cmd *executor.CommandBuilder, args map[string]any) error {
+	minDelay := services.GetOptionalString(args, "min_retry_delay", "")
+	maxDelay := services.GetOptionalString(args, "max_retry_delay", "")
+
+	var minDur, maxDur time.Duration
+	if minDelay != "" {
+		d, err := time.ParseDuration(minDelay)
+		if err != nil {
+			return fmt.Errorf("invalid min_retry_delay %q: %w", minDelay, err)
+		}
+		minDur = d
+		cmd.WithFlag("min-retry-delay", minDelay)
+	}
+	if maxDelay != "" {
+		d, err := time.ParseDuration(maxDelay)
+		if err != nil {
+			return fmt.Errorf("invalid max_retry_delay %q: %w", maxDelay, err)
+		}
+		maxDur = d
+		cmd.WithFlag("max-retry-delay", maxDelay)
+	}
+	if minDelay != "" && maxDelay != "" && maxDur < minDur {
+		return fmt.Errorf("max_retry_delay must not be less than min_retry_delay")
+	}
+	return nil
+}
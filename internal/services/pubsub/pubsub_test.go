@@ -0,0 +1,619 @@
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"gcloud-go-mcp/internal/executor"
+	"gcloud-go-mcp/internal/executor/executortest"
+	"gcloud-go-mcp/internal/services"
+	"gcloud-go-mcp/internal/services/servicetest"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func newTestBase() *services.BaseService {
+	return services.NewBaseService(servicetest.NewConfig())
+}
+
+func TestRegisterTools(t *testing.T) {
+	server := mcp.NewServer(
+		&mcp.Implementation{
+			Name:    "test-server",
+			Version: "0.0.1",
+		},
+		&mcp.ServerOptions{},
+	)
+
+	// Should not panic
+	RegisterTools(server, newTestBase())
+}
+
+func TestTopicUpdateCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "retention duration",
+			args: map[string]any{"topic": "orders", "message_retention_duration": "7d"},
+			want: []string{"pubsub", "topics", "update", "orders", "--message-retention-duration=7d"},
+		},
+		{
+			name: "clear retention",
+			args: map[string]any{"topic": "orders", "clear_message_retention_duration": true},
+			want: []string{"--clear-message-retention-duration"},
+		},
+		{
+			name: "label updates",
+			args: map[string]any{
+				"topic":         "orders",
+				"update_labels": map[string]any{"team": "data", "env": "prod"},
+				"remove_labels": []any{"owner", "tmp"},
+			},
+			want: []string{"--update-labels=env=prod,team=data", "--remove-labels=owner,tmp"},
+		},
+		{
+			name: "clear labels",
+			args: map[string]any{"topic": "orders", "clear_labels": true},
+			want: []string{"--clear-labels"},
+		},
+		{
+			name:    "conflicting retention options",
+			args:    map[string]any{"topic": "orders", "message_retention_duration": "1d", "clear_message_retention_duration": true},
+			wantErr: "cannot be combined",
+		},
+		{
+			name:    "nothing to update",
+			args:    map[string]any{"topic": "orders"},
+			wantErr: "no updates specified",
+		},
+		{
+			name:    "missing topic",
+			args:    map[string]any{"message_retention_duration": "1d"},
+			wantErr: "missing required parameter: topic",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := topicUpdateCommand(newTestBase(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			args := cmd.Build()
+			for _, w := range tt.want {
+				if !slices.Contains(args, w) {
+					t.Errorf("expected %q in args, got %v", w, args)
+				}
+			}
+		})
+	}
+}
+
+func TestSubscriptionUpdateCommand_DeadLetter(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		notWant []string
+		wantErr string
+	}{
+		{
+			name: "dead-letter topic with attempts",
+			args: map[string]any{
+				"subscription":          "orders-sub",
+				"dead_letter_topic":     "orders-dlq",
+				"max_delivery_attempts": float64(10),
+			},
+			want: []string{"pubsub", "subscriptions", "update", "orders-sub", "--dead-letter-topic=orders-dlq", "--max-delivery-attempts=10"},
+		},
+		{
+			name:    "dead-letter topic only",
+			args:    map[string]any{"subscription": "orders-sub", "dead_letter_topic": "orders-dlq"},
+			want:    []string{"--dead-letter-topic=orders-dlq"},
+			notWant: []string{"--max-delivery-attempts"},
+		},
+		{
+			name: "other settings",
+			args: map[string]any{
+				"subscription":               "orders-sub",
+				"ack_deadline":               float64(60),
+				"push_endpoint":              "https://example.com/push",
+				"message_retention_duration": "3d",
+			},
+			want:    []string{"--ack-deadline=60", "--push-endpoint=https://example.com/push", "--message-retention-duration=3d"},
+			notWant: []string{"--dead-letter-topic"},
+		},
+		{
+			name: "convert to pull",
+			args: map[string]any{"subscription": "orders-sub", "push_endpoint": ""},
+			want: []string{"--push-endpoint="},
+		},
+		{
+			name: "clear dead-letter policy",
+			args: map[string]any{"subscription": "orders-sub", "clear_dead_letter_policy": true},
+			want: []string{"--clear-dead-letter-policy"},
+		},
+		{
+			name:    "attempts without topic",
+			args:    map[string]any{"subscription": "orders-sub", "max_delivery_attempts": float64(10)},
+			wantErr: "max_delivery_attempts requires dead_letter_topic",
+		},
+		{
+			name:    "attempts out of range",
+			args:    map[string]any{"subscription": "orders-sub", "dead_letter_topic": "orders-dlq", "max_delivery_attempts": float64(200)},
+			wantErr: "between 5 and 100",
+		},
+		{
+			name:    "clear conflicts with topic",
+			args:    map[string]any{"subscription": "orders-sub", "dead_letter_topic": "orders-dlq", "clear_dead_letter_policy": true},
+			wantErr: "cannot be combined",
+		},
+		{
+			name:    "nothing to update",
+			args:    map[string]any{"subscription": "orders-sub"},
+			wantErr: "no updates specified",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := subscriptionUpdateCommand(newTestBase(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			args := cmd.Build()
+			for _, w := range tt.want {
+				if !slices.Contains(args, w) {
+					t.Errorf("expected %q in args, got %v", w, args)
+				}
+			}
+			for _, nw := range tt.notWant {
+				for _, arg := range args {
+					if strings.HasPrefix(arg, nw) {
+						t.Errorf("did not expect %q in args, got %v", nw, args)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSubscriptionCreateCommand_Policies(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		notWant []string
+		wantErr string
+	}{
+		{
+			name:    "defaults",
+			args:    map[string]any{"subscription": "orders-sub", "topic": "orders"},
+			want:    []string{"pubsub", "subscriptions", "create", "orders-sub", "--topic=orders", "--ack-deadline=10"},
+			notWant: []string{"--dead-letter-topic", "--max-delivery-attempts", "--min-retry-delay", "--max-retry-delay"},
+		},
+		{
+			name: "dead-letter and retry policy",
+			args: map[string]any{
+				"subscription":          "orders-sub",
+				"topic":                 "orders",
+				"dead_letter_topic":     "orders-dlq",
+				"max_delivery_attempts": float64(5),
+				"min_retry_delay":       "10s",
+				"max_retry_delay":       "600s",
+			},
+			want: []string{
+				"--dead-letter-topic=orders-dlq",
+				"--max-delivery-attempts=5",
+				"--min-retry-delay=10s",
+				"--max-retry-delay=600s",
+			},
+		},
+		{
+			name:    "attempts without dead-letter topic",
+			args:    map[string]any{"subscription": "orders-sub", "topic": "orders", "max_delivery_attempts": float64(5)},
+			wantErr: "max_delivery_attempts requires dead_letter_topic",
+		},
+		{
+			name:    "invalid retry delay",
+			args:    map[string]any{"subscription": "orders-sub", "topic": "orders", "min_retry_delay": "ten seconds"},
+			wantErr: "invalid min_retry_delay",
+		},
+		{
+			name:    "max delay below min delay",
+			args:    map[string]any{"subscription": "orders-sub", "topic": "orders", "min_retry_delay": "60s", "max_retry_delay": "10s"},
+			wantErr: "max_retry_delay must not be less than min_retry_delay",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := subscriptionCreateCommand(newTestBase(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			args := cmd.Build()
+			for _, w := range tt.want {
+				if !slices.Contains(args, w) {
+					t.Errorf("expected %q in args, got %v", w, args)
+				}
+			}
+			for _, nw := range tt.notWant {
+				for _, arg := range args {
+					if strings.HasPrefix(arg, nw) {
+						t.Errorf("did not expect %q in args, got %v", nw, args)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestToolCall_TopicsUpdate(t *testing.T) {
+	runner := &executortest.Runner{}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_pubsub_topics_update", map[string]any{
+		"topic":                      "orders",
+		"message_retention_duration": "7d",
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+
+	args := runner.LastArgs()
+	if len(args) < 4 || !slices.Equal(args[:4], []string{"pubsub", "topics", "update", "orders"}) {
+		t.Errorf("unexpected command %v", args)
+	}
+	if missing := executortest.Missing(args, "--message-retention-duration=7d"); len(missing) > 0 {
+		t.Errorf("missing %v in %v", missing, args)
+	}
+}
+
+func TestToolCall_TopicsDescribeSoftNotFound(t *testing.T) {
+	runner := &executortest.Runner{
+		Stderr: "ERROR: (gcloud.pubsub.topics.describe) NOT_FOUND: Resource not found (resource=orders).",
+		Err:    errors.New("exit status 1"),
+	}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_pubsub_topics_describe", map[string]any{
+		"topic":          "orders",
+		"soft_not_found": true,
+	})
+	if result.IsError {
+		t.Fatalf("expected a soft not-found result, got error: %s", servicetest.Text(result))
+	}
+
+	var got services.NotFound
+	if err := json.Unmarshal([]byte(servicetest.Text(result)), &got); err != nil {
+		t.Fatalf("result is not JSON: %v", err)
+	}
+	if got.Exists {
+		t.Errorf("expected exists=false, got %+v", got)
+	}
+}
+
+func TestToolCall_TopicsDeleteRequiresConfirmation(t *testing.T) {
+	cfg := servicetest.NewConfig()
+	cfg.RequireConfirmation = true
+
+	runner := &executortest.Runner{}
+	result := servicetest.CallToolWithConfig(t, cfg, RegisterTools, runner, "gcp_pubsub_topics_delete", map[string]any{
+		"topic": "orders",
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+	if text := servicetest.Text(result); !strings.Contains(text, "confirm: true") {
+		t.Errorf("expected a confirmation request, got %q", text)
+	}
+	if len(runner.Calls()) != 0 {
+		t.Fatalf("expected no gcloud calls before confirmation, got %v", runner.Calls())
+	}
+
+	result = servicetest.CallToolWithConfig(t, cfg, RegisterTools, runner, "gcp_pubsub_topics_delete", map[string]any{
+		"topic":   "orders",
+		"confirm": true,
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+	if args := runner.LastArgs(); len(args) < 4 || !slices.Equal(args[:4], []string{"pubsub", "topics", "delete", "orders"}) {
+		t.Errorf("unexpected command %v", args)
+	}
+}
+
+func TestToolCall_TopicsDeleteWithoutConfirmationPolicy(t *testing.T) {
+	runner := &executortest.Runner{}
+	servicetest.CallTool(t, RegisterTools, runner, "gcp_pubsub_topics_delete", map[string]any{
+		"topic": "orders",
+	})
+	if len(runner.Calls()) != 1 {
+		t.Errorf("expected the delete to run without the confirmation policy, got %d calls", len(runner.Calls()))
+	}
+}
+
+func TestToolCall_TopicsListConfiguration(t *testing.T) {
+	cfg := servicetest.NewConfig()
+	cfg.Configuration = "dev"
+
+	runner := &executortest.Runner{Stdout: "[]"}
+	servicetest.CallToolWithConfig(t, cfg, RegisterTools, runner, "gcp_pubsub_topics_list", map[string]any{})
+	if !slices.Contains(runner.LastArgs(), "--configuration=dev") {
+		t.Errorf("expected configured --configuration=dev, got %v", runner.LastArgs())
+	}
+
+	servicetest.CallToolWithConfig(t, cfg, RegisterTools, runner, "gcp_pubsub_topics_list", map[string]any{
+		"configuration": "prod",
+	})
+	if args := runner.LastArgs(); !slices.Contains(args, "--configuration=prod") || slices.Contains(args, "--configuration=dev") {
+		t.Errorf("expected per-call --configuration=prod to override, got %v", args)
+	}
+}
+
+func TestToolCall_ErrorIncludesCommand(t *testing.T) {
+	runner := &executortest.Runner{
+		Stderr: "ERROR: (gcloud.pubsub.topics.create) PERMISSION_DENIED: User not authorized to perform this action.",
+		Err:    errors.New("exit status 1"),
+	}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_pubsub_topics_create", map[string]any{
+		"topic": "orders",
+	})
+	if !result.IsError {
+		t.Fatal("expected an error result")
+	}
+
+	var parsed executor.ErrorResponse
+	if err := json.Unmarshal([]byte(servicetest.Text(result)), &parsed); err != nil {
+		t.Fatalf("expected a JSON error, got %q", servicetest.Text(result))
+	}
+	if !strings.HasPrefix(parsed.Command, "gcloud pubsub topics create orders ") || !strings.Contains(parsed.Command, "--project=test-project") {
+		t.Errorf("expected the gcloud command line, got %q", parsed.Command)
+	}
+	if !strings.Contains(parsed.Error, "PERMISSION_DENIED") {
+		t.Errorf("expected the gcloud error, got %q", parsed.Error)
+	}
+}
+
+func TestToolCall_TopicsListAccount(t *testing.T) {
+	cfg := servicetest.NewConfig()
+	cfg.Account = "ops@example.com"
+
+	runner := &executortest.Runner{Stdout: "[]"}
+	servicetest.CallToolWithConfig(t, cfg, RegisterTools, runner, "gcp_pubsub_topics_list", map[string]any{})
+	if !slices.Contains(runner.LastArgs(), "--account=ops@example.com") {
+		t.Errorf("expected configured --account=ops@example.com, got %v", runner.LastArgs())
+	}
+
+	servicetest.CallToolWithConfig(t, cfg, RegisterTools, runner, "gcp_pubsub_topics_list", map[string]any{
+		"gcloud_account": "deploy@example.com",
+	})
+	if args := runner.LastArgs(); !slices.Contains(args, "--account=deploy@example.com") || slices.Contains(args, "--account=ops@example.com") {
+		t.Errorf("expected per-call --account=deploy@example.com to override, got %v", args)
+	}
+}
+
+func TestToolCall_TopicsCreateIfNotExists(t *testing.T) {
+	t.Run("exists", func(t *testing.T) {
+		runner := &executortest.Runner{Stdout: `{"name":"projects/test-project/topics/orders"}`}
+		result := servicetest.CallTool(t, RegisterTools, runner, "gcp_pubsub_topics_create", map[string]any{
+			"topic":         "orders",
+			"if_not_exists": true,
+		})
+		if result.IsError {
+			t.Fatalf("unexpected error: %s", servicetest.Text(result))
+		}
+		if calls := runner.Calls(); len(calls) != 1 || !slices.Equal(calls[0][:4], []string{"pubsub", "topics", "describe", "orders"}) {
+			t.Errorf("expected only a describe call, got %v", calls)
+		}
+		if text := servicetest.Text(result); !strings.Contains(text, `"created": false`) {
+			t.Errorf("expected the existing topic, got %q", text)
+		}
+	})
+
+	t.Run("does not exist", func(t *testing.T) {
+		runner := &executortest.Runner{Handler: func(args []string) (*executor.Result, error) {
+			if args[2] == "describe" {
+				return &executor.Result{Stderr: "NOT_FOUND: Resource not found"}, errors.New("exit status 1")
+			}
+			return &executor.Result{Stdout: `{"name":"projects/test-project/topics/orders"}`}, nil
+		}}
+		result := servicetest.CallTool(t, RegisterTools, runner, "gcp_pubsub_topics_create", map[string]any{
+			"topic":         "orders",
+			"if_not_exists": true,
+		})
+		if result.IsError {
+			t.Fatalf("unexpected error: %s", servicetest.Text(result))
+		}
+		calls := runner.Calls()
+		if len(calls) != 2 || !slices.Equal(calls[1][:4], []string{"pubsub", "topics", "create", "orders"}) {
+			t.Errorf("expected describe then create, got %v", calls)
+		}
+	})
+}
+
+func TestToolCall_Capabilities(t *testing.T) {
+	register := func(server *mcp.Server, base *services.BaseService) {
+		RegisterTools(server, base)
+		services.RegisterCapabilitiesTool(server, base)
+	}
+	runner := &executortest.Runner{}
+	result := servicetest.CallTool(t, register, runner, "gcp_capabilities", map[string]any{"service": "pubsub"})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+	if len(runner.Calls()) != 0 {
+		t.Errorf("expected no gcloud calls, got %v", runner.Calls())
+	}
+
+	var groups map[string][]services.Capability
+	if err := json.Unmarshal([]byte(servicetest.Text(result)), &groups); err != nil {
+		t.Fatalf("failed to parse capabilities: %v", err)
+	}
+	tools := make(map[string]services.Capability)
+	for _, tool := range groups["pubsub"] {
+		tools[tool.Name] = tool
+	}
+	for _, name := range []string{"gcp_pubsub_topics_list", "gcp_pubsub_topics_create", "gcp_pubsub_topics_publish"} {
+		if _, ok := tools[name]; !ok {
+			t.Errorf("expected %s in capabilities, got %v", name, groups)
+		}
+	}
+	if create := tools["gcp_pubsub_topics_create"]; !slices.Contains(create.Required, "topic") {
+		t.Errorf("expected topic to be required by gcp_pubsub_topics_create, got %+v", create)
+	}
+}
+
+// publishHandler answers each publish call with a message ID derived from
+// the message, failing for the message "bad".
+func publishHandler(args []string) (*executor.Result, error) {
+	for _, arg := range args {
+		if message, ok := strings.CutPrefix(arg, "--message="); ok {
+			if message == "bad" {
+				return &executor.Result{Stderr: "INVALID_ARGUMENT"}, errors.New("exit status 1")
+			}
+			return &executor.Result{Stdout: `{"messageIds": ["id-` + message + `"]}`}, nil
+		}
+	}
+	return nil, errors.New("no message")
+}
+
+func TestToolCall_TopicsPublishBatch(t *testing.T) {
+	messages := make([]any, 20)
+	want := make([]string, len(messages))
+	for i := range messages {
+		messages[i] = fmt.Sprintf("m%d", i)
+		want[i] = fmt.Sprintf("id-m%d", i)
+	}
+
+	runner := &executortest.Runner{Handler: publishHandler}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_pubsub_topics_publish", map[string]any{
+		"topic":      "orders",
+		"messages":   messages,
+		"attributes": map[string]any{"source": "test"},
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+
+	calls := runner.Calls()
+	if len(calls) != len(messages) {
+		t.Fatalf("expected %d publish calls, got %d", len(messages), len(calls))
+	}
+	for _, call := range calls {
+		if !slices.Equal(call[:4], []string{"pubsub", "topics", "publish", "orders"}) || !slices.Contains(call, "--attribute=source=test") {
+			t.Errorf("unexpected call %v", call)
+		}
+	}
+
+	var out batchPublishResult
+	if err := json.Unmarshal([]byte(servicetest.Text(result)), &out); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+	if !slices.Equal(out.MessageIDs, want) {
+		t.Errorf("expected message IDs %v in order, got %v", want, out.MessageIDs)
+	}
+}
+
+func TestToolCall_TopicsPublishBatchPartialFailure(t *testing.T) {
+	runner := &executortest.Runner{Handler: publishHandler}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_pubsub_topics_publish", map[string]any{
+		"topic":    "orders",
+		"messages": []any{"a", "bad", "c"},
+	})
+	if !result.IsError {
+		t.Fatal("expected an error result")
+	}
+
+	var out batchPublishResult
+	if err := json.Unmarshal([]byte(servicetest.Text(result)), &out); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+	if !slices.Equal(out.MessageIDs, []string{"id-a", "", "id-c"}) {
+		t.Errorf("unexpected message IDs %v", out.MessageIDs)
+	}
+	if len(out.Failed) != 1 || out.Failed[0].Index != 1 {
+		t.Errorf("expected message 1 to fail, got %+v", out.Failed)
+	}
+}
+
+func TestPublishBatch_StopsOnCancel(t *testing.T) {
+	started := make(chan struct{}, publishConcurrency)
+	release := make(chan struct{})
+	runner := &executortest.Runner{Handler: func(args []string) (*executor.Result, error) {
+		started <- struct{}{}
+		<-release
+		return publishHandler(args)
+	}}
+	base := services.NewBaseServiceWithRunner(servicetest.NewConfig(), runner)
+
+	messages := make([]string, 3*publishConcurrency)
+	for i := range messages {
+		messages[i] = fmt.Sprintf("m%d", i)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan *mcp.CallToolResult)
+	go func() {
+		result, _ := publishBatch(ctx, base, "orders", messages, map[string]any{})
+		done <- result
+	}()
+	for range publishConcurrency {
+		<-started
+	}
+	cancel()
+	close(release)
+	result := <-done
+
+	if calls := len(runner.Calls()); calls != publishConcurrency {
+		t.Errorf("expected %d publish calls, got %d", publishConcurrency, calls)
+	}
+	var out batchPublishResult
+	if err := json.Unmarshal([]byte(servicetest.Text(result)), &out); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+	if !result.IsError || len(out.Failed) != len(messages)-publishConcurrency {
+		t.Fatalf("expected %d unpublished messages, got %+v", len(messages)-publishConcurrency, out.Failed)
+	}
+	if failed := out.Failed[0]; failed.Index != publishConcurrency || !strings.Contains(failed.Error, "not published") {
+		t.Errorf("unexpected first failure %+v", failed)
+	}
+}
+
+func TestToolCall_TopicsPublishMessageAndMessages(t *testing.T) {
+	runner := &executortest.Runner{}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_pubsub_topics_publish", map[string]any{
+		"topic":    "orders",
+		"message":  "a",
+		"messages": []any{"b"},
+	})
+	if !result.IsError || !strings.Contains(servicetest.Text(result), "exactly one") {
+		t.Errorf("expected exactly one error, got %q", servicetest.Text(result))
+	}
+	if len(runner.Calls()) != 0 {
+		t.Errorf("expected no gcloud calls, got %v", runner.Calls())
+	}
+}
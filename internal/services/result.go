@@ -0,0 +1,71 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+
+	"gcloud-go-mcp/internal/executor"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ResultEnvelope wraps command output with metadata about the gcloud
+// invocation that produced it.
+type ResultEnvelope struct {
+	Command    []string `json:"command"`
+	Project    string   `json:"project,omitempty"`
+	Region     string   `json:"region,omitempty"`
+	DurationMS int64    `json:"duration_ms"`
+	Result     any      `json:"result"`
+}
+
+// CommandResult creates a successful tool result from command output. When
+// metadata is enabled the output is wrapped in a ResultEnvelope. Output longer
+// than Config.MaxOutputBytes is truncated.
+func (b *BaseService) CommandResult(result *executor.Result) *mcp.CallToolResult {
+	return b.TextResult(b.commandText(result))
+}
+
+// TextResult creates a successful tool result from text a tool built itself,
+// such as reshaped command output, truncated like CommandResult.
+func (b *BaseService) TextResult(text string) *mcp.CallToolResult {
+	return ToolResult(truncateOutput(text, b.Config.MaxOutputBytes))
+}
+
+// commandText formats command output as the text of a tool result.
+func (b *BaseService) commandText(result *executor.Result) string {
+	if !b.Config.IncludeMetadata {
+		return result.ToJSONString()
+	}
+
+	envelope := ResultEnvelope{
+		Command:    result.Command,
+		Project:    result.Project,
+		Region:     result.Region,
+		DurationMS: result.Duration.Milliseconds(),
+		Result:     result.AsStructured(),
+	}
+
+	data, err := json.MarshalIndent(envelope, "", "  ")
+	if err != nil {
+		return result.ToJSONString()
+	}
+	return string(data)
+}
+
+// truncateOutput cuts text to at most limit bytes, on a UTF-8 boundary, and
+// appends a notice with the original size. A limit of zero disables
+// truncation.
+func truncateOutput(text string, limit int) string {
+	if limit <= 0 || len(text) <= limit {
+		return text
+	}
+
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return text[:cut] + fmt.Sprintf(
+		"\n\n[Output truncated: showing %d of %d bytes. Narrow the result with filter, limit, or a format projection, or raise GCLOUD_MAX_OUTPUT_BYTES.]",
+		cut, len(text))
+}
@@ -0,0 +1,194 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"gcloud-go-mcp/internal/config"
+	"gcloud-go-mcp/internal/executor"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func resultText(t *testing.T, result *mcp.CallToolResult) string {
+	t.Helper()
+	if len(result.Content) != 1 {
+		t.Fatalf("expected 1 content item, got %d", len(result.Content))
+	}
+	text, ok := result.Content[0].(*mcp.TextContent)
+	if !ok {
+		t.Fatalf("expected text content, got %T", result.Content[0])
+	}
+	return text.Text
+}
+
+func TestCommandResult_WithoutMetadata(t *testing.T) {
+	base := NewBaseService(&config.Config{GCloudPath: "gcloud", CommandTimeout: time.Minute})
+
+	got := resultText(t, base.CommandResult(&executor.Result{
+		JSON:    json.RawMessage(`{"name":"svc"}`),
+		Command: []string{"gcloud", "run", "services", "describe", "svc"},
+	}))
+
+	if got != "{\n  \"name\": \"svc\"\n}" {
+		t.Errorf("expected raw JSON output, got %q", got)
+	}
+}
+
+func TestCommandResult_WithMetadata(t *testing.T) {
+	base := NewBaseService(&config.Config{
+		GCloudPath:      "gcloud",
+		CommandTimeout:  time.Minute,
+		IncludeMetadata: true,
+	})
+	argv := []string{"gcloud", "run", "services", "describe", "svc", "--region=us-central1", "--project=p1", "--format=json"}
+
+	got := resultText(t, base.CommandResult(&executor.Result{
+		JSON:     json.RawMessage(`{"name":"svc"}`),
+		Stdout:   `{"name":"svc"}`,
+		Command:  argv,
+		Project:  "p1",
+		Region:   "us-central1",
+		Duration: 1500 * time.Millisecond,
+	}))
+
+	var envelope struct {
+		Command    []string       `json:"command"`
+		Project    string         `json:"project"`
+		Region     string         `json:"region"`
+		DurationMS int64          `json:"duration_ms"`
+		Result     map[string]any `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(got), &envelope); err != nil {
+		t.Fatalf("envelope is not valid JSON: %v\n%s", err, got)
+	}
+	if !slices.Equal(envelope.Command, argv) {
+		t.Errorf("expected command %v, got %v", argv, envelope.Command)
+	}
+	if envelope.DurationMS != 1500 {
+		t.Errorf("expected duration_ms 1500, got %d", envelope.DurationMS)
+	}
+	if envelope.Project != "p1" || envelope.Region != "us-central1" {
+		t.Errorf("expected project/region p1/us-central1, got %s/%s", envelope.Project, envelope.Region)
+	}
+	if envelope.Result["name"] != "svc" {
+		t.Errorf("expected embedded JSON result, got %v", envelope.Result)
+	}
+}
+
+func TestCommandResult_WithMetadataTextOutput(t *testing.T) {
+	base := NewBaseService(&config.Config{
+		GCloudPath:      "gcloud",
+		CommandTimeout:  time.Minute,
+		IncludeMetadata: true,
+	})
+
+	got := resultText(t, base.CommandResult(&executor.Result{
+		Stdout:  "hello world\n",
+		Command: []string{"gcloud", "storage", "cat", "gs://b/o"},
+	}))
+
+	var envelope map[string]any
+	if err := json.Unmarshal([]byte(got), &envelope); err != nil {
+		t.Fatalf("envelope is not valid JSON: %v", err)
+	}
+	if envelope["result"] != "hello world\n" {
+		t.Errorf("expected text result, got %v", envelope["result"])
+	}
+}
+
+func TestCommandResult_WithMetadataTextOutputThatIsJSON(t *testing.T) {
+	base := NewBaseService(&config.Config{
+		GCloudPath:      "gcloud",
+		CommandTimeout:  time.Minute,
+		IncludeMetadata: true,
+	})
+
+	got := resultText(t, base.CommandResult(&executor.Result{
+		Stdout:  `{"config": {"enabled": true}}`,
+		Command: []string{"gcloud", "storage", "cat", "gs://b/config.json"},
+	}))
+
+	var envelope struct {
+		Result map[string]any `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(got), &envelope); err != nil {
+		t.Fatalf("expected structured result, got %v\n%s", err, got)
+	}
+	if _, ok := envelope.Result["config"]; !ok {
+		t.Errorf("expected parsed JSON in envelope, got %v", envelope.Result)
+	}
+}
+
+func TestTruncateOutput(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		limit     int
+		want      string
+		truncated bool
+	}{
+		{name: "under limit", text: "abcd", limit: 5, want: "abcd"},
+		{name: "exactly at limit", text: "abcde", limit: 5, want: "abcde"},
+		{name: "one byte over", text: "abcdef", limit: 5, want: "abcde", truncated: true},
+		{name: "limit disabled", text: "abcdef", limit: 0, want: "abcdef"},
+		// "é" is two bytes; cutting at 4 would split it
+		{name: "multibyte boundary", text: "abcéf", limit: 4, want: "abc", truncated: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncateOutput(tt.text, tt.limit)
+			if !tt.truncated {
+				if got != tt.want {
+					t.Errorf("expected %q, got %q", tt.want, got)
+				}
+				return
+			}
+
+			prefix, notice, ok := strings.Cut(got, "\n\n[Output truncated:")
+			if !ok {
+				t.Fatalf("expected truncation notice, got %q", got)
+			}
+			if prefix != tt.want {
+				t.Errorf("expected kept output %q, got %q", tt.want, prefix)
+			}
+			if !strings.Contains(notice, fmt.Sprintf("of %d bytes", len(tt.text))) {
+				t.Errorf("expected original size in notice, got %q", notice)
+			}
+		})
+	}
+}
+
+func TestCommandResult_Truncated(t *testing.T) {
+	base := NewBaseService(&config.Config{
+		GCloudPath:     "gcloud",
+		CommandTimeout: time.Minute,
+		MaxOutputBytes: 64,
+	})
+
+	got := resultText(t, base.CommandResult(&executor.Result{
+		Stdout: strings.Repeat("x", 200),
+	}))
+
+	if !strings.HasPrefix(got, strings.Repeat("x", 64)+"\n\n[Output truncated: showing 64 of 200 bytes.") {
+		t.Errorf("unexpected truncated output %q", got)
+	}
+}
+
+func TestTextResult_Truncated(t *testing.T) {
+	base := NewBaseService(&config.Config{
+		GCloudPath:     "gcloud",
+		CommandTimeout: time.Minute,
+		MaxOutputBytes: 64,
+	})
+
+	got := resultText(t, base.TextResult(strings.Repeat("x", 200)))
+
+	if !strings.HasPrefix(got, strings.Repeat("x", 64)+"\n\n[Output truncated: showing 64 of 200 bytes.") {
+		t.Errorf("unexpected truncated output %q", got)
+	}
+}
@@ -4,9 +4,12 @@ package run
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
 	"strings"
 
+	"gcloud-go-mcp/internal/executor"
 	"gcloud-go-mcp/internal/services"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -14,7 +17,7 @@ import (
 // RegisterTools registers all Cloud Run tools with the MCP server.
 func RegisterTools(server *mcp.Server, base *services.BaseService) {
 	// List services
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_run_services_list",
 			Description: "List Cloud Run services in a project",
@@ -34,30 +37,36 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"description": "Maximum number of services to return",
 						"default":     100,
 					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
 				},
 			},
 		},
 		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			args := parseArgs(req)
 			project := services.GetOptionalString(args, "project", "")
-			region := services.GetOptionalString(args, "region", "")
+			region := serviceRegion(base, args)
 			limit := services.GetOptionalInt(args, "limit", 100)
 
-			result, err := base.Executor.Command("run", "services", "list").
+			cmd := base.Executor.Command("run", "services", "list").
 				WithProject(project).
 				WithRegion(region).
-				WithFlag("limit", fmt.Sprintf("%d", limit)).
-				ExecuteWithRegion(ctx)
+				WithFlag("limit", fmt.Sprintf("%d", limit))
+
+			if err := services.ApplyListOutput(cmd, args, csvColumns["services"]); err != nil {
+				return services.ToolError(err), nil
+			}
 
+			result, err := cmd.ExecuteWithRegion(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Describe service
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_run_services_describe",
 			Description: "Get detailed information about a Cloud Run service",
@@ -77,6 +86,12 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "string",
 						"description": "Region of the service",
 					},
+					"summary": map[string]any{
+						"type":        "boolean",
+						"description": "Return only the URL, latest ready revision, last modifier and traffic split",
+						"default":     false,
+					},
+					"soft_not_found": services.SoftNotFoundProperty(),
 				},
 			},
 		},
@@ -89,24 +104,32 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 
 			result, err := base.Executor.Command("run", "services", "describe", service).
 				WithProject(services.GetOptionalString(args, "project", "")).
-				WithRegion(services.GetOptionalString(args, "region", "")).
+				WithRegion(serviceRegion(base, args)).
 				ExecuteWithRegion(ctx)
 
 			if err != nil {
-				return services.ToolError(err), nil
+				return services.DescribeError(args, result, err), nil
+			}
+			if services.GetOptionalBool(args, "summary", false) {
+				summary, err := summarizeService(result)
+				if err != nil {
+					return services.ToolError(err), nil
+				}
+				data, _ := json.MarshalIndent(summary, "", "  ")
+				return services.ToolResult(string(data)), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Deploy service
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_run_services_deploy",
-			Description: "Deploy a container image to Cloud Run",
+			Description: "Deploy a container image to Cloud Run, or build and deploy from source with Cloud Build",
 			InputSchema: map[string]any{
 				"type":     "object",
-				"required": []string{"service", "image"},
+				"required": []string{"service"},
 				"properties": map[string]any{
 					"service": map[string]any{
 						"type":        "string",
@@ -114,7 +137,11 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 					},
 					"image": map[string]any{
 						"type":        "string",
-						"description": "Container image to deploy (e.g., gcr.io/project/image:tag)",
+						"description": "Container image to deploy (e.g., gcr.io/project/image:tag). Exactly one of image or source is required",
+					},
+					"source": map[string]any{
+						"type":        "string",
+						"description": "Local source directory to build with Cloud Build (using a Dockerfile or buildpacks) and deploy. Exactly one of image or source is required",
 					},
 					"project": map[string]any{
 						"type":        "string",
@@ -137,6 +164,23 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "string",
 						"description": "CPU limit (e.g., 1, 2)",
 					},
+					"cpu_boost": map[string]any{
+						"type":        "boolean",
+						"description": "Allocate extra CPU while instances start up (false turns it off)",
+					},
+					"no_cpu_throttling": map[string]any{
+						"type":        "boolean",
+						"description": "Keep CPU allocated outside of requests (instance-based billing), e.g. for background work. false restores request-based CPU allocation",
+					},
+					"execution_environment": map[string]any{
+						"type":        "string",
+						"description": "Execution environment of the container",
+						"enum":        []string{"gen1", "gen2"},
+					},
+					"timeout": map[string]any{
+						"type":        "string",
+						"description": "Request timeout as seconds or a duration (e.g., 300, 15m). At most 60m",
+					},
 					"min_instances": map[string]any{
 						"type":        "number",
 						"description": "Minimum number of instances",
@@ -153,6 +197,45 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "object",
 						"description": "Environment variables as key-value pairs",
 					},
+					"env_vars_file": map[string]any{
+						"type":        "string",
+						"description": "Path to a YAML file of environment variables (KEY: value), replacing all existing ones. Mutually exclusive with env_vars",
+					},
+					"set_secrets": map[string]any{
+						"type":        "object",
+						"description": "Secrets exposed as environment variables, mapping the variable name to SECRET or SECRET:VERSION (latest if omitted). Replaces all existing secrets",
+					},
+					"secret_volumes": map[string]any{
+						"type":        "object",
+						"description": "Secrets mounted as files, mapping an absolute mount path to SECRET or SECRET:VERSION (latest if omitted). Replaces all existing secrets",
+					},
+					"remove_secrets": map[string]any{
+						"type":        "array",
+						"description": "Secret environment variables or mount paths to remove. Mutually exclusive with set_secrets and secret_volumes",
+						"items":       map[string]any{"type": "string"},
+					},
+					"add_cloudsql_instances": map[string]any{
+						"type":        "array",
+						"description": "Cloud SQL instance connection names to connect to (PROJECT:REGION:INSTANCE)",
+						"items":       map[string]any{"type": "string"},
+					},
+					"vpc_connector": map[string]any{
+						"type":        "string",
+						"description": "Serverless VPC Access connector to reach private resources through. Mutually exclusive with network and subnet",
+					},
+					"network": map[string]any{
+						"type":        "string",
+						"description": "VPC network to send traffic to directly (Direct VPC egress)",
+					},
+					"subnet": map[string]any{
+						"type":        "string",
+						"description": "Subnet to send traffic to directly (Direct VPC egress)",
+					},
+					"vpc_egress": map[string]any{
+						"type":        "string",
+						"description": "Which outbound traffic goes through the VPC. Requires vpc_connector, network or subnet",
+						"enum":        []string{"all-traffic", "private-ranges-only"},
+					},
 					"allow_unauthenticated": map[string]any{
 						"type":        "boolean",
 						"description": "Allow unauthenticated access",
@@ -163,65 +246,82 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 		},
 		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			args := parseArgs(req)
-			service, err := services.GetRequiredString(args, "service")
+			cmd, err := deployCommand(base, args)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			image, err := services.GetRequiredString(args, "image")
+
+			result, err := cmd.ExecuteWithRegion(ctx)
 			if err != nil {
-				return services.ToolError(err), nil
+				return services.DeployError(result, err), nil
 			}
+			return base.CommandResult(result), nil
+		},
+	)
 
-			cmd := base.Executor.Command("run", "deploy", service).
-				WithFlag("image", image).
-				WithProject(services.GetOptionalString(args, "project", "")).
-				WithRegion(services.GetOptionalString(args, "region", ""))
-
-			if port := services.GetOptionalString(args, "port", ""); port != "" {
-				cmd.WithFlag("port", port)
-			}
-			if memory := services.GetOptionalString(args, "memory", ""); memory != "" {
-				cmd.WithFlag("memory", memory)
-			}
-			if cpu := services.GetOptionalString(args, "cpu", ""); cpu != "" {
-				cmd.WithFlag("cpu", cpu)
-			}
-			if minInstances := services.GetOptionalInt(args, "min_instances", -1); minInstances >= 0 {
-				cmd.WithFlag("min-instances", fmt.Sprintf("%d", minInstances))
-			}
-			if maxInstances := services.GetOptionalInt(args, "max_instances", -1); maxInstances >= 0 {
-				cmd.WithFlag("max-instances", fmt.Sprintf("%d", maxInstances))
-			}
-			if sa := services.GetOptionalString(args, "service_account", ""); sa != "" {
-				cmd.WithFlag("service-account", sa)
+	// Set environment variables from YAML
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_run_services_set_env_file",
+			Description: "Replace all environment variables of a Cloud Run service with the ones in inline YAML content, deploying a new revision",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"service", "env_yaml"},
+				"properties": map[string]any{
+					"service": map[string]any{
+						"type":        "string",
+						"description": "Name of the service",
+					},
+					"env_yaml": map[string]any{
+						"type":        "string",
+						"description": "Environment variables as YAML mapping (e.g., \"KEY: value\\nOTHER: value\")",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"region": map[string]any{
+						"type":        "string",
+						"description": "Region of the service",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			service, err := services.GetRequiredString(args, "service")
+			if err != nil {
+				return services.ToolError(err), nil
 			}
-
-			// Handle environment variables
-			if envVars := services.GetOptionalStringMap(args, "env_vars"); len(envVars) > 0 {
-				var pairs []string
-				for k, v := range envVars {
-					pairs = append(pairs, fmt.Sprintf("%s=%s", k, v))
-				}
-				cmd.WithFlag("set-env-vars", strings.Join(pairs, ","))
+			envYAML, err := services.GetRequiredString(args, "env_yaml")
+			if err != nil {
+				return services.ToolError(err), nil
 			}
 
-			if services.GetOptionalBool(args, "allow_unauthenticated", false) {
-				cmd.WithBoolFlag("allow-unauthenticated")
+			path, cleanup, err := writeEnvFile(envYAML)
+			if err != nil {
+				return services.ToolError(err), nil
 			}
+			defer cleanup()
 
-			result, err := cmd.ExecuteWithRegion(ctx)
+			result, err := base.Executor.Command("run", "services", "update", service).
+				WithFlag("env-vars-file", path).
+				WithProject(services.GetOptionalString(args, "project", "")).
+				WithRegion(serviceRegion(base, args)).
+				ExecuteWithRegion(ctx)
 			if err != nil {
-				return services.ToolError(err), nil
+				return services.DeployError(result, err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Delete service
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_run_services_delete",
 			Description: "Delete a Cloud Run service",
+			Annotations: services.Destructive(),
 			InputSchema: map[string]any{
 				"type":     "object",
 				"required": []string{"service"},
@@ -250,19 +350,19 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 
 			result, err := base.Executor.Command("run", "services", "delete", service).
 				WithProject(services.GetOptionalString(args, "project", "")).
-				WithRegion(services.GetOptionalString(args, "region", "")).
+				WithRegion(serviceRegion(base, args)).
 				WithBoolFlag("quiet").
 				ExecuteWithRegion(ctx)
 
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Update traffic
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_run_services_update_traffic",
 			Description: "Update traffic allocation for a Cloud Run service",
@@ -303,7 +403,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 
 			cmd := base.Executor.Command("run", "services", "update-traffic", service).
 				WithProject(services.GetOptionalString(args, "project", "")).
-				WithRegion(services.GetOptionalString(args, "region", ""))
+				WithRegion(serviceRegion(base, args))
 
 			if services.GetOptionalBool(args, "to_latest", false) {
 				cmd.WithBoolFlag("to-latest")
@@ -315,12 +415,12 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Get IAM policy
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_run_services_get_iam_policy",
 			Description: "Get IAM policy for a Cloud Run service",
@@ -352,18 +452,18 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 
 			result, err := base.Executor.Command("run", "services", "get-iam-policy", service).
 				WithProject(services.GetOptionalString(args, "project", "")).
-				WithRegion(services.GetOptionalString(args, "region", "")).
+				WithRegion(serviceRegion(base, args)).
 				ExecuteWithRegion(ctx)
 
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Add IAM policy binding
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_run_services_add_iam_policy_binding",
 			Description: "Add IAM policy binding to a Cloud Run service",
@@ -413,18 +513,18 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 				WithFlag("member", member).
 				WithFlag("role", role).
 				WithProject(services.GetOptionalString(args, "project", "")).
-				WithRegion(services.GetOptionalString(args, "region", "")).
+				WithRegion(serviceRegion(base, args)).
 				ExecuteWithRegion(ctx)
 
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// List revisions
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_run_revisions_list",
 			Description: "List revisions for a Cloud Run service",
@@ -444,6 +544,8 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "string",
 						"description": "Region of the service",
 					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
 				},
 			},
 		},
@@ -454,21 +556,114 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 				return services.ToolError(err), nil
 			}
 
-			result, err := base.Executor.Command("run", "revisions", "list").
+			cmd := base.Executor.Command("run", "revisions", "list").
 				WithFlag("service", service).
 				WithProject(services.GetOptionalString(args, "project", "")).
-				WithRegion(services.GetOptionalString(args, "region", "")).
+				WithRegion(serviceRegion(base, args))
+
+			if err := services.ApplyListOutput(cmd, args, csvColumns["revisions"]); err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.ExecuteWithRegion(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Describe revision
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_run_revisions_describe",
+			Description: "Get details of a Cloud Run revision, including its container, scaling and status conditions",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"revision"},
+				"properties": map[string]any{
+					"revision": map[string]any{
+						"type":        "string",
+						"description": "Name of the revision (e.g., hello-00005-xyz)",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"region": map[string]any{
+						"type":        "string",
+						"description": "Region of the revision",
+					},
+					"soft_not_found": services.SoftNotFoundProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			revision, err := services.GetRequiredString(args, "revision")
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := base.Executor.Command("run", "revisions", "describe", revision).
+				WithProject(services.GetOptionalString(args, "project", "")).
+				WithRegion(serviceRegion(base, args)).
+				ExecuteWithRegion(ctx)
+
+			if err != nil {
+				return services.DescribeError(args, result, err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Delete revision
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_run_revisions_delete",
+			Description: "Delete a Cloud Run revision. Revisions that serve traffic cannot be deleted",
+			Annotations: services.Destructive(),
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"revision"},
+				"properties": map[string]any{
+					"revision": map[string]any{
+						"type":        "string",
+						"description": "Name of the revision to delete",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"region": map[string]any{
+						"type":        "string",
+						"description": "Region of the revision",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			revision, err := services.GetRequiredString(args, "revision")
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := base.Executor.Command("run", "revisions", "delete", revision).
+				WithProject(services.GetOptionalString(args, "project", "")).
+				WithRegion(serviceRegion(base, args)).
+				WithBoolFlag("quiet").
 				ExecuteWithRegion(ctx)
 
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// List jobs
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_run_jobs_list",
 			Description: "List Cloud Run jobs",
@@ -483,26 +678,32 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "string",
 						"description": "Region",
 					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
 				},
 			},
 		},
 		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			args := parseArgs(req)
 
-			result, err := base.Executor.Command("run", "jobs", "list").
+			cmd := base.Executor.Command("run", "jobs", "list").
 				WithProject(services.GetOptionalString(args, "project", "")).
-				WithRegion(services.GetOptionalString(args, "region", "")).
-				ExecuteWithRegion(ctx)
+				WithRegion(serviceRegion(base, args))
+
+			if err := services.ApplyListOutput(cmd, args, csvColumns["jobs"]); err != nil {
+				return services.ToolError(err), nil
+			}
 
+			result, err := cmd.ExecuteWithRegion(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Execute job
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_run_jobs_execute",
 			Description: "Execute a Cloud Run job",
@@ -534,13 +735,13 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 
 			result, err := base.Executor.Command("run", "jobs", "execute", job).
 				WithProject(services.GetOptionalString(args, "project", "")).
-				WithRegion(services.GetOptionalString(args, "region", "")).
+				WithRegion(serviceRegion(base, args)).
 				ExecuteWithRegion(ctx)
 
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 }
@@ -556,3 +757,211 @@ func parseArgs(req *mcp.CallToolRequest) map[string]any {
 	}
 	return args
 }
+
+// csvColumns are the default column projections used when a list tool is
+// called with output=csv, keyed by resource.
+var csvColumns = map[string]string{
+	"services":  "metadata.name,status.url,status.latestReadyRevisionName,metadata.creationTimestamp",
+	"revisions": "metadata.name,status.conditions[0].status,metadata.creationTimestamp",
+	"jobs":      "metadata.name,metadata.creationTimestamp",
+}
+
+// serviceSummary is the condensed view of a Cloud Run service returned by
+// describe when summary is requested.
+type serviceSummary struct {
+	URL                 string          `json:"url"`
+	LatestReadyRevision string          `json:"latestReadyRevision"`
+	LastModifier        string          `json:"lastModifier,omitempty"`
+	Traffic             []serviceTarget `json:"traffic"`
+}
+
+// serviceTarget is a single entry of a service's traffic split.
+type serviceTarget struct {
+	RevisionName   string `json:"revisionName,omitempty"`
+	Percent        int    `json:"percent"`
+	LatestRevision bool   `json:"latestRevision,omitempty"`
+	Tag            string `json:"tag,omitempty"`
+	URL            string `json:"url,omitempty"`
+}
+
+// summarizeService extracts the serviceSummary from `run services describe` JSON.
+func summarizeService(result *executor.Result) (*serviceSummary, error) {
+	var svc struct {
+		Metadata struct {
+			Annotations map[string]string `json:"annotations"`
+		} `json:"metadata"`
+		Status struct {
+			URL                     string          `json:"url"`
+			LatestReadyRevisionName string          `json:"latestReadyRevisionName"`
+			Traffic                 []serviceTarget `json:"traffic"`
+		} `json:"status"`
+	}
+	if err := result.ParseJSON(&svc); err != nil {
+		return nil, fmt.Errorf("failed to parse service description: %w", err)
+	}
+
+	return &serviceSummary{
+		URL:                 svc.Status.URL,
+		LatestReadyRevision: svc.Status.LatestReadyRevisionName,
+		LastModifier:        svc.Metadata.Annotations["serving.knative.dev/lastModifier"],
+		Traffic:             svc.Status.Traffic,
+	}, nil
+}
+
+// serviceRegion returns the region argument, falling back to the configured
+// Cloud Run region (GCLOUD_RUN_REGION). When both are empty the default
+// region applies.
+func serviceRegion(base *services.BaseService, args map[string]any) string {
+	return services.GetOptionalString(args, "region", base.Config.RunRegion)
+}
+
+// deployCommand builds the `run deploy` command for a container image.
+func deployCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	// Report a missing service and image or source in one error
+	var problems []string
+	if err := services.RequireAll(args, "service"); err != nil {
+		problems = append(problems, err.Error())
+	}
+	image := services.GetOptionalString(args, "image", "")
+	source := services.GetOptionalString(args, "source", "")
+	if (image == "") == (source == "") {
+		problems = append(problems, "exactly one of image or source is required")
+	}
+	if len(problems) > 0 {
+		return nil, errors.New(strings.Join(problems, "; "))
+	}
+	service, _ := services.GetRequiredString(args, "service")
+
+	cmd := base.Executor.Command("run", "deploy", service).
+		WithFlag("image", image).
+		WithFlag("source", source).
+		WithProject(services.GetOptionalString(args, "project", "")).
+		WithRegion(serviceRegion(base, args))
+
+	if port := services.GetOptionalString(args, "port", ""); port != "" {
+		cmd.WithFlag("port", port)
+	}
+	if memory := services.GetOptionalString(args, "memory", ""); memory != "" {
+		cmd.WithFlag("memory", memory)
+	}
+	if cpu := services.GetOptionalString(args, "cpu", ""); cpu != "" {
+		cmd.WithFlag("cpu", cpu)
+	}
+	if _, ok := args["cpu_boost"]; ok {
+		if services.GetOptionalBool(args, "cpu_boost", false) {
+			cmd.WithBoolFlag("cpu-boost")
+		} else {
+			cmd.WithBoolFlag("no-cpu-boost")
+		}
+	}
+	if _, ok := args["no_cpu_throttling"]; ok {
+		if services.GetOptionalBool(args, "no_cpu_throttling", false) {
+			cmd.WithBoolFlag("no-cpu-throttling")
+		} else {
+			cmd.WithBoolFlag("cpu-throttling")
+		}
+	}
+	switch env := services.GetOptionalString(args, "execution_environment", ""); env {
+	case "", "gen1", "gen2":
+		cmd.WithFlag("execution-environment", env)
+	default:
+		return nil, fmt.Errorf("execution_environment must be gen1 or gen2, got %q", env)
+	}
+	cmd.WithFlag("timeout", services.GetOptionalString(args, "timeout", ""))
+	if minInstances := services.GetOptionalInt(args, "min_instances", -1); minInstances >= 0 {
+		cmd.WithFlag("min-instances", fmt.Sprintf("%d", minInstances))
+	}
+	if maxInstances := services.GetOptionalInt(args, "max_instances", -1); maxInstances >= 0 {
+		cmd.WithFlag("max-instances", fmt.Sprintf("%d", maxInstances))
+	}
+	if sa := services.GetOptionalString(args, "service_account", ""); sa != "" {
+		cmd.WithFlag("service-account", sa)
+	}
+
+	// Handle environment variables
+	envVars := services.GetOptionalStringMap(args, "env_vars")
+	envVarsFile := services.GetOptionalString(args, "env_vars_file", "")
+	if len(envVars) > 0 && envVarsFile != "" {
+		return nil, fmt.Errorf("env_vars and env_vars_file are mutually exclusive")
+	}
+	if len(envVars) > 0 {
+		var pairs []string
+		for k, v := range envVars {
+			pairs = append(pairs, fmt.Sprintf("%s=%s", k, v))
+		}
+		cmd.WithFlag("set-env-vars", strings.Join(pairs, ","))
+	}
+	cmd.WithFlag("env-vars-file", envVarsFile)
+
+	secrets, err := services.SecretMappings(
+		services.GetOptionalStringMap(args, "set_secrets"),
+		services.GetOptionalStringMap(args, "secret_volumes"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	removeSecrets := services.GetOptionalStringArray(args, "remove_secrets")
+	if secrets != "" && len(removeSecrets) > 0 {
+		return nil, fmt.Errorf("remove_secrets cannot be combined with set_secrets or secret_volumes")
+	}
+	cmd.WithFlag("set-secrets", secrets).
+		WithFlag("remove-secrets", strings.Join(removeSecrets, ",")).
+		WithFlag("add-cloudsql-instances", strings.Join(services.GetOptionalStringArray(args, "add_cloudsql_instances"), ","))
+
+	if err := applyVPCAccess(cmd, args); err != nil {
+		return nil, err
+	}
+
+	if services.GetOptionalBool(args, "allow_unauthenticated", false) {
+		cmd.WithBoolFlag("allow-unauthenticated")
+	}
+	return cmd, nil
+}
+
+// applyVPCAccess adds the flags that connect a service to a VPC network,
+// either through a Serverless VPC Access connector or with Direct VPC egress.
+func applyVPCAccess(cmd *executor.CommandBuilder, args map[string]any) error {
+	connector := services.GetOptionalString(args, "vpc_connector", "")
+	network := services.GetOptionalString(args, "network", "")
+	subnet := services.GetOptionalString(args, "subnet", "")
+	egress := services.GetOptionalString(args, "vpc_egress", "")
+
+	if connector != "" && (network != "" || subnet != "") {
+		return fmt.Errorf("vpc_connector cannot be combined with network or subnet (Direct VPC egress)")
+	}
+	switch egress {
+	case "":
+	case "all-traffic", "private-ranges-only":
+		if connector == "" && network == "" && subnet == "" {
+			return fmt.Errorf("vpc_egress requires vpc_connector, network or subnet")
+		}
+	default:
+		return fmt.Errorf("vpc_egress must be all-traffic or private-ranges-only, got %q", egress)
+	}
+
+	cmd.WithFlag("vpc-connector", connector).
+		WithFlag("network", network).
+		WithFlag("subnet", subnet).
+		WithFlag("vpc-egress", egress)
+	return nil
+}
+
+// writeEnvFile writes envYAML to a temporary file for --env-vars-file and
+// returns its path and a function that removes it.
+func writeEnvFile(envYAML string) (string, func(), error) {
+	f, err := os.CreateTemp("", "gcloud-mcp-env-*.yaml")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create env vars file: %w", err)
+	}
+	cleanup := func() { os.Remove(f.Name()) }
+
+	_, err = f.WriteString(envYAML)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("failed to write env vars file: %w", err)
+	}
+	return f.Name(), cleanup, nil
+}
@@ -0,0 +1,510 @@
+package run
+
+import (
+	"encoding/json"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"gcloud-go-mcp/internal/executor"
+	"gcloud-go-mcp/internal/executor/executortest"
+	"gcloud-go-mcp/internal/services"
+	"gcloud-go-mcp/internal/services/servicetest"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func newTestBase() *services.BaseService {
+	return services.NewBaseService(servicetest.NewConfig())
+}
+
+func TestRegisterTools(t *testing.T) {
+	server := mcp.NewServer(
+		&mcp.Implementation{
+			Name:    "test-server",
+			Version: "0.0.1",
+		},
+		&mcp.ServerOptions{},
+	)
+
+	// Should not panic
+	RegisterTools(server, newTestBase())
+}
+
+const describePayload = `{
+  "apiVersion": "serving.knative.dev/v1",
+  "kind": "Service",
+  "metadata": {
+    "name": "hello",
+    "namespace": "123456789",
+    "annotations": {
+      "run.googleapis.com/ingress": "all",
+      "serving.knative.dev/creator": "alice@example.com",
+      "serving.knative.dev/lastModifier": "deployer@test-project.iam.gserviceaccount.com"
+    }
+  },
+  "spec": {
+    "template": {
+      "spec": {
+        "containers": [{"image": "gcr.io/test-project/hello:v2"}]
+      }
+    }
+  },
+  "status": {
+    "observedGeneration": 7,
+    "url": "https://hello-abc123-uc.a.run.app",
+    "latestCreatedRevisionName": "hello-00007-xyz",
+    "latestReadyRevisionName": "hello-00007-xyz",
+    "traffic": [
+      {"revisionName": "hello-00007-xyz", "percent": 90, "latestRevision": true},
+      {"revisionName": "hello-00006-abc", "percent": 10, "tag": "canary", "url": "https://canary---hello-abc123-uc.a.run.app"}
+    ]
+  }
+}`
+
+func TestSummarizeService(t *testing.T) {
+	summary, err := summarizeService(&executor.Result{JSON: json.RawMessage(describePayload)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if summary.URL != "https://hello-abc123-uc.a.run.app" {
+		t.Errorf("unexpected url %q", summary.URL)
+	}
+	if summary.LatestReadyRevision != "hello-00007-xyz" {
+		t.Errorf("unexpected latestReadyRevision %q", summary.LatestReadyRevision)
+	}
+	if summary.LastModifier != "deployer@test-project.iam.gserviceaccount.com" {
+		t.Errorf("unexpected lastModifier %q", summary.LastModifier)
+	}
+	if len(summary.Traffic) != 2 {
+		t.Fatalf("expected 2 traffic targets, got %d", len(summary.Traffic))
+	}
+	if summary.Traffic[0].Percent != 90 || !summary.Traffic[0].LatestRevision {
+		t.Errorf("unexpected first traffic target %+v", summary.Traffic[0])
+	}
+	if summary.Traffic[1].Tag != "canary" || summary.Traffic[1].Percent != 10 {
+		t.Errorf("unexpected second traffic target %+v", summary.Traffic[1])
+	}
+
+	data, _ := json.Marshal(summary)
+	var keys map[string]any
+	_ = json.Unmarshal(data, &keys)
+	for _, key := range []string{"url", "latestReadyRevision", "lastModifier", "traffic"} {
+		if _, ok := keys[key]; !ok {
+			t.Errorf("expected %q in summary JSON, got %s", key, data)
+		}
+	}
+	if _, ok := keys["spec"]; ok {
+		t.Error("did not expect spec in summary JSON")
+	}
+}
+
+func TestSummarizeService_InvalidJSON(t *testing.T) {
+	if _, err := summarizeService(&executor.Result{Stdout: "not json"}); err == nil {
+		t.Error("expected error when there is no JSON output")
+	}
+}
+
+func TestToolCall_ServicesDescribeSummary(t *testing.T) {
+	runner := &executortest.Runner{Stdout: describePayload}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_run_services_describe", map[string]any{
+		"service": "hello",
+		"region":  "europe-west1",
+		"summary": true,
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+
+	args := runner.LastArgs()
+	if len(args) < 4 || !slices.Equal(args[:4], []string{"run", "services", "describe", "hello"}) {
+		t.Errorf("unexpected command %v", args)
+	}
+	if missing := executortest.Missing(args, "--region=europe-west1"); len(missing) > 0 {
+		t.Errorf("missing %v in %v", missing, args)
+	}
+	if text := servicetest.Text(result); !strings.Contains(text, "https://hello-abc123-uc.a.run.app") {
+		t.Errorf("expected %q in result, got %q", "https://hello-abc123-uc.a.run.app", text)
+	}
+}
+
+func TestDeployCommand_ImageOrSource(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		notWant []string
+		wantErr string
+	}{
+		{
+			name:    "image",
+			args:    map[string]any{"service": "hello", "image": "gcr.io/test-project/hello:v2"},
+			want:    []string{"--image=gcr.io/test-project/hello:v2"},
+			notWant: []string{"--source="},
+		},
+		{
+			name:    "source",
+			args:    map[string]any{"service": "hello", "source": "./app"},
+			want:    []string{"--source=./app"},
+			notWant: []string{"--image="},
+		},
+		{
+			name:    "both",
+			args:    map[string]any{"service": "hello", "image": "gcr.io/test-project/hello:v2", "source": "./app"},
+			wantErr: "exactly one of image or source",
+		},
+		{
+			name:    "neither",
+			args:    map[string]any{"service": "hello"},
+			wantErr: "exactly one of image or source",
+		},
+		{
+			name:    "no service and neither",
+			args:    map[string]any{},
+			wantErr: "missing required parameter: service; exactly one of image or source is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := deployCommand(newTestBase(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if missing := executortest.Missing(args, tt.want...); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, args)
+			}
+			for _, prefix := range tt.notWant {
+				for _, arg := range args {
+					if strings.HasPrefix(arg, prefix) {
+						t.Errorf("unexpected %s in %v", arg, args)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestDeployCommand_EnvVarsFile(t *testing.T) {
+	cmd, err := deployCommand(newTestBase(), map[string]any{
+		"service":       "hello",
+		"image":         "gcr.io/test-project/hello:v2",
+		"env_vars_file": "/config/env.yaml",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	args := cmd.Build()
+	if missing := executortest.Missing(args, "--env-vars-file=/config/env.yaml"); len(missing) > 0 {
+		t.Errorf("missing %v in %v", missing, args)
+	}
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "--set-env-vars") {
+			t.Errorf("did not expect %q in %v", arg, args)
+		}
+	}
+
+	_, err = deployCommand(newTestBase(), map[string]any{
+		"service":       "hello",
+		"image":         "gcr.io/test-project/hello:v2",
+		"env_vars":      map[string]any{"MODE": "prod"},
+		"env_vars_file": "/config/env.yaml",
+	})
+	if err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Errorf("expected mutually exclusive error, got %v", err)
+	}
+}
+
+func TestToolCall_ServicesSetEnvFile(t *testing.T) {
+	const envYAML = "MODE: prod\nGREETING: \"hello, world\"\n"
+
+	var path, content string
+	runner := &executortest.Runner{Handler: func(args []string) (*executor.Result, error) {
+		for _, arg := range args {
+			if p, ok := strings.CutPrefix(arg, "--env-vars-file="); ok {
+				path = p
+				data, err := os.ReadFile(p)
+				if err != nil {
+					return nil, err
+				}
+				content = string(data)
+			}
+		}
+		return &executor.Result{Stdout: "{}"}, nil
+	}}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_run_services_set_env_file", map[string]any{
+		"service":  "hello",
+		"env_yaml": envYAML,
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+
+	args := runner.LastArgs()
+	if len(args) < 4 || !slices.Equal(args[:4], []string{"run", "services", "update", "hello"}) {
+		t.Errorf("unexpected command %v", args)
+	}
+	if path == "" {
+		t.Fatalf("expected --env-vars-file in %v", args)
+	}
+	if content != envYAML {
+		t.Errorf("expected env file content %q, got %q", envYAML, content)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected %s to be removed after the call, got %v", path, err)
+	}
+}
+
+func TestDeployCommand_SecretsAndCloudSQL(t *testing.T) {
+	cmd, err := deployCommand(newTestBase(), map[string]any{
+		"service":                "hello",
+		"image":                  "gcr.io/test-project/hello:v2",
+		"set_secrets":            map[string]any{"DB_PASSWORD": "db-password:3", "API_KEY": "api-key"},
+		"secret_volumes":         map[string]any{"/etc/tls": "tls-cert:1"},
+		"add_cloudsql_instances": []any{"test-project:us-central1:orders", "test-project:us-central1:users"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	args := cmd.Build()
+	want := []string{
+		"--set-secrets=API_KEY=api-key:latest,DB_PASSWORD=db-password:3,/etc/tls=tls-cert:1",
+		"--add-cloudsql-instances=test-project:us-central1:orders,test-project:us-central1:users",
+	}
+	if missing := executortest.Missing(args, want...); len(missing) > 0 {
+		t.Errorf("missing %v in %v", missing, args)
+	}
+}
+
+func TestDeployCommand_RemoveSecrets(t *testing.T) {
+	args := map[string]any{
+		"service":        "hello",
+		"image":          "gcr.io/test-project/hello:v2",
+		"remove_secrets": []any{"API_KEY", "/etc/tls"},
+	}
+	cmd, err := deployCommand(newTestBase(), args)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if built := cmd.Build(); !slices.Contains(built, "--remove-secrets=API_KEY,/etc/tls") {
+		t.Errorf("expected --remove-secrets in %v", built)
+	}
+
+	args["set_secrets"] = map[string]any{"DB_PASSWORD": "db-password"}
+	if _, err := deployCommand(newTestBase(), args); err == nil || !strings.Contains(err.Error(), "cannot be combined") {
+		t.Errorf("expected conflict error, got %v", err)
+	}
+}
+
+func TestDeployCommand_Runtime(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		notWant []string
+		wantErr string
+	}{
+		{
+			name: "always-allocated gen2",
+			args: map[string]any{
+				"execution_environment": "gen2",
+				"no_cpu_throttling":     true,
+				"cpu_boost":             true,
+				"timeout":               "15m",
+			},
+			want: []string{"--execution-environment=gen2", "--no-cpu-throttling", "--cpu-boost", "--timeout=15m"},
+		},
+		{
+			name:    "request-based cpu",
+			args:    map[string]any{"no_cpu_throttling": false, "cpu_boost": false},
+			want:    []string{"--cpu-throttling", "--no-cpu-boost"},
+			notWant: []string{"--no-cpu-throttling"},
+		},
+		{
+			name:    "defaults",
+			args:    map[string]any{},
+			notWant: []string{"--execution-environment", "--cpu-throttling", "--no-cpu-throttling", "--cpu-boost", "--no-cpu-boost", "--timeout"},
+		},
+		{
+			name:    "unknown environment",
+			args:    map[string]any{"execution_environment": "gen3"},
+			wantErr: "gen1 or gen2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := map[string]any{"service": "hello", "image": "gcr.io/test-project/hello:v2"}
+			maps.Copy(args, tt.args)
+			cmd, err := deployCommand(newTestBase(), args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			built := cmd.Build()
+			if missing := executortest.Missing(built, tt.want...); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, built)
+			}
+			for _, prefix := range tt.notWant {
+				for _, arg := range built {
+					if strings.HasPrefix(arg, prefix) {
+						t.Errorf("unexpected %s in %v", arg, built)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestDeployCommand_VPCAccess(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		notWant []string
+		wantErr string
+	}{
+		{
+			name:    "connector",
+			args:    map[string]any{"vpc_connector": "private-connector", "vpc_egress": "private-ranges-only"},
+			want:    []string{"--vpc-connector=private-connector", "--vpc-egress=private-ranges-only"},
+			notWant: []string{"--network=", "--subnet="},
+		},
+		{
+			name:    "direct vpc egress",
+			args:    map[string]any{"network": "prod-vpc", "subnet": "prod-us-central1", "vpc_egress": "all-traffic"},
+			want:    []string{"--network=prod-vpc", "--subnet=prod-us-central1", "--vpc-egress=all-traffic"},
+			notWant: []string{"--vpc-connector="},
+		},
+		{
+			name:    "no vpc access",
+			args:    map[string]any{},
+			notWant: []string{"--vpc-connector=", "--network=", "--subnet=", "--vpc-egress="},
+		},
+		{
+			name:    "connector and network",
+			args:    map[string]any{"vpc_connector": "private-connector", "network": "prod-vpc"},
+			wantErr: "cannot be combined",
+		},
+		{
+			name:    "egress without vpc",
+			args:    map[string]any{"vpc_egress": "all-traffic"},
+			wantErr: "requires vpc_connector, network or subnet",
+		},
+		{
+			name:    "unknown egress",
+			args:    map[string]any{"vpc_connector": "private-connector", "vpc_egress": "public"},
+			wantErr: "all-traffic or private-ranges-only",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := map[string]any{"service": "hello", "image": "gcr.io/test-project/hello:v2"}
+			maps.Copy(args, tt.args)
+			cmd, err := deployCommand(newTestBase(), args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			built := cmd.Build()
+			if missing := executortest.Missing(built, tt.want...); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, built)
+			}
+			for _, prefix := range tt.notWant {
+				for _, arg := range built {
+					if strings.HasPrefix(arg, prefix) {
+						t.Errorf("unexpected %s in %v", arg, built)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestToolCall_ServicesListRegionPrecedence(t *testing.T) {
+	tests := []struct {
+		name      string
+		runRegion string
+		args      map[string]any
+		want      string
+	}{
+		{"default region", "", map[string]any{}, "--region=us-central1"},
+		{"run region", "europe-west1", map[string]any{}, "--region=europe-west1"},
+		{"argument", "europe-west1", map[string]any{"region": "asia-east1"}, "--region=asia-east1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := servicetest.NewConfig()
+			cfg.RunRegion = tt.runRegion
+			runner := &executortest.Runner{Stdout: "[]"}
+			result := servicetest.CallToolWithConfig(t, cfg, RegisterTools, runner, "gcp_run_services_list", tt.args)
+			if result.IsError {
+				t.Fatalf("unexpected error: %s", servicetest.Text(result))
+			}
+			if args := runner.LastArgs(); !slices.Contains(args, tt.want) {
+				t.Errorf("expected %q in %v", tt.want, args)
+			}
+		})
+	}
+}
+
+func TestToolCall_Revisions(t *testing.T) {
+	tests := []struct {
+		tool string
+		args map[string]any
+		want []string
+	}{
+		{
+			tool: "gcp_run_revisions_describe",
+			args: map[string]any{"revision": "hello-00005-xyz"},
+			want: []string{"run", "revisions", "describe", "hello-00005-xyz", "--region=us-central1", "--project=test-project"},
+		},
+		{
+			tool: "gcp_run_revisions_delete",
+			args: map[string]any{"revision": "hello-00004-abc", "region": "europe-west1", "project": "other-project"},
+			want: []string{"run", "revisions", "delete", "hello-00004-abc", "--region=europe-west1", "--project=other-project", "--quiet"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tool, func(t *testing.T) {
+			runner := &executortest.Runner{Stdout: "{}"}
+			result := servicetest.CallTool(t, RegisterTools, runner, tt.tool, tt.args)
+			if result.IsError {
+				t.Fatalf("unexpected error: %s", servicetest.Text(result))
+			}
+			args := runner.LastArgs()
+			if !slices.Equal(args[:4], tt.want[:4]) {
+				t.Errorf("unexpected command %v", args)
+			}
+			if missing := executortest.Missing(args, tt.want[4:]...); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, args)
+			}
+		})
+	}
+}
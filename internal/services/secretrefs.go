@@ -0,0 +1,48 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SecretMappings renders secrets exposed as environment variables and as
+// mounted files into a --set-secrets value for Cloud Run and Cloud Functions.
+// envs maps variable names and volumes maps mount paths to a secret
+// reference, SECRET or SECRET:VERSION, where SECRET may be a secret name or a
+// projects/PROJECT/secrets/SECRET path. References without a version use
+// latest.
+func SecretMappings(envs, volumes map[string]string) (string, error) {
+	var pairs []string
+	for _, group := range []struct {
+		mappings map[string]string
+		volume   bool
+	}{{envs, false}, {volumes, true}} {
+		keys := make([]string, 0, len(group.mappings))
+		for k := range group.mappings {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			if group.volume != strings.HasPrefix(key, "/") {
+				if group.volume {
+					return "", fmt.Errorf("secret mount path %q must be absolute", key)
+				}
+				return "", fmt.Errorf("secret environment variable %q must not be a path", key)
+			}
+			ref := group.mappings[key]
+			if ref == "" {
+				return "", fmt.Errorf("secret reference for %s cannot be empty", key)
+			}
+			if strings.ContainsAny(key+ref, ",=") {
+				return "", fmt.Errorf("secret mapping %s=%s cannot contain ',' or '='", key, ref)
+			}
+			if !strings.Contains(ref, ":") {
+				ref += ":latest"
+			}
+			pairs = append(pairs, key+"="+ref)
+		}
+	}
+	return strings.Join(pairs, ","), nil
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSecretMappings(t *testing.T) {
+	tests := []struct {
+		name    string
+		envs    map[string]string
+		volumes map[string]string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "none",
+			want: "",
+		},
+		{
+			name: "env vars with and without version",
+			envs: map[string]string{"DB_PASSWORD": "db-password:3", "API_KEY": "api-key"},
+			want: "API_KEY=api-key:latest,DB_PASSWORD=db-password:3",
+		},
+		{
+			name:    "env vars then volumes",
+			envs:    map[string]string{"API_KEY": "projects/shared/secrets/api-key:2"},
+			volumes: map[string]string{"/etc/tls": "tls-cert"},
+			want:    "API_KEY=projects/shared/secrets/api-key:2,/etc/tls=tls-cert:latest",
+		},
+		{
+			name:    "relative mount path",
+			volumes: map[string]string{"etc/tls": "tls-cert"},
+			wantErr: "must be absolute",
+		},
+		{
+			name:    "path as env var",
+			envs:    map[string]string{"/etc/tls": "tls-cert"},
+			wantErr: "must not be a path",
+		},
+		{
+			name:    "empty reference",
+			envs:    map[string]string{"API_KEY": ""},
+			wantErr: "cannot be empty",
+		},
+		{
+			name:    "comma in reference",
+			envs:    map[string]string{"API_KEY": "a,b"},
+			wantErr: "cannot contain",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SecretMappings(tt.envs, tt.volumes)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("SecretMappings() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
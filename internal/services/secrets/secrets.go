@@ -5,7 +5,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strings"
 
 	"gcloud-go-mcp/internal/services"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -14,7 +13,7 @@ import (
 // RegisterTools registers all Secret Manager tools with the MCP server.
 func RegisterTools(server *mcp.Server, base *services.BaseService) {
 	// List secrets
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_secrets_list",
 			Description: "List secrets in a project",
@@ -34,6 +33,8 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"description": "Maximum results",
 						"default":     100,
 					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
 				},
 			},
 		},
@@ -49,16 +50,20 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 				cmd.WithFlag("limit", fmt.Sprintf("%d", limit))
 			}
 
+			if err := services.ApplyListOutput(cmd, args, csvColumns["secrets"]); err != nil {
+				return services.ToolError(err), nil
+			}
+
 			result, err := cmd.Execute(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Create secret
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_secrets_create",
 			Description: "Create a new secret",
@@ -83,6 +88,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "object",
 						"description": "Labels as key-value pairs",
 					},
+					"if_not_exists": services.IfNotExistsProperty(),
 				},
 			},
 		},
@@ -93,6 +99,15 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 				return services.ToolError(err), nil
 			}
 
+			existing, err := base.ExistingResource(ctx, args, base.Executor.Command("secrets", "describe", secretID).
+				WithProject(services.GetOptionalString(args, "project", "")))
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			if existing != nil {
+				return existing, nil
+			}
+
 			cmd := base.Executor.Command("secrets", "create", secretID).
 				WithProject(services.GetOptionalString(args, "project", ""))
 
@@ -100,24 +115,20 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 				cmd.WithFlag("replication-policy", policy)
 			}
 
-			if labels := services.GetOptionalStringMap(args, "labels"); len(labels) > 0 {
-				var pairs []string
-				for k, v := range labels {
-					pairs = append(pairs, fmt.Sprintf("%s=%s", k, v))
-				}
-				cmd.WithFlag("labels", strings.Join(pairs, ","))
+			if labels := base.Labels(args); labels != "" {
+				cmd.WithFlag("labels", labels)
 			}
 
 			result, err := cmd.Execute(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Describe secret
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_secrets_describe",
 			Description: "Get details of a secret",
@@ -133,6 +144,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "string",
 						"description": "GCP project ID",
 					},
+					"soft_not_found": services.SoftNotFoundProperty(),
 				},
 			},
 		},
@@ -148,17 +160,18 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 				Execute(ctx)
 
 			if err != nil {
-				return services.ToolError(err), nil
+				return services.DescribeError(args, result, err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Delete secret
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_secrets_delete",
 			Description: "Delete a secret",
+			Annotations: services.Destructive(),
 			InputSchema: map[string]any{
 				"type":     "object",
 				"required": []string{"secret_id"},
@@ -194,7 +207,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 	)
 
 	// Add version
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_secrets_versions_add",
 			Description: "Add a new version to a secret",
@@ -228,24 +241,22 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 				return services.ToolError(err), nil
 			}
 
-			// Use echo to pipe data to the command
+			// Pass the data on stdin so it doesn't appear in the process list
 			result, err := base.Executor.Command("secrets", "versions", "add", secretID).
 				WithFlag("data-file", "-").
+				WithStdin([]byte(data)).
 				WithProject(services.GetOptionalString(args, "project", "")).
 				Execute(ctx)
 
-			// Note: This is a simplified implementation. For real use,
-			// we'd need to handle stdin properly
-			_ = data
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Access version
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_secrets_versions_access",
 			Description: "Access a secret version's data",
@@ -286,12 +297,12 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.Stdout), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// List versions
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_secrets_versions_list",
 			Description: "List versions of a secret",
@@ -311,6 +322,8 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "string",
 						"description": "Filter expression",
 					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
 				},
 			},
 		},
@@ -328,16 +341,20 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 				cmd.WithFlag("filter", filter)
 			}
 
+			if err := services.ApplyListOutput(cmd, args, csvColumns["versions"]); err != nil {
+				return services.ToolError(err), nil
+			}
+
 			result, err := cmd.Execute(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Disable version
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_secrets_versions_disable",
 			Description: "Disable a secret version",
@@ -379,12 +396,12 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Enable version
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_secrets_versions_enable",
 			Description: "Enable a disabled secret version",
@@ -426,15 +443,16 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Destroy version
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_secrets_versions_destroy",
 			Description: "Destroy a secret version (irreversible)",
+			Annotations: services.Destructive(),
 			InputSchema: map[string]any{
 				"type":     "object",
 				"required": []string{"secret_id", "version"},
@@ -479,7 +497,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 	)
 
 	// Get IAM policy
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_secrets_get_iam_policy",
 			Description: "Get IAM policy for a secret",
@@ -512,12 +530,12 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Add IAM policy binding
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_secrets_add_iam_policy_binding",
 			Description: "Add IAM policy binding to a secret",
@@ -568,7 +586,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 }
@@ -583,3 +601,10 @@ func parseArgs(req *mcp.CallToolRequest) map[string]any {
 	}
 	return args
 }
+
+// csvColumns are the default column projections used when a list tool is
+// called with output=csv, keyed by resource.
+var csvColumns = map[string]string{
+	"secrets":  "name.basename(),createTime",
+	"versions": "name.basename(),state,createTime",
+}
@@ -2,11 +2,16 @@ package secrets
 
 import (
 	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"gcloud-go-mcp/internal/config"
+	"gcloud-go-mcp/internal/executor/executortest"
 	"gcloud-go-mcp/internal/services"
+	"gcloud-go-mcp/internal/services/servicetest"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -328,3 +333,59 @@ func BenchmarkParseArgs_LargePayload(b *testing.B) {
 		testParseArgs(argsJSON)
 	}
 }
+
+func TestToolCall_Describe(t *testing.T) {
+	runner := &executortest.Runner{}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_secrets_describe", map[string]any{
+		"secret_id": "api-key",
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+
+	args := runner.LastArgs()
+	if len(args) < 3 || !slices.Equal(args[:3], []string{"secrets", "describe", "api-key"}) {
+		t.Errorf("unexpected command %v", args)
+	}
+	if missing := executortest.Missing(args, "--project=test-project"); len(missing) > 0 {
+		t.Errorf("missing %v in %v", missing, args)
+	}
+}
+
+func TestToolCall_DescribeError(t *testing.T) {
+	runner := &executortest.Runner{
+		Stderr: "ERROR: (gcloud.secrets.describe) NOT_FOUND: Secret [api-key] not found",
+		Err:    errors.New("exit status 1"),
+	}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_secrets_describe", map[string]any{
+		"secret_id": "api-key",
+	})
+	if !result.IsError {
+		t.Fatal("expected an error result")
+	}
+	if text := servicetest.Text(result); !strings.Contains(text, "NOT_FOUND") {
+		t.Errorf("expected stderr in error, got %q", text)
+	}
+}
+
+func TestToolCall_VersionsAddPassesDataOnStdin(t *testing.T) {
+	runner := &executortest.Runner{}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_secrets_versions_add", map[string]any{
+		"secret_id": "api-key",
+		"data":      "s3cr3t",
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+
+	args := runner.LastArgs()
+	if slices.ContainsFunc(args, func(a string) bool { return strings.Contains(a, "s3cr3t") }) {
+		t.Errorf("secret data leaked into args %v", args)
+	}
+	if missing := executortest.Missing(args, "--data-file=-"); len(missing) > 0 {
+		t.Errorf("missing %v in %v", missing, args)
+	}
+	if got := string(runner.LastStdin()); got != "s3cr3t" {
+		t.Errorf("expected data on stdin, got %q", got)
+	}
+}
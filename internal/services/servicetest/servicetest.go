@@ -0,0 +1,73 @@
+// Package servicetest provides helpers for calling service tools end to end
+// against a fake gcloud runner.
+package servicetest
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"gcloud-go-mcp/internal/config"
+	"gcloud-go-mcp/internal/executor/executortest"
+	"gcloud-go-mcp/internal/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// NewConfig returns the configuration used by service tests.
+func NewConfig() *config.Config {
+	return &config.Config{
+		Project:        "test-project",
+		Region:         "us-central1",
+		Zone:           "us-central1-a",
+		GCloudPath:     "gcloud",
+		CommandTimeout: 5 * time.Minute,
+	}
+}
+
+// CallTool registers a service's tools on a new server backed by runner,
+// calls the named tool over an in-memory MCP session and returns its result.
+func CallTool(t testing.TB, register func(*mcp.Server, *services.BaseService), runner *executortest.Runner, name string, args map[string]any) *mcp.CallToolResult {
+	t.Helper()
+	return CallToolWithConfig(t, NewConfig(), register, runner, name, args)
+}
+
+// CallToolWithConfig is like CallTool but registers the tools with cfg.
+func CallToolWithConfig(t testing.TB, cfg *config.Config, register func(*mcp.Server, *services.BaseService), runner *executortest.Runner, name string, args map[string]any) *mcp.CallToolResult {
+	t.Helper()
+	ctx := context.Background()
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test-server", Version: "0.0.1"}, nil)
+	register(server, services.NewBaseServiceWithRunner(cfg, runner))
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	if err != nil {
+		t.Fatalf("failed to connect server: %v", err)
+	}
+	defer serverSession.Close()
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "0.0.1"}, nil)
+	clientSession, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("failed to connect client: %v", err)
+	}
+	defer clientSession.Close()
+
+	result, err := clientSession.CallTool(ctx, &mcp.CallToolParams{Name: name, Arguments: args})
+	if err != nil {
+		t.Fatalf("CallTool(%s) failed: %v", name, err)
+	}
+	return result
+}
+
+// Text returns the concatenated text content of a tool result.
+func Text(result *mcp.CallToolResult) string {
+	var b strings.Builder
+	for _, c := range result.Content {
+		if text, ok := c.(*mcp.TextContent); ok {
+			b.WriteString(text.Text)
+		}
+	}
+	return b.String()
+}
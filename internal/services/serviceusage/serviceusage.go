@@ -0,0 +1,207 @@
+// Package serviceusage provides MCP tools for enabling and disabling Google Cloud APIs.
+package serviceusage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gcloud-go-mcp/internal/executor"
+	"gcloud-go-mcp/internal/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RegisterTools registers all Service Usage tools with the MCP server.
+func RegisterTools(server *mcp.Server, base *services.BaseService) {
+	// List services
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_services_list",
+			Description: "List enabled (or available) Google Cloud APIs for a project",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"available": map[string]any{
+						"type":        "boolean",
+						"description": "List all services available to the project instead of only enabled ones",
+						"default":     false,
+					},
+					"filter": map[string]any{
+						"type":        "string",
+						"description": "Filter expression (e.g., 'config.name:run.googleapis.com')",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+
+			cmd := base.Executor.Command("services", "list").
+				WithProject(services.GetOptionalString(args, "project", ""))
+
+			if services.GetOptionalBool(args, "available", false) {
+				cmd.WithBoolFlag("available")
+			} else {
+				cmd.WithBoolFlag("enabled")
+			}
+			if filter := services.GetOptionalString(args, "filter", ""); filter != "" {
+				cmd.WithFlag("filter", filter)
+			}
+
+			if err := services.ApplyListOutput(cmd, args, csvColumns["services"]); err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Enable service
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_services_enable",
+			Description: "Enable a Google Cloud API for a project (e.g., run.googleapis.com)",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"service"},
+				"properties": map[string]any{
+					"service": map[string]any{
+						"type":        "string",
+						"description": "Service name (e.g., run.googleapis.com)",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := enableCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			if result.Stdout == "" {
+				return services.ToolResult("Service enabled successfully"), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Disable service
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_services_disable",
+			Description: "Disable a Google Cloud API for a project",
+			Annotations: services.Destructive(),
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"service"},
+				"properties": map[string]any{
+					"service": map[string]any{
+						"type":        "string",
+						"description": "Service name (e.g., run.googleapis.com)",
+					},
+					"force": map[string]any{
+						"type":        "boolean",
+						"description": "Also disable services that depend on this one (required when dependents are enabled)",
+						"default":     false,
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := disableCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				if !services.GetOptionalBool(args, "force", false) && needsForce(result) {
+					return services.ToolError(fmt.Errorf("%w\nother enabled services depend on this service; re-run with force: true to disable them as well", err)), nil
+				}
+				return services.ToolError(err), nil
+			}
+			if result.Stdout == "" {
+				return services.ToolResult("Service disabled successfully"), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+}
+
+// enableCommand builds the `services enable` command.
+func enableCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	service, err := services.GetRequiredString(args, "service")
+	if err != nil {
+		return nil, err
+	}
+	return base.Executor.Command("services", "enable", service).
+		WithProject(services.GetOptionalString(args, "project", "")).
+		WithTextFormat(), nil
+}
+
+// disableCommand builds the `services disable` command, adding --force when requested.
+func disableCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	service, err := services.GetRequiredString(args, "service")
+	if err != nil {
+		return nil, err
+	}
+	cmd := base.Executor.Command("services", "disable", service).
+		WithProject(services.GetOptionalString(args, "project", "")).
+		WithTextFormat()
+
+	if services.GetOptionalBool(args, "force", false) {
+		cmd.WithBoolFlag("force")
+	}
+	return cmd, nil
+}
+
+// needsForce reports whether a failed disable was rejected because of dependent services.
+func needsForce(result *executor.Result) bool {
+	if result == nil {
+		return false
+	}
+	stderr := strings.ToLower(result.Stderr)
+	return strings.Contains(stderr, "--force") || strings.Contains(stderr, "depend")
+}
+
+func parseArgs(req *mcp.CallToolRequest) map[string]any {
+	var args map[string]any
+	if req.Params.Arguments != nil {
+		_ = json.Unmarshal(req.Params.Arguments, &args)
+	}
+	if args == nil {
+		args = make(map[string]any)
+	}
+	return args
+}
+
+// csvColumns are the default column projections used when a list tool is
+// called with output=csv, keyed by resource.
+var csvColumns = map[string]string{
+	"services": "config.name,config.title,state",
+}
@@ -0,0 +1,144 @@
+package serviceusage
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"gcloud-go-mcp/internal/executor"
+	"gcloud-go-mcp/internal/executor/executortest"
+	"gcloud-go-mcp/internal/services"
+	"gcloud-go-mcp/internal/services/servicetest"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func newTestBase() *services.BaseService {
+	return services.NewBaseService(servicetest.NewConfig())
+}
+
+func TestRegisterTools(t *testing.T) {
+	server := mcp.NewServer(
+		&mcp.Implementation{
+			Name:    "test-server",
+			Version: "0.0.1",
+		},
+		&mcp.ServerOptions{},
+	)
+
+	// Should not panic
+	RegisterTools(server, newTestBase())
+}
+
+func TestEnableCommand(t *testing.T) {
+	cmd, err := enableCommand(newTestBase(), map[string]any{
+		"service": "run.googleapis.com",
+		"project": "my-project",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	args := cmd.Build()
+	expected := []string{"services", "enable", "run.googleapis.com", "--project=my-project"}
+	if !slices.Equal(args, expected) {
+		t.Errorf("expected args %v, got %v", expected, args)
+	}
+}
+
+func TestEnableCommand_MissingService(t *testing.T) {
+	if _, err := enableCommand(newTestBase(), map[string]any{}); err == nil {
+		t.Error("expected error for missing service")
+	}
+}
+
+func TestDisableCommand_Force(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      map[string]any
+		wantForce bool
+	}{
+		{
+			name:      "without force",
+			args:      map[string]any{"service": "compute.googleapis.com"},
+			wantForce: false,
+		},
+		{
+			name:      "with force",
+			args:      map[string]any{"service": "compute.googleapis.com", "force": true},
+			wantForce: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := disableCommand(newTestBase(), tt.args)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			args := cmd.Build()
+			if args[0] != "services" || args[1] != "disable" || args[2] != "compute.googleapis.com" {
+				t.Errorf("expected components first, got %v", args)
+			}
+			if got := slices.Contains(args, "--force"); got != tt.wantForce {
+				t.Errorf("expected --force present=%v, got args %v", tt.wantForce, args)
+			}
+		})
+	}
+}
+
+func TestNeedsForce(t *testing.T) {
+	tests := []struct {
+		name   string
+		result *executor.Result
+		want   bool
+	}{
+		{
+			name:   "nil result",
+			result: nil,
+			want:   false,
+		},
+		{
+			name: "dependent services error",
+			result: &executor.Result{
+				Stderr: "ERROR: (gcloud.services.disable) FAILED_PRECONDITION: The service compute.googleapis.com is depended on by the following active service(s): container.googleapis.com; Please specify disable_dependent_services=true if you still wish to disable the service. Use --force.",
+			},
+			want: true,
+		},
+		{
+			name: "permission error",
+			result: &executor.Result{
+				Stderr: "ERROR: (gcloud.services.disable) PERMISSION_DENIED: Permission denied",
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := needsForce(tt.result); got != tt.want {
+				t.Errorf("needsForce() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToolCall_Enable(t *testing.T) {
+	runner := &executortest.Runner{}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_services_enable", map[string]any{
+		"service": "run.googleapis.com",
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+
+	args := runner.LastArgs()
+	if len(args) < 3 || !slices.Equal(args[:3], []string{"services", "enable", "run.googleapis.com"}) {
+		t.Errorf("unexpected command %v", args)
+	}
+	if missing := executortest.Missing(args, "--project=test-project"); len(missing) > 0 {
+		t.Errorf("missing %v in %v", missing, args)
+	}
+	if text := servicetest.Text(result); !strings.Contains(text, "Service enabled successfully") {
+		t.Errorf("expected %q in result, got %q", "Service enabled successfully", text)
+	}
+}
@@ -0,0 +1,656 @@
+// Package sql provides MCP tools for Cloud SQL.
+package sql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gcloud-go-mcp/internal/executor"
+	"gcloud-go-mcp/internal/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RegisterTools registers all Cloud SQL tools with the MCP server.
+func RegisterTools(server *mcp.Server, base *services.BaseService) {
+	// Patch instance
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_sql_instances_patch",
+			Description: "Update the configuration of a Cloud SQL instance (machine tier, database flags, backup and maintenance windows). Changing the tier or some flags restarts the instance",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"instance"},
+				"properties": map[string]any{
+					"instance": map[string]any{
+						"type":        "string",
+						"description": "Cloud SQL instance ID",
+					},
+					"tier": map[string]any{
+						"type":        "string",
+						"description": "Machine tier (e.g., db-custom-2-7680, db-f1-micro)",
+					},
+					"database_flags": map[string]any{
+						"type":        "object",
+						"description": "Database flags to set (e.g., {\"max_connections\": \"200\"}). Replaces all flags currently set on the instance; use an empty value for flags without one",
+					},
+					"clear_database_flags": map[string]any{
+						"type":        "boolean",
+						"description": "Clear all database flags",
+						"default":     false,
+					},
+					"backup_start_time": map[string]any{
+						"type":        "string",
+						"description": "Start of the daily backup window in UTC, as HH:MM (enables automated backups)",
+					},
+					"maintenance_window": map[string]any{
+						"type":        "object",
+						"description": "Weekly maintenance window, in UTC",
+						"properties": map[string]any{
+							"day": map[string]any{
+								"type": "string",
+								"enum": []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"},
+							},
+							"hour": map[string]any{
+								"type":        "integer",
+								"description": "Hour of the day (0-23)",
+							},
+						},
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := instancePatchCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// List backups
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_sql_backups_list",
+			Description: "List the backups of a Cloud SQL instance",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"instance"},
+				"properties": map[string]any{
+					"instance": map[string]any{
+						"type":        "string",
+						"description": "Cloud SQL instance ID",
+					},
+					"limit": map[string]any{
+						"type":        "integer",
+						"description": "Maximum number of backups to return",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := instanceListCommand(base, "backups", args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Create backup
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_sql_backups_create",
+			Description: "Create an on-demand backup of a Cloud SQL instance",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"instance"},
+				"properties": map[string]any{
+					"instance": map[string]any{
+						"type":        "string",
+						"description": "Cloud SQL instance ID",
+					},
+					"description": map[string]any{
+						"type":        "string",
+						"description": "Description of the backup",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			instance, err := services.GetRequiredString(args, "instance")
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := base.Executor.Command("sql", "backups", "create").
+				WithFlag("instance", instance).
+				WithFlag("description", services.GetOptionalString(args, "description", "")).
+				WithProject(services.GetOptionalString(args, "project", "")).
+				Execute(ctx)
+			if err != nil {
+				return services.DeployError(result, err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Restore backup
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_sql_backups_restore",
+			Description: "Restore a backup to a Cloud SQL instance, overwriting all of the instance's current data. The instance is restarted",
+			Annotations: services.Destructive(),
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"backup_id", "instance"},
+				"properties": map[string]any{
+					"backup_id": map[string]any{
+						"type":        "string",
+						"description": "ID of the backup to restore (from gcp_sql_backups_list)",
+					},
+					"instance": map[string]any{
+						"type":        "string",
+						"description": "Cloud SQL instance to restore the backup to",
+					},
+					"backup_instance": map[string]any{
+						"type":        "string",
+						"description": "Instance the backup was taken from, when it is not the target instance",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := backupRestoreCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.DeployError(result, err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// List operations
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_sql_operations_list",
+			Description: "List the operations (backups, restores, updates, imports) run on a Cloud SQL instance, most recent first",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"instance"},
+				"properties": map[string]any{
+					"instance": map[string]any{
+						"type":        "string",
+						"description": "Cloud SQL instance ID",
+					},
+					"limit": map[string]any{
+						"type":        "integer",
+						"description": "Maximum number of operations to return",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := instanceListCommand(base, "operations", args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// List users
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_sql_users_list",
+			Description: "List the database users of a Cloud SQL instance",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"instance"},
+				"properties": map[string]any{
+					"instance": map[string]any{
+						"type":        "string",
+						"description": "Cloud SQL instance ID",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := instanceListCommand(base, "users", args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Create user
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_sql_users_create",
+			Description: "Create a database user on a Cloud SQL instance. The password is never included in results or the audit log",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"instance", "username", "password"},
+				"properties": map[string]any{
+					"instance": map[string]any{
+						"type":        "string",
+						"description": "Cloud SQL instance ID",
+					},
+					"username": map[string]any{
+						"type":        "string",
+						"description": "Name of the user",
+					},
+					"password": map[string]any{
+						"type":        "string",
+						"description": "Password of the user (sensitive)",
+						"writeOnly":   true,
+					},
+					"host": map[string]any{
+						"type":        "string",
+						"description": "Host the user can connect from, for MySQL instances (e.g., % or 10.0.0.%)",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := userCreateCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Delete user
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_sql_users_delete",
+			Description: "Delete a database user from a Cloud SQL instance",
+			Annotations: services.Destructive(),
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"instance", "username"},
+				"properties": map[string]any{
+					"instance": map[string]any{
+						"type":        "string",
+						"description": "Cloud SQL instance ID",
+					},
+					"username": map[string]any{
+						"type":        "string",
+						"description": "Name of the user",
+					},
+					"host": map[string]any{
+						"type":        "string",
+						"description": "Host of the user, for MySQL instances",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := instanceResourceCommand(base, "users", "delete", "username", args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			cmd.WithFlag("host", services.GetOptionalString(args, "host", "")).
+				WithBoolFlag("quiet")
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Create database
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_sql_databases_create",
+			Description: "Create a database on a Cloud SQL instance",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"instance", "database"},
+				"properties": map[string]any{
+					"instance": map[string]any{
+						"type":        "string",
+						"description": "Cloud SQL instance ID",
+					},
+					"database": map[string]any{
+						"type":        "string",
+						"description": "Name of the database",
+					},
+					"charset": map[string]any{
+						"type":        "string",
+						"description": "Character set of the database (e.g., utf8mb4, UTF8)",
+					},
+					"collation": map[string]any{
+						"type":        "string",
+						"description": "Collation of the database (e.g., utf8mb4_general_ci, en_US.UTF8)",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := instanceResourceCommand(base, "databases", "create", "database", args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			cmd.WithFlag("charset", services.GetOptionalString(args, "charset", "")).
+				WithFlag("collation", services.GetOptionalString(args, "collation", ""))
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Delete database
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_sql_databases_delete",
+			Description: "Delete a database from a Cloud SQL instance, including all of its data",
+			Annotations: services.Destructive(),
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"instance", "database"},
+				"properties": map[string]any{
+					"instance": map[string]any{
+						"type":        "string",
+						"description": "Cloud SQL instance ID",
+					},
+					"database": map[string]any{
+						"type":        "string",
+						"description": "Name of the database",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := instanceResourceCommand(base, "databases", "delete", "database", args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			cmd.WithBoolFlag("quiet")
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+}
+
+func parseArgs(req *mcp.CallToolRequest) map[string]any {
+	var args map[string]any
+	if req.Params.Arguments != nil {
+		_ = json.Unmarshal(req.Params.Arguments, &args)
+	}
+	if args == nil {
+		args = make(map[string]any)
+	}
+	return args
+}
+
+// csvColumns are the default column projections used when a list tool is
+// called with output=csv, keyed by resource.
+var csvColumns = map[string]string{
+	"backups":    "id,windowStartTime,type,status",
+	"operations": "name,operationType,startTime,endTime,status",
+	"users":      "name,host,type",
+}
+
+// instanceListCommand builds a `sql <resource> list` command for the
+// resources of the instance named in args.
+func instanceListCommand(base *services.BaseService, resource string, args map[string]any) (*executor.CommandBuilder, error) {
+	instance, err := services.GetRequiredString(args, "instance")
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := base.Executor.Command("sql", resource, "list").
+		WithFlag("instance", instance).
+		WithProject(services.GetOptionalString(args, "project", ""))
+	if limit := services.GetOptionalInt(args, "limit", 0); limit > 0 {
+		cmd.WithFlag("limit", strconv.Itoa(limit))
+	}
+	if err := services.ApplyListOutput(cmd, args, csvColumns[resource]); err != nil {
+		return nil, err
+	}
+	return cmd, nil
+}
+
+// instanceResourceCommand builds a `sql <resource> <action> NAME` command for
+// a user or database of the instance named in args, taking NAME from the
+// nameArg argument.
+func instanceResourceCommand(base *services.BaseService, resource, action, nameArg string, args map[string]any) (*executor.CommandBuilder, error) {
+	name, err := services.GetRequiredString(args, nameArg)
+	if err != nil {
+		return nil, err
+	}
+	instance, err := services.GetRequiredString(args, "instance")
+	if err != nil {
+		return nil, err
+	}
+
+	return base.Executor.Command("sql", resource, action, name).
+		WithFlag("instance", instance).
+		WithProject(services.GetOptionalString(args, "project", "")), nil
+}
+
+// userCreateCommand builds the `sql users create` command. The password is
+// passed as a secret flag so that it is redacted from the reported command.
+func userCreateCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	password, err := services.GetRequiredString(args, "password")
+	if err != nil {
+		return nil, err
+	}
+	cmd, err := instanceResourceCommand(base, "users", "create", "username", args)
+	if err != nil {
+		return nil, err
+	}
+
+	return cmd.WithSecretFlag("password", password).
+		WithFlag("host", services.GetOptionalString(args, "host", "")), nil
+}
+
+// backupRestoreCommand builds the `sql backups restore` command. The backup
+// is taken from the target instance unless backup_instance is given.
+func backupRestoreCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	backupID, err := services.GetRequiredString(args, "backup_id")
+	if err != nil {
+		return nil, err
+	}
+	instance, err := services.GetRequiredString(args, "instance")
+	if err != nil {
+		return nil, err
+	}
+
+	return base.Executor.Command("sql", "backups", "restore", backupID).
+		WithFlag("restore-instance", instance).
+		WithFlag("backup-instance", services.GetOptionalString(args, "backup_instance", "")).
+		WithProject(services.GetOptionalString(args, "project", "")).
+		WithBoolFlag("quiet"), nil
+}
+
+// instancePatchCommand builds the `sql instances patch` command. At least one
+// setting must be changed.
+func instancePatchCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	instance, err := services.GetRequiredString(args, "instance")
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := base.Executor.Command("sql", "instances", "patch", instance).
+		WithProject(services.GetOptionalString(args, "project", "")).
+		WithBoolFlag("quiet")
+	changed := false
+
+	if tier := services.GetOptionalString(args, "tier", ""); tier != "" {
+		cmd.WithFlag("tier", tier)
+		changed = true
+	}
+
+	flags := services.GetOptionalStringMap(args, "database_flags")
+	clearFlags := services.GetOptionalBool(args, "clear_database_flags", false)
+	if len(flags) > 0 && clearFlags {
+		return nil, fmt.Errorf("database_flags and clear_database_flags are mutually exclusive")
+	}
+	if len(flags) > 0 {
+		value, err := databaseFlags(flags)
+		if err != nil {
+			return nil, err
+		}
+		cmd.WithFlag("database-flags", value)
+		changed = true
+	}
+	if clearFlags {
+		cmd.WithBoolFlag("clear-database-flags")
+		changed = true
+	}
+
+	if start := services.GetOptionalString(args, "backup_start_time", ""); start != "" {
+		cmd.WithFlag("backup-start-time", start)
+		changed = true
+	}
+
+	if window, ok := args["maintenance_window"].(map[string]any); ok {
+		day := services.GetOptionalString(window, "day", "")
+		hour := services.GetOptionalInt(window, "hour", -1)
+		if day == "" || hour < 0 || hour > 23 {
+			return nil, fmt.Errorf("maintenance_window requires a day and an hour between 0 and 23")
+		}
+		cmd.WithFlag("maintenance-window-day", day).
+			WithFlag("maintenance-window-hour", strconv.Itoa(hour))
+		changed = true
+	}
+
+	if !changed {
+		return nil, fmt.Errorf("nothing to update: set tier, database_flags, clear_database_flags, backup_start_time or maintenance_window")
+	}
+	return cmd, nil
+}
+
+// flagDelimiters are the alternative delimiters tried, in order, when a
+// database flag value contains a comma.
+var flagDelimiters = []string{":", ";", "|", "#", "~"}
+
+// databaseFlags returns the --database-flags value for flags, sorted by name.
+// Flags with an empty value are passed by name alone. gcloud splits the value
+// on commas, so when a value contains one (e.g., a list of preloaded
+// libraries) the value is written with gcloud's ^DELIM^ alternative
+// delimiter syntax.
+func databaseFlags(flags map[string]string) (string, error) {
+	pairs := make([]string, 0, len(flags))
+	for name, value := range flags {
+		if value == "" {
+			pairs = append(pairs, name)
+		} else {
+			pairs = append(pairs, name+"="+value)
+		}
+	}
+	sort.Strings(pairs)
+
+	joined := strings.Join(pairs, "")
+	if !strings.Contains(joined, ",") {
+		return strings.Join(pairs, ","), nil
+	}
+	for _, delim := range flagDelimiters {
+		if !strings.Contains(joined, delim) {
+			return "^" + delim + "^" + strings.Join(pairs, delim), nil
+		}
+	}
+	return "", fmt.Errorf("database flag values contain commas and every supported delimiter (%s)", strings.Join(flagDelimiters, " "))
+}
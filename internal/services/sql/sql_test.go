@@ -0,0 +1,336 @@
+package sql
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"gcloud-go-mcp/internal/executor/executortest"
+	"gcloud-go-mcp/internal/services"
+	"gcloud-go-mcp/internal/services/servicetest"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func newTestBase() *services.BaseService {
+	return services.NewBaseService(servicetest.NewConfig())
+}
+
+func TestRegisterTools(t *testing.T) {
+	server := mcp.NewServer(
+		&mcp.Implementation{
+			Name:    "test-server",
+			Version: "0.0.1",
+		},
+		&mcp.ServerOptions{},
+	)
+
+	// Should not panic
+	RegisterTools(server, newTestBase())
+}
+
+func TestDatabaseFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   map[string]string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "sorted pairs",
+			flags: map[string]string{"max_connections": "200", "log_min_duration_statement": "500"},
+			want:  "log_min_duration_statement=500,max_connections=200",
+		},
+		{
+			name:  "flag without value",
+			flags: map[string]string{"skip_show_database": "", "general_log": "on"},
+			want:  "general_log=on,skip_show_database",
+		},
+		{
+			name:  "comma in value",
+			flags: map[string]string{"shared_preload_libraries": "pg_stat_statements,pgaudit", "max_connections": "200"},
+			want:  "^:^max_connections=200:shared_preload_libraries=pg_stat_statements,pgaudit",
+		},
+		{
+			name:  "comma and colon in values",
+			flags: map[string]string{"shared_preload_libraries": "pg_stat_statements,pgaudit", "log_line_prefix": "%m:%p"},
+			want:  "^;^log_line_prefix=%m:%p;shared_preload_libraries=pg_stat_statements,pgaudit",
+		},
+		{
+			name:    "no usable delimiter",
+			flags:   map[string]string{"a": ",:;|#~"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := databaseFlags(tt.flags)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("databaseFlags() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstancePatchCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		notWant []string
+		wantErr string
+	}{
+		{
+			name: "tier and flags",
+			args: map[string]any{
+				"instance":       "orders-db",
+				"tier":           "db-custom-2-7680",
+				"database_flags": map[string]any{"max_connections": float64(200), "cloudsql.iam_authentication": "on"},
+			},
+			want:    []string{"--tier=db-custom-2-7680", "--database-flags=cloudsql.iam_authentication=on,max_connections=200", "--quiet", "--project=test-project"},
+			notWant: []string{"--clear-database-flags", "--backup-start-time=", "--maintenance-window-day="},
+		},
+		{
+			name: "backup and maintenance windows",
+			args: map[string]any{
+				"instance":           "orders-db",
+				"backup_start_time":  "02:00",
+				"maintenance_window": map[string]any{"day": "SUN", "hour": float64(3)},
+			},
+			want:    []string{"--backup-start-time=02:00", "--maintenance-window-day=SUN", "--maintenance-window-hour=3"},
+			notWant: []string{"--tier=", "--database-flags="},
+		},
+		{
+			name:    "clear flags",
+			args:    map[string]any{"instance": "orders-db", "clear_database_flags": true},
+			want:    []string{"--clear-database-flags"},
+			notWant: []string{"--database-flags="},
+		},
+		{
+			name:    "flags and clear",
+			args:    map[string]any{"instance": "orders-db", "clear_database_flags": true, "database_flags": map[string]any{"a": "1"}},
+			wantErr: "mutually exclusive",
+		},
+		{
+			name:    "maintenance hour out of range",
+			args:    map[string]any{"instance": "orders-db", "maintenance_window": map[string]any{"day": "SUN", "hour": float64(24)}},
+			wantErr: "maintenance_window",
+		},
+		{
+			name:    "maintenance window without hour",
+			args:    map[string]any{"instance": "orders-db", "maintenance_window": map[string]any{"day": "SUN"}},
+			wantErr: "maintenance_window",
+		},
+		{
+			name:    "nothing to update",
+			args:    map[string]any{"instance": "orders-db"},
+			wantErr: "nothing to update",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := instancePatchCommand(newTestBase(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if !slices.Equal(args[:4], []string{"sql", "instances", "patch", "orders-db"}) {
+				t.Errorf("unexpected command %v", args)
+			}
+			if missing := executortest.Missing(args, tt.want...); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, args)
+			}
+			for _, prefix := range tt.notWant {
+				for _, arg := range args {
+					if strings.HasPrefix(arg, prefix) {
+						t.Errorf("unexpected %s in %v", arg, args)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestBackupRestoreCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		notWant []string
+		wantErr string
+	}{
+		{
+			name:    "same instance",
+			args:    map[string]any{"backup_id": "1712345678901", "instance": "orders-db"},
+			want:    []string{"--restore-instance=orders-db", "--quiet", "--project=test-project"},
+			notWant: []string{"--backup-instance="},
+		},
+		{
+			name: "from another instance",
+			args: map[string]any{"backup_id": "1712345678901", "instance": "orders-db-staging", "backup_instance": "orders-db"},
+			want: []string{"--restore-instance=orders-db-staging", "--backup-instance=orders-db", "--quiet"},
+		},
+		{
+			name:    "missing backup",
+			args:    map[string]any{"instance": "orders-db"},
+			wantErr: "backup_id",
+		},
+		{
+			name:    "missing target",
+			args:    map[string]any{"backup_id": "1712345678901"},
+			wantErr: "instance",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := backupRestoreCommand(newTestBase(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if !slices.Equal(args[:4], []string{"sql", "backups", "restore", "1712345678901"}) {
+				t.Errorf("unexpected command %v", args)
+			}
+			if missing := executortest.Missing(args, tt.want...); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, args)
+			}
+			for _, prefix := range tt.notWant {
+				for _, arg := range args {
+					if strings.HasPrefix(arg, prefix) {
+						t.Errorf("unexpected %s in %v", arg, args)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestInstanceListCommand(t *testing.T) {
+	cmd, err := instanceListCommand(newTestBase(), "operations", map[string]any{
+		"instance": "orders-db",
+		"limit":    float64(5),
+		"output":   "csv",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	args := cmd.Build()
+	if !slices.Equal(args[:3], []string{"sql", "operations", "list"}) {
+		t.Errorf("unexpected command %v", args)
+	}
+	want := []string{"--instance=orders-db", "--limit=5", "--format=csv(" + csvColumns["operations"] + ")"}
+	if missing := executortest.Missing(args, want...); len(missing) > 0 {
+		t.Errorf("missing %v in %v", missing, args)
+	}
+
+	if _, err := instanceListCommand(newTestBase(), "backups", map[string]any{}); err == nil {
+		t.Error("expected an error without an instance")
+	}
+}
+
+func TestUserCreateCommand(t *testing.T) {
+	cmd, err := userCreateCommand(newTestBase(), map[string]any{
+		"instance": "orders-db",
+		"username": "app",
+		"password": "s3cret!",
+		"host":     "%",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	args := cmd.Build()
+	if !slices.Equal(args[:4], []string{"sql", "users", "create", "app"}) {
+		t.Errorf("unexpected command %v", args)
+	}
+	want := []string{"--instance=orders-db", "--password=s3cret!", "--host=%", "--project=test-project"}
+	if missing := executortest.Missing(args, want...); len(missing) > 0 {
+		t.Errorf("missing %v in %v", missing, args)
+	}
+
+	for _, key := range []string{"instance", "username", "password"} {
+		args := map[string]any{"instance": "orders-db", "username": "app", "password": "s3cret!"}
+		delete(args, key)
+		if _, err := userCreateCommand(newTestBase(), args); err == nil || !strings.Contains(err.Error(), key) {
+			t.Errorf("expected an error for missing %s, got %v", key, err)
+		}
+	}
+}
+
+func TestToolCall_UsersCreateRedactsPassword(t *testing.T) {
+	auditPath := filepath.Join(t.TempDir(), "audit.log")
+	cfg := servicetest.NewConfig()
+	cfg.IncludeMetadata = true
+	cfg.AuditLogPath = auditPath
+
+	runner := &executortest.Runner{Stdout: "{}"}
+	result := servicetest.CallToolWithConfig(t, cfg, RegisterTools, runner, "gcp_sql_users_create", map[string]any{
+		"instance": "orders-db",
+		"username": "app",
+		"password": "s3cret!",
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+	if missing := executortest.Missing(runner.LastArgs(), "--password=s3cret!"); len(missing) > 0 {
+		t.Errorf("expected the password to be passed to gcloud, got %v", runner.LastArgs())
+	}
+
+	text := servicetest.Text(result)
+	if strings.Contains(text, "s3cret!") {
+		t.Errorf("expected the password to be redacted from the result, got %s", text)
+	}
+	if !strings.Contains(text, "--password=[REDACTED]") {
+		t.Errorf("expected the redacted flag in the result, got %s", text)
+	}
+
+	audit, err := os.ReadFile(auditPath)
+	if err != nil {
+		t.Fatalf("failed to read audit log: %v", err)
+	}
+	if strings.Contains(string(audit), "s3cret!") {
+		t.Errorf("expected the password to be redacted from the audit log, got %s", audit)
+	}
+}
+
+func TestToolCall_UsersCreateFailureRedactsPassword(t *testing.T) {
+	runner := &executortest.Runner{Stderr: "ERROR: (gcloud.sql.users.create) HTTPError 409: user already exists", Err: errors.New("exit status 1")}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_sql_users_create", map[string]any{
+		"instance": "orders-db",
+		"username": "app",
+		"password": "s3cret!",
+	})
+	if !result.IsError {
+		t.Fatal("expected an error result")
+	}
+	if text := servicetest.Text(result); strings.Contains(text, "s3cret!") {
+		t.Errorf("expected the password to be redacted from the error, got %s", text)
+	}
+}
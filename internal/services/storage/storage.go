@@ -5,7 +5,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 
+	"gcloud-go-mcp/internal/executor"
 	"gcloud-go-mcp/internal/services"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -13,7 +17,7 @@ import (
 // RegisterTools registers all Cloud Storage tools with the MCP server.
 func RegisterTools(server *mcp.Server, base *services.BaseService) {
 	// List buckets
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_storage_buckets_list",
 			Description: "List Cloud Storage buckets",
@@ -24,25 +28,31 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "string",
 						"description": "GCP project ID",
 					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
 				},
 			},
 		},
 		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			args := parseArgs(req)
 
-			result, err := base.Executor.Command("storage", "buckets", "list").
-				WithProject(services.GetOptionalString(args, "project", "")).
-				Execute(ctx)
+			cmd := base.Executor.Command("storage", "buckets", "list").
+				WithProject(services.GetOptionalString(args, "project", ""))
+
+			if err := services.ApplyListOutput(cmd, args, csvColumns["buckets"]); err != nil {
+				return services.ToolError(err), nil
+			}
 
+			result, err := cmd.Execute(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Describe bucket
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_storage_buckets_describe",
 			Description: "Get details of a bucket",
@@ -52,8 +62,9 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 				"properties": map[string]any{
 					"bucket": map[string]any{
 						"type":        "string",
-						"description": "Bucket name (without gs://)",
+						"description": "Bucket name (e.g., my-bucket or gs://my-bucket)",
 					},
+					"soft_not_found": services.SoftNotFoundProperty(),
 				},
 			},
 		},
@@ -64,19 +75,98 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 				return services.ToolError(err), nil
 			}
 
-			bucketURL := fmt.Sprintf("gs://%s", bucket)
+			bucketURL, err := NormalizeBucketURL(bucket)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
 			result, err := base.Executor.Command("storage", "buckets", "describe", bucketURL).
 				Execute(ctx)
 
+			if err != nil {
+				return services.DescribeError(args, result, err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Bucket usage
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_storage_buckets_usage",
+			Description: "Get the total size of a bucket from a summarized du, and optionally count its objects up to a limit",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"bucket"},
+				"properties": map[string]any{
+					"bucket": map[string]any{
+						"type":        "string",
+						"description": "Bucket name (e.g., my-bucket or gs://my-bucket)",
+					},
+					"count_objects": map[string]any{
+						"type":        "boolean",
+						"description": "Also count objects. This is expensive: it lists objects, stopping after max_objects",
+						"default":     false,
+					},
+					"max_objects": map[string]any{
+						"type":        "integer",
+						"description": "Maximum number of objects to list when counting; larger buckets report object_count_capped",
+						"default":     defaultMaxCountedObjects,
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			bucket, err := services.GetRequiredString(args, "bucket")
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			bucketURL, err := NormalizeBucketURL(bucket)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+
+			project := services.GetOptionalString(args, "project", "")
+			result, err := base.Executor.Command("storage", "du", bucketURL).
+				WithBoolFlag("summarize").
+				WithProject(project).
+				WithTextFormat().
+				Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			usage, err := parseDu(result.Stdout)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			usage.Bucket = bucket
+
+			if services.GetOptionalBool(args, "count_objects", false) {
+				cmd, maxObjects, err := objectCountCommand(base, bucketURL, project, args)
+				if err != nil {
+					return services.ToolError(err), nil
+				}
+				result, err := cmd.Execute(ctx)
+				if err != nil {
+					return services.ToolError(err), nil
+				}
+				count := countLines(result.Stdout)
+				usage.ObjectCountCapped = count > maxObjects
+				count = min(count, maxObjects)
+				usage.ObjectCount = &count
+			}
+			data, _ := json.MarshalIndent(usage, "", "  ")
+			return services.ToolResult(string(data)), nil
 		},
 	)
 
 	// Create bucket
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_storage_buckets_create",
 			Description: "Create a new bucket",
@@ -103,10 +193,15 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"description": "Enable uniform bucket-level access",
 						"default":     true,
 					},
+					"labels": map[string]any{
+						"type":        "object",
+						"description": "Labels for the bucket",
+					},
 					"project": map[string]any{
 						"type":        "string",
 						"description": "GCP project ID",
 					},
+					"if_not_exists": services.IfNotExistsProperty(),
 				},
 			},
 		},
@@ -117,7 +212,19 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 				return services.ToolError(err), nil
 			}
 
-			bucketURL := fmt.Sprintf("gs://%s", bucket)
+			bucketURL, err := NormalizeBucketURL(bucket)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			existing, err := base.ExistingResource(ctx, args, base.Executor.Command("storage", "buckets", "describe", bucketURL).
+				WithProject(services.GetOptionalString(args, "project", "")))
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			if existing != nil {
+				return existing, nil
+			}
+
 			cmd := base.Executor.Command("storage", "buckets", "create", bucketURL).
 				WithProject(services.GetOptionalString(args, "project", ""))
 
@@ -130,20 +237,24 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if services.GetOptionalBool(args, "uniform_bucket_level_access", true) {
 				cmd.WithBoolFlag("uniform-bucket-level-access")
 			}
+			if labels := base.Labels(args); labels != "" {
+				cmd.WithFlag("labels", labels)
+			}
 
 			result, err := cmd.Execute(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Delete bucket
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_storage_buckets_delete",
 			Description: "Delete a bucket (must be empty)",
+			Annotations: services.Destructive(),
 			InputSchema: map[string]any{
 				"type":     "object",
 				"required": []string{"bucket"},
@@ -162,7 +273,10 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 				return services.ToolError(err), nil
 			}
 
-			bucketURL := fmt.Sprintf("gs://%s", bucket)
+			bucketURL, err := NormalizeBucketURL(bucket)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
 			_, err = base.Executor.Command("storage", "buckets", "delete", bucketURL).
 				WithTextFormat().
 				Execute(ctx)
@@ -174,8 +288,267 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 		},
 	)
 
+	// Get bucket IAM policy
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_storage_buckets_get_iam_policy",
+			Description: "Get the IAM policy of a bucket",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"bucket"},
+				"properties": map[string]any{
+					"bucket": map[string]any{
+						"type":        "string",
+						"description": "Bucket name (e.g., my-bucket or gs://my-bucket)",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			bucket, err := services.GetRequiredString(args, "bucket")
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			bucketURL, err := NormalizeBucketURL(bucket)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			result, err := base.Executor.Command("storage", "buckets", "get-iam-policy", bucketURL).
+				WithProject(services.GetOptionalString(args, "project", "")).
+				Execute(ctx)
+
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Add bucket IAM binding
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_storage_buckets_add_iam_policy_binding",
+			Description: "Add an IAM policy binding to a bucket",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"bucket", "member", "role"},
+				"properties": map[string]any{
+					"bucket": map[string]any{
+						"type":        "string",
+						"description": "Bucket name (e.g., my-bucket or gs://my-bucket)",
+					},
+					"member": map[string]any{
+						"type":        "string",
+						"description": "Member (e.g., user:alice@example.com, serviceAccount:sa@project.iam.gserviceaccount.com)",
+					},
+					"role": map[string]any{
+						"type":        "string",
+						"description": "Role (e.g., roles/storage.objectViewer)",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := bucketIAMBindingCommand(base, "add-iam-policy-binding", args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Remove bucket IAM binding
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_storage_buckets_remove_iam_policy_binding",
+			Description: "Remove an IAM policy binding from a bucket",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"bucket", "member", "role"},
+				"properties": map[string]any{
+					"bucket": map[string]any{
+						"type":        "string",
+						"description": "Bucket name (e.g., my-bucket or gs://my-bucket)",
+					},
+					"member": map[string]any{
+						"type":        "string",
+						"description": "Member (e.g., user:alice@example.com, serviceAccount:sa@project.iam.gserviceaccount.com)",
+					},
+					"role": map[string]any{
+						"type":        "string",
+						"description": "Role (e.g., roles/storage.objectViewer)",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := bucketIAMBindingCommand(base, "remove-iam-policy-binding", args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// List bucket notifications
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_storage_buckets_notifications_list",
+			Description: "List the Pub/Sub notification configurations of a bucket",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"bucket"},
+				"properties": map[string]any{
+					"bucket": map[string]any{
+						"type":        "string",
+						"description": "Bucket name (e.g., my-bucket or gs://my-bucket)",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := notificationListCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Create bucket notification
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_storage_buckets_notifications_create",
+			Description: "Publish a Pub/Sub message for changes to a bucket's objects. The topic is created if it does not exist, and the bucket's service agent is granted permission to publish to it",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"bucket", "topic"},
+				"properties": map[string]any{
+					"bucket": map[string]any{
+						"type":        "string",
+						"description": "Bucket name (e.g., my-bucket or gs://my-bucket)",
+					},
+					"topic": map[string]any{
+						"type":        "string",
+						"description": "Pub/Sub topic ID or full name (projects/PROJECT/topics/TOPIC)",
+					},
+					"event_types": map[string]any{
+						"type":        "array",
+						"items":       map[string]any{"type": "string", "enum": notificationEventTypes},
+						"description": "Events that trigger a notification; all events when empty",
+					},
+					"payload_format": map[string]any{
+						"type":        "string",
+						"description": "Message payload: the object metadata as JSON, or none",
+						"enum":        []string{"json", "none"},
+						"default":     "json",
+					},
+					"object_prefix": map[string]any{
+						"type":        "string",
+						"description": "Only notify for objects whose names start with this prefix",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := notificationCreateCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Delete bucket notification
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_storage_buckets_notifications_delete",
+			Description: "Delete a Pub/Sub notification configuration from a bucket",
+			Annotations: services.Destructive(),
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"bucket", "notification_id"},
+				"properties": map[string]any{
+					"bucket": map[string]any{
+						"type":        "string",
+						"description": "Bucket name (e.g., my-bucket or gs://my-bucket)",
+					},
+					"notification_id": map[string]any{
+						"type":        "string",
+						"description": "ID of the notification configuration (from gcp_storage_buckets_notifications_list)",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := notificationDeleteCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			_, err = cmd.WithTextFormat().Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return services.ToolResult("Notification deleted successfully"), nil
+		},
+	)
+
 	// List objects
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_storage_objects_list",
 			Description: "List objects in a bucket",
@@ -206,9 +579,12 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 				return services.ToolError(err), nil
 			}
 
-			bucketURL := fmt.Sprintf("gs://%s", bucket)
+			bucketURL, err := NormalizeBucketURL(bucket)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
 			if prefix := services.GetOptionalString(args, "prefix", ""); prefix != "" {
-				bucketURL = fmt.Sprintf("gs://%s/%s", bucket, prefix)
+				bucketURL += "/" + strings.TrimPrefix(prefix, "/")
 			}
 
 			// Note: gcloud storage ls doesn't have a direct limit flag
@@ -220,15 +596,15 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.ToJSONString()), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Cat object
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_storage_objects_cat",
-			Description: "Display contents of an object",
+			Description: "Display contents of an object, or of a byte range of it",
 			InputSchema: map[string]any{
 				"type":     "object",
 				"required": []string{"url"},
@@ -237,29 +613,38 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 						"type":        "string",
 						"description": "Object URL (gs://bucket/path/to/object)",
 					},
+					"start_byte": map[string]any{
+						"type":        "integer",
+						"description": "First byte to display, counted from 0",
+					},
+					"end_byte": map[string]any{
+						"type":        "integer",
+						"description": "Last byte to display, inclusive (defaults to the end of the object)",
+					},
+					"max_bytes": map[string]any{
+						"type":        "integer",
+						"description": "Display at most this many bytes from start_byte (e.g., 4096 for the head of a large log)",
+					},
 				},
 			},
 		},
 		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			args := parseArgs(req)
-			url, err := services.GetRequiredString(args, "url")
+			cmd, err := catCommand(base, args)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
 
-			result, err := base.Executor.Command("storage", "cat", url).
-				WithTextFormat().
-				Execute(ctx)
-
+			result, err := cmd.Execute(ctx)
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.Stdout), nil
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Copy objects
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_storage_objects_copy",
 			Description: "Copy objects between buckets or within a bucket",
@@ -307,15 +692,169 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if result.Stdout == "" {
 				return services.ToolResult("Copy completed successfully"), nil
 			}
-			return services.ToolResult(result.Stdout), nil
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Compose objects
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_storage_objects_compose",
+			Description: "Concatenate objects in a bucket into a single destination object (e.g., to assemble a multipart upload)",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"sources", "destination"},
+				"properties": map[string]any{
+					"sources": map[string]any{
+						"type":        "array",
+						"description": "Source object URLs in the order they are concatenated (2-32 gs:// URLs in the destination bucket)",
+						"items":       map[string]any{"type": "string"},
+					},
+					"destination": map[string]any{
+						"type":        "string",
+						"description": "Destination object URL (gs://bucket/object)",
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := composeCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.WithTextFormat().Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			if result.Stdout == "" {
+				return services.ToolResult("Compose completed successfully"), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Update object metadata
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_storage_objects_update",
+			Description: "Update the metadata of existing objects (e.g., Content-Type or Cache-Control of static assets) without re-uploading them",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"url"},
+				"properties": map[string]any{
+					"url": map[string]any{
+						"type":        "string",
+						"description": "Object URL (gs://bucket/object); wildcards such as gs://bucket/assets/*.css are allowed",
+					},
+					"content_type": map[string]any{
+						"type":        "string",
+						"description": "Content-Type (e.g., text/css)",
+					},
+					"cache_control": map[string]any{
+						"type":        "string",
+						"description": "Cache-Control (e.g., public, max-age=3600)",
+					},
+					"content_encoding": map[string]any{
+						"type":        "string",
+						"description": "Content-Encoding (e.g., gzip)",
+					},
+					"metadata": map[string]any{
+						"type":        "object",
+						"description": "Custom metadata to add or update as key-value pairs",
+					},
+					"remove_metadata": map[string]any{
+						"type":        "array",
+						"description": "Custom metadata keys to remove",
+						"items":       map[string]any{"type": "string"},
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := objectUpdateCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			if result.Stdout == "" {
+				return services.ToolResult("Object metadata updated successfully"), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Sync directories
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_storage_rsync",
+			Description: "Synchronize the contents of a source directory or bucket prefix to a destination",
+			Annotations: services.Destructive(),
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"source", "destination"},
+				"properties": map[string]any{
+					"source": map[string]any{
+						"type":        "string",
+						"description": "Source URL (gs://bucket/prefix or local directory)",
+					},
+					"destination": map[string]any{
+						"type":        "string",
+						"description": "Destination URL (gs://bucket/prefix or local directory)",
+					},
+					"recursive": map[string]any{
+						"type":        "boolean",
+						"description": "Sync subdirectories recursively",
+						"default":     true,
+					},
+					"delete_unmatched": map[string]any{
+						"type":        "boolean",
+						"description": "Delete destination objects that don't exist in the source. Requires confirm_delete unless dry_run is set",
+						"default":     false,
+					},
+					"confirm_delete": map[string]any{
+						"type":        "boolean",
+						"description": "Confirm that unmatched destination objects should be deleted",
+						"default":     false,
+					},
+					"dry_run": map[string]any{
+						"type":        "boolean",
+						"description": "List the changes that would be made without making them",
+						"default":     false,
+					},
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := rsyncCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.WithTextFormat().Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			if result.Stdout == "" {
+				return services.ToolResult("Sync completed successfully"), nil
+			}
+			return base.CommandResult(result), nil
 		},
 	)
 
 	// Delete objects
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_storage_objects_delete",
 			Description: "Delete objects",
+			Annotations: services.Destructive(),
 			InputSchema: map[string]any{
 				"type":     "object",
 				"required": []string{"url"},
@@ -338,6 +877,10 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
+			url, err = NormalizeObjectURL(url)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
 
 			cmd := base.Executor.Command("storage", "rm", url)
 
@@ -354,7 +897,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 	)
 
 	// Generate signed URL
-	server.AddTool(
+	base.AddTool(server,
 		&mcp.Tool{
 			Name:        "gcp_storage_objects_signed_url",
 			Description: "Generate a signed URL for an object",
@@ -386,6 +929,10 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
+			url, err = NormalizeObjectURL(url)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
 
 			result, err := base.Executor.Command("storage", "sign-url", url).
 				WithFlag("duration", services.GetOptionalString(args, "duration", "1h")).
@@ -396,7 +943,7 @@ func RegisterTools(server *mcp.Server, base *services.BaseService) {
 			if err != nil {
 				return services.ToolError(err), nil
 			}
-			return services.ToolResult(result.Stdout), nil
+			return base.CommandResult(result), nil
 		},
 	)
 }
@@ -411,3 +958,348 @@ func parseArgs(req *mcp.CallToolRequest) map[string]any {
 	}
 	return args
 }
+
+// csvColumns are the default column projections used when a list tool is
+// called with output=csv, keyed by resource.
+var csvColumns = map[string]string{
+	"buckets":       "name,location,default_storage_class,creation_time",
+	"notifications": `"Notification Configuration".id:label=ID,"Notification Configuration".topic:label=TOPIC,"Notification Configuration".payload_format:label=PAYLOAD_FORMAT`,
+}
+
+// bucketUsage is the result of gcp_storage_buckets_usage.
+type bucketUsage struct {
+	Bucket            string `json:"bucket"`
+	TotalBytes        int64  `json:"total_bytes"`
+	ObjectCount       *int   `json:"object_count,omitempty"`
+	ObjectCountCapped bool   `json:"object_count_capped,omitempty"`
+}
+
+// defaultMaxCountedObjects is the default number of objects
+// gcp_storage_buckets_usage lists when counting objects.
+const defaultMaxCountedObjects = 10000
+
+// maxCountedObjects caps the max_objects argument, as the listed names are
+// held in memory.
+const maxCountedObjects = 1000000
+
+// parseDu parses the output of `storage du --summarize`, a size in bytes
+// followed by the bucket URL.
+func parseDu(output string) (*bucketUsage, error) {
+	usage := &bucketUsage{}
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("unexpected du output line %q", line)
+		}
+		size, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected du output line %q", line)
+		}
+		usage.TotalBytes += size
+	}
+	return usage, nil
+}
+
+// objectCountCommand builds a command that lists the names of the objects in
+// a bucket, one per line. It lists one object more than max_objects, which it
+// returns, so that a capped count can be told from an exact one.
+func objectCountCommand(base *services.BaseService, bucketURL, project string, args map[string]any) (*executor.CommandBuilder, int, error) {
+	maxObjects := services.GetOptionalInt(args, "max_objects", defaultMaxCountedObjects)
+	if maxObjects < 1 || maxObjects > maxCountedObjects {
+		return nil, 0, fmt.Errorf("max_objects must be between 1 and %d, got %d", maxCountedObjects, maxObjects)
+	}
+	cmd := base.Executor.Command("storage", "objects", "list", strings.TrimSuffix(bucketURL, "/")+"/**").
+		WithFlag("limit", strconv.Itoa(maxObjects+1)).
+		WithProject(project).
+		WithFormat("value(name)")
+	return cmd, maxObjects, nil
+}
+
+// countLines returns the number of non-empty lines in output.
+func countLines(output string) int {
+	count := 0
+	for _, line := range strings.Split(output, "\n") {
+		if strings.TrimSpace(line) != "" {
+			count++
+		}
+	}
+	return count
+}
+
+// bucketIAMBindingCommand builds an add or remove IAM policy binding command
+// for the bucket named in args.
+func bucketIAMBindingCommand(base *services.BaseService, action string, args map[string]any) (*executor.CommandBuilder, error) {
+	bucket, err := services.GetRequiredString(args, "bucket")
+	if err != nil {
+		return nil, err
+	}
+	member, err := services.GetRequiredString(args, "member")
+	if err != nil {
+		return nil, err
+	}
+	role, err := services.GetRequiredString(args, "role")
+	if err != nil {
+		return nil, err
+	}
+
+	bucketURL, err := NormalizeBucketURL(bucket)
+	if err != nil {
+		return nil, err
+	}
+	return base.Executor.Command("storage", "buckets", action, bucketURL).
+		WithFlag("member", member).
+		WithFlag("role", role).
+		WithProject(services.GetOptionalString(args, "project", "")), nil
+}
+
+// notificationEventTypes are the object events a bucket notification can be
+// limited to.
+var notificationEventTypes = []string{"OBJECT_FINALIZE", "OBJECT_METADATA_UPDATE", "OBJECT_DELETE", "OBJECT_ARCHIVE"}
+
+// notificationListCommand builds the `storage buckets notifications list`
+// command. Each listed entry nests the notification under "Notification
+// Configuration", which the csv columns select from.
+func notificationListCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	bucket, err := services.GetRequiredString(args, "bucket")
+	if err != nil {
+		return nil, err
+	}
+	bucketURL, err := NormalizeBucketURL(bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := base.Executor.Command("storage", "buckets", "notifications", "list", bucketURL).
+		WithProject(services.GetOptionalString(args, "project", ""))
+	if err := services.ApplyListOutput(cmd, args, csvColumns["notifications"]); err != nil {
+		return nil, err
+	}
+	return cmd, nil
+}
+
+// notificationCreateCommand builds the `storage buckets notifications create`
+// command, passing the event types as a single comma-separated flag.
+func notificationCreateCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	bucket, err := services.GetRequiredString(args, "bucket")
+	if err != nil {
+		return nil, err
+	}
+	bucketURL, err := NormalizeBucketURL(bucket)
+	if err != nil {
+		return nil, err
+	}
+	topic, err := services.GetRequiredString(args, "topic")
+	if err != nil {
+		return nil, err
+	}
+
+	var eventTypes []string
+	for _, eventType := range services.GetOptionalStringArray(args, "event_types") {
+		eventType = strings.ToUpper(eventType)
+		if !slices.Contains(notificationEventTypes, eventType) {
+			return nil, fmt.Errorf("invalid event type %q: must be one of %s", eventType, strings.Join(notificationEventTypes, ", "))
+		}
+		if !slices.Contains(eventTypes, eventType) {
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+	payloadFormat := services.GetOptionalString(args, "payload_format", "json")
+	if payloadFormat != "json" && payloadFormat != "none" {
+		return nil, fmt.Errorf("invalid payload_format %q: must be json or none", payloadFormat)
+	}
+
+	return base.Executor.Command("storage", "buckets", "notifications", "create", bucketURL).
+		WithFlag("topic", topic).
+		WithFlag("event-types", strings.Join(eventTypes, ",")).
+		WithFlag("payload-format", payloadFormat).
+		WithFlag("object-prefix", services.GetOptionalString(args, "object_prefix", "")).
+		WithProject(services.GetOptionalString(args, "project", "")), nil
+}
+
+// notificationDeleteCommand builds the `storage buckets notifications delete`
+// command for a single notification. Passing only the bucket would delete
+// all of its notifications.
+func notificationDeleteCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	bucket, err := services.GetRequiredString(args, "bucket")
+	if err != nil {
+		return nil, err
+	}
+	bucketURL, err := NormalizeBucketURL(bucket)
+	if err != nil {
+		return nil, err
+	}
+	id, err := services.GetRequiredString(args, "notification_id")
+	if err != nil {
+		return nil, err
+	}
+
+	name := fmt.Sprintf("projects/_/buckets/%s/notificationConfigs/%s", strings.TrimPrefix(bucketURL, "gs://"), id)
+	return base.Executor.Command("storage", "buckets", "notifications", "delete", name).
+		WithProject(services.GetOptionalString(args, "project", "")), nil
+}
+
+// catCommand builds a storage cat command, limited to the byte range given by
+// start_byte, end_byte and max_bytes.
+func catCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	url, err := services.GetRequiredString(args, "url")
+	if err != nil {
+		return nil, err
+	}
+	url, err = NormalizeObjectURL(url)
+	if err != nil {
+		return nil, err
+	}
+	byteRange, err := catRange(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return base.Executor.Command("storage", "cat", url).
+		WithFlag("range", byteRange).
+		WithTextFormat(), nil
+}
+
+// catRange returns the --range value (START-END or START-, both inclusive)
+// for the start_byte, end_byte and max_bytes arguments, or an empty string to
+// display the whole object. max_bytes lowers end_byte when both are given.
+func catRange(args map[string]any) (string, error) {
+	_, hasStart := args["start_byte"]
+	_, hasEnd := args["end_byte"]
+	_, hasMax := args["max_bytes"]
+	if !hasStart && !hasEnd && !hasMax {
+		return "", nil
+	}
+
+	start := services.GetOptionalInt(args, "start_byte", 0)
+	if start < 0 {
+		return "", fmt.Errorf("start_byte must not be negative, got %d", start)
+	}
+	end := -1
+	if hasEnd {
+		end = services.GetOptionalInt(args, "end_byte", 0)
+		if end < start {
+			return "", fmt.Errorf("end_byte (%d) must not be less than start_byte (%d)", end, start)
+		}
+	}
+	if hasMax {
+		maxBytes := services.GetOptionalInt(args, "max_bytes", 0)
+		if maxBytes < 1 {
+			return "", fmt.Errorf("max_bytes must be at least 1, got %d", maxBytes)
+		}
+		if last := start + maxBytes - 1; end < 0 || last < end {
+			end = last
+		}
+	}
+
+	if end < 0 {
+		return fmt.Sprintf("%d-", start), nil
+	}
+	return fmt.Sprintf("%d-%d", start, end), nil
+}
+
+// maxComposeSources is the most source objects a single compose request
+// accepts.
+const maxComposeSources = 32
+
+// composeCommand builds a storage objects compose command. Cloud Storage
+// requires the sources and destination to be in the same bucket.
+func composeCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	sources := services.GetOptionalStringArray(args, "sources")
+	if len(sources) < 2 {
+		return nil, fmt.Errorf("at least two sources are required, got %d", len(sources))
+	}
+	if len(sources) > maxComposeSources {
+		return nil, fmt.Errorf("at most %d sources can be composed, got %d", maxComposeSources, len(sources))
+	}
+	destination, err := services.GetRequiredString(args, "destination")
+	if err != nil {
+		return nil, err
+	}
+
+	destination, err = NormalizeObjectURL(destination)
+	if err != nil {
+		return nil, fmt.Errorf("invalid destination: %w", err)
+	}
+	bucket, _, _ := splitObjectURL(destination)
+
+	components := []string{"storage", "objects", "compose"}
+	for _, source := range sources {
+		url, err := NormalizeObjectURL(source)
+		if err != nil {
+			return nil, fmt.Errorf("invalid source: %w", err)
+		}
+		if !strings.HasPrefix(url, "gs://"+bucket+"/") {
+			return nil, fmt.Errorf("source %s is not in the destination bucket %s", source, bucket)
+		}
+		components = append(components, url)
+	}
+
+	return base.Executor.Command(append(components, destination)...), nil
+}
+
+// objectUpdateCommand builds a storage objects update command that changes
+// object metadata. Custom metadata is set with --update-custom-metadata, so
+// keys that are not mentioned are kept.
+func objectUpdateCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	url, err := services.GetRequiredString(args, "url")
+	if err != nil {
+		return nil, err
+	}
+	url, err = NormalizeObjectURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url: %w", err)
+	}
+
+	contentType := services.GetOptionalString(args, "content_type", "")
+	cacheControl := services.GetOptionalString(args, "cache_control", "")
+	contentEncoding := services.GetOptionalString(args, "content_encoding", "")
+	metadata := services.GetOptionalStringMap(args, "metadata")
+	remove := services.GetOptionalStringArray(args, "remove_metadata")
+	if contentType == "" && cacheControl == "" && contentEncoding == "" && len(metadata) == 0 && len(remove) == 0 {
+		return nil, fmt.Errorf("at least one of content_type, cache_control, content_encoding, metadata or remove_metadata is required")
+	}
+
+	return base.Executor.Command("storage", "objects", "update", url).
+		WithFlag("content-type", contentType).
+		WithFlag("cache-control", cacheControl).
+		WithFlag("content-encoding", contentEncoding).
+		WithFlag("update-custom-metadata", services.FormatLabels(metadata)).
+		WithFlag("remove-custom-metadata", strings.Join(remove, ",")), nil
+}
+
+// rsyncCommand builds a storage rsync command. Deleting unmatched
+// destination objects must be confirmed explicitly unless it is a dry run.
+func rsyncCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	source, err := services.GetRequiredString(args, "source")
+	if err != nil {
+		return nil, err
+	}
+	destination, err := services.GetRequiredString(args, "destination")
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSuffix(source, "/") == strings.TrimSuffix(destination, "/") {
+		return nil, fmt.Errorf("source and destination must differ")
+	}
+
+	dryRun := services.GetOptionalBool(args, "dry_run", false)
+	deleteUnmatched := services.GetOptionalBool(args, "delete_unmatched", false)
+	if deleteUnmatched && !dryRun && !services.GetOptionalBool(args, "confirm_delete", false) {
+		return nil, fmt.Errorf("delete_unmatched removes objects from %s that are not in %s; set confirm_delete to true to proceed", destination, source)
+	}
+
+	cmd := base.Executor.Command("storage", "rsync", source, destination)
+	if services.GetOptionalBool(args, "recursive", true) {
+		cmd.WithBoolFlag("recursive")
+	}
+	if deleteUnmatched {
+		cmd.WithBoolFlag("delete-unmatched-destination-objects")
+	}
+	if dryRun {
+		cmd.WithBoolFlag("dry-run")
+	}
+	return cmd, nil
+}
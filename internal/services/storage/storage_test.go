@@ -0,0 +1,663 @@
+package storage
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"gcloud-go-mcp/internal/executor"
+	"gcloud-go-mcp/internal/executor/executortest"
+	"gcloud-go-mcp/internal/services"
+	"gcloud-go-mcp/internal/services/servicetest"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func newTestBase() *services.BaseService {
+	return services.NewBaseService(servicetest.NewConfig())
+}
+
+func TestRegisterTools(t *testing.T) {
+	server := mcp.NewServer(
+		&mcp.Implementation{
+			Name:    "test-server",
+			Version: "0.0.1",
+		},
+		&mcp.ServerOptions{},
+	)
+
+	// Should not panic
+	RegisterTools(server, newTestBase())
+}
+
+func TestBucketIAMBindingCommand(t *testing.T) {
+	args := map[string]any{
+		"bucket": "my-bucket",
+		"member": "user:alice@example.com",
+		"role":   "roles/storage.objectViewer",
+	}
+
+	for _, action := range []string{"add-iam-policy-binding", "remove-iam-policy-binding"} {
+		t.Run(action, func(t *testing.T) {
+			cmd, err := bucketIAMBindingCommand(newTestBase(), action, args)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			built := cmd.Build()
+			if !slices.Equal(built[:4], []string{"storage", "buckets", action, "gs://my-bucket"}) {
+				t.Errorf("expected command on bucket URL, got %v", built)
+			}
+			for _, w := range []string{"--member=user:alice@example.com", "--role=roles/storage.objectViewer"} {
+				if !slices.Contains(built, w) {
+					t.Errorf("expected %q in args, got %v", w, built)
+				}
+			}
+		})
+	}
+}
+
+func TestBucketIAMBindingCommand_MissingParams(t *testing.T) {
+	for _, missing := range []string{"bucket", "member", "role"} {
+		args := map[string]any{
+			"bucket": "my-bucket",
+			"member": "user:alice@example.com",
+			"role":   "roles/storage.objectViewer",
+		}
+		delete(args, missing)
+
+		_, err := bucketIAMBindingCommand(newTestBase(), "add-iam-policy-binding", args)
+		if err == nil || !strings.Contains(err.Error(), missing) {
+			t.Errorf("expected error mentioning %q, got %v", missing, err)
+		}
+	}
+}
+
+func TestToolCall_BucketsAddIAMPolicyBinding(t *testing.T) {
+	runner := &executortest.Runner{}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_storage_buckets_add_iam_policy_binding", map[string]any{
+		"bucket": "my-bucket",
+		"member": "user:alice@example.com",
+		"role":   "roles/storage.objectViewer",
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+
+	args := runner.LastArgs()
+	if len(args) < 4 || !slices.Equal(args[:4], []string{"storage", "buckets", "add-iam-policy-binding", "gs://my-bucket"}) {
+		t.Errorf("unexpected command %v", args)
+	}
+	if missing := executortest.Missing(args, "--member=user:alice@example.com", "--role=roles/storage.objectViewer"); len(missing) > 0 {
+		t.Errorf("missing %v in %v", missing, args)
+	}
+}
+
+func TestRsyncCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		notWant []string
+		wantErr string
+	}{
+		{
+			name:    "recursive by default",
+			args:    map[string]any{"source": "./site", "destination": "gs://my-bucket/site"},
+			want:    []string{"--recursive"},
+			notWant: []string{"--delete-unmatched-destination-objects", "--dry-run"},
+		},
+		{
+			name:    "non-recursive",
+			args:    map[string]any{"source": "./site", "destination": "gs://my-bucket/site", "recursive": false},
+			notWant: []string{"--recursive"},
+		},
+		{
+			name: "delete with confirmation",
+			args: map[string]any{
+				"source":           "./site",
+				"destination":      "gs://my-bucket/site",
+				"delete_unmatched": true,
+				"confirm_delete":   true,
+			},
+			want: []string{"--recursive", "--delete-unmatched-destination-objects"},
+		},
+		{
+			name: "delete dry run needs no confirmation",
+			args: map[string]any{
+				"source":           "./site",
+				"destination":      "gs://my-bucket/site",
+				"delete_unmatched": true,
+				"dry_run":          true,
+			},
+			want: []string{"--delete-unmatched-destination-objects", "--dry-run"},
+		},
+		{
+			name: "delete without confirmation",
+			args: map[string]any{
+				"source":           "./site",
+				"destination":      "gs://my-bucket/site",
+				"delete_unmatched": true,
+			},
+			wantErr: "confirm_delete",
+		},
+		{
+			name:    "same source and destination",
+			args:    map[string]any{"source": "gs://my-bucket/site/", "destination": "gs://my-bucket/site"},
+			wantErr: "must differ",
+		},
+		{
+			name:    "missing destination",
+			args:    map[string]any{"source": "./site"},
+			wantErr: "destination",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := rsyncCommand(newTestBase(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			built := cmd.Build()
+			if !slices.Equal(built[:4], []string{"storage", "rsync", tt.args["source"].(string), tt.args["destination"].(string)}) {
+				t.Errorf("unexpected command %v", built)
+			}
+			for _, w := range tt.want {
+				if !slices.Contains(built, w) {
+					t.Errorf("expected %q in args, got %v", w, built)
+				}
+			}
+			for _, nw := range tt.notWant {
+				if slices.Contains(built, nw) {
+					t.Errorf("did not expect %q in args, got %v", nw, built)
+				}
+			}
+		})
+	}
+}
+
+func TestComposeCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "three sources in order",
+			args: map[string]any{
+				"sources":     []any{"gs://uploads/big.part1", "gs://uploads/big.part2", "gs://uploads/big.part3"},
+				"destination": "gs://uploads/big.bin",
+			},
+			want: []string{
+				"storage", "objects", "compose",
+				"gs://uploads/big.part1", "gs://uploads/big.part2", "gs://uploads/big.part3",
+				"gs://uploads/big.bin",
+			},
+		},
+		{
+			name: "bare paths are normalized",
+			args: map[string]any{
+				"sources":     []any{"uploads/a", "gs://uploads/b"},
+				"destination": "uploads/ab",
+			},
+			want: []string{"storage", "objects", "compose", "gs://uploads/a", "gs://uploads/b", "gs://uploads/ab"},
+		},
+		{
+			name:    "single source",
+			args:    map[string]any{"sources": []any{"gs://uploads/a"}, "destination": "gs://uploads/b"},
+			wantErr: "at least two sources",
+		},
+		{
+			name:    "missing sources",
+			args:    map[string]any{"destination": "gs://uploads/b"},
+			wantErr: "at least two sources",
+		},
+		{
+			name:    "different bucket",
+			args:    map[string]any{"sources": []any{"gs://uploads/a", "gs://other/b"}, "destination": "gs://uploads/c"},
+			wantErr: "not in the destination bucket",
+		},
+		{
+			name:    "local source",
+			args:    map[string]any{"sources": []any{"gs://uploads/a", "./b"}, "destination": "gs://uploads/c"},
+			wantErr: "invalid source",
+		},
+		{
+			name:    "bucket destination",
+			args:    map[string]any{"sources": []any{"gs://uploads/a", "gs://uploads/b"}, "destination": "gs://uploads"},
+			wantErr: "invalid destination",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := composeCommand(newTestBase(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if built := cmd.Build(); !slices.Equal(built[:len(tt.want)], tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, built)
+			}
+		})
+	}
+}
+
+func TestComposeCommand_TooManySources(t *testing.T) {
+	sources := make([]any, maxComposeSources+1)
+	for i := range sources {
+		sources[i] = fmt.Sprintf("gs://uploads/part%d", i)
+	}
+	_, err := composeCommand(newTestBase(), map[string]any{"sources": sources, "destination": "gs://uploads/all"})
+	if err == nil || !strings.Contains(err.Error(), "at most") {
+		t.Errorf("expected too many sources error, got %v", err)
+	}
+}
+
+func TestObjectUpdateCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "content type and cache control",
+			args: map[string]any{
+				"url":           "gs://site/assets/*.css",
+				"content_type":  "text/css",
+				"cache_control": "public, max-age=3600",
+			},
+			want: []string{"--content-type=text/css", "--cache-control=public, max-age=3600"},
+		},
+		{
+			name: "custom metadata",
+			args: map[string]any{
+				"url":             "gs://site/index.html",
+				"metadata":        map[string]any{"owner": "web", "build": "1234"},
+				"remove_metadata": []any{"stale", "legacy"},
+			},
+			want: []string{"--update-custom-metadata=build=1234,owner=web", "--remove-custom-metadata=stale,legacy"},
+		},
+		{
+			name:    "nothing to update",
+			args:    map[string]any{"url": "gs://site/index.html"},
+			wantErr: "at least one of",
+		},
+		{
+			name:    "bucket url",
+			args:    map[string]any{"url": "gs://site", "content_type": "text/html"},
+			wantErr: "invalid url",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := objectUpdateCommand(newTestBase(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			built := cmd.Build()
+			if !slices.Equal(built[:4], []string{"storage", "objects", "update", tt.args["url"].(string)}) {
+				t.Errorf("unexpected command %v", built)
+			}
+			if missing := executortest.Missing(built, tt.want...); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, built)
+			}
+		})
+	}
+}
+
+func TestParseDu(t *testing.T) {
+	tests := []struct {
+		name      string
+		output    string
+		wantBytes int64
+		wantErr   bool
+	}{
+		{
+			name:      "summary",
+			output:    "1073741824   gs://my-bucket\n",
+			wantBytes: 1073741824,
+		},
+		{
+			name:      "empty bucket summary",
+			output:    "0  gs://my-bucket",
+			wantBytes: 0,
+		},
+		{
+			name:    "unexpected output",
+			output:  "ERROR something went wrong",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDu(tt.output)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.TotalBytes != tt.wantBytes {
+				t.Errorf("expected %d bytes, got %d", tt.wantBytes, got.TotalBytes)
+			}
+			if got.ObjectCount != nil {
+				t.Errorf("expected no object count, got %d", *got.ObjectCount)
+			}
+		})
+	}
+}
+
+func TestToolCall_BucketsUsage(t *testing.T) {
+	runner := &executortest.Runner{Stdout: "2048  gs://my-bucket\n"}
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_storage_buckets_usage", map[string]any{
+		"bucket": "my-bucket",
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", servicetest.Text(result))
+	}
+
+	args := runner.LastArgs()
+	if !slices.Equal(args[:3], []string{"storage", "du", "gs://my-bucket"}) || !slices.Contains(args, "--summarize") {
+		t.Errorf("expected a summarized du, got %v", args)
+	}
+	if text := servicetest.Text(result); !strings.Contains(text, `"total_bytes": 2048`) {
+		t.Errorf("unexpected result %q", text)
+	}
+}
+
+func TestToolCall_BucketsUsageCountObjects(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       map[string]any
+		objects    string
+		wantLimit  string
+		wantResult []string
+		wantErr    string
+	}{
+		{
+			name:       "exact count",
+			args:       map[string]any{"bucket": "my-bucket", "count_objects": true},
+			objects:    "a.txt\nlogs/b.log\n",
+			wantLimit:  "--limit=10001",
+			wantResult: []string{`"object_count": 2`},
+		},
+		{
+			name:       "capped count",
+			args:       map[string]any{"bucket": "my-bucket", "count_objects": true, "max_objects": float64(2)},
+			objects:    "a.txt\nb.txt\nc.txt\n",
+			wantLimit:  "--limit=3",
+			wantResult: []string{`"object_count": 2`, `"object_count_capped": true`},
+		},
+		{
+			name:    "invalid max_objects",
+			args:    map[string]any{"bucket": "my-bucket", "count_objects": true, "max_objects": float64(0)},
+			wantErr: "max_objects must be between",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &executortest.Runner{Handler: func(args []string) (*executor.Result, error) {
+				if args[1] == "du" {
+					return &executor.Result{Stdout: "2048  gs://my-bucket\n"}, nil
+				}
+				return &executor.Result{Stdout: tt.objects}, nil
+			}}
+			result := servicetest.CallTool(t, RegisterTools, runner, "gcp_storage_buckets_usage", tt.args)
+			text := servicetest.Text(result)
+			if tt.wantErr != "" {
+				if !result.IsError || !strings.Contains(text, tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %q", tt.wantErr, text)
+				}
+				return
+			}
+			if result.IsError {
+				t.Fatalf("unexpected error: %s", text)
+			}
+
+			calls := runner.Calls()
+			if len(calls) != 2 || !slices.Contains(calls[0], "--summarize") {
+				t.Fatalf("expected a summarized du and an object listing, got %v", calls)
+			}
+			if !slices.Equal(calls[1][:4], []string{"storage", "objects", "list", "gs://my-bucket/**"}) {
+				t.Errorf("unexpected listing %v", calls[1])
+			}
+			if missing := executortest.Missing(calls[1], tt.wantLimit, "--format=value(name)"); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, calls[1])
+			}
+			for _, want := range append(tt.wantResult, `"total_bytes": 2048`) {
+				if !strings.Contains(text, want) {
+					t.Errorf("expected %s in %q", want, text)
+				}
+			}
+		})
+	}
+}
+
+func TestNotificationCreateCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    []string
+		notWant []string
+		wantErr string
+	}{
+		{
+			name: "event types",
+			args: map[string]any{
+				"bucket":      "uploads",
+				"topic":       "upload-events",
+				"event_types": []any{"OBJECT_FINALIZE", "object_delete", "OBJECT_FINALIZE"},
+			},
+			want: []string{"--topic=upload-events", "--event-types=OBJECT_FINALIZE,OBJECT_DELETE", "--payload-format=json"},
+		},
+		{
+			name: "all events without payload",
+			args: map[string]any{
+				"bucket":         "gs://uploads",
+				"topic":          "projects/other/topics/upload-events",
+				"payload_format": "none",
+				"object_prefix":  "incoming/",
+			},
+			want:    []string{"--topic=projects/other/topics/upload-events", "--payload-format=none", "--object-prefix=incoming/"},
+			notWant: []string{"--event-types="},
+		},
+		{
+			name:    "unknown event type",
+			args:    map[string]any{"bucket": "uploads", "topic": "upload-events", "event_types": []any{"OBJECT_CREATE"}},
+			wantErr: `invalid event type "OBJECT_CREATE"`,
+		},
+		{
+			name:    "unknown payload format",
+			args:    map[string]any{"bucket": "uploads", "topic": "upload-events", "payload_format": "xml"},
+			wantErr: "invalid payload_format",
+		},
+		{
+			name:    "missing topic",
+			args:    map[string]any{"bucket": "uploads"},
+			wantErr: "topic",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, err := notificationCreateCommand(newTestBase(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if !slices.Equal(args[:5], []string{"storage", "buckets", "notifications", "create", "gs://uploads"}) {
+				t.Errorf("unexpected command %v", args)
+			}
+			if missing := executortest.Missing(args, tt.want...); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, args)
+			}
+			for _, prefix := range tt.notWant {
+				for _, arg := range args {
+					if strings.HasPrefix(arg, prefix) {
+						t.Errorf("unexpected %s in %v", arg, args)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestNotificationListCommand(t *testing.T) {
+	cmd, err := notificationListCommand(newTestBase(), map[string]any{"bucket": "uploads", "names_only": true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	args := cmd.Build()
+	if !slices.Equal(args[:5], []string{"storage", "buckets", "notifications", "list", "gs://uploads"}) {
+		t.Errorf("unexpected command %v", args)
+	}
+	if want := `--format=value("Notification Configuration".id:label=ID)`; !slices.Contains(args, want) {
+		t.Errorf("expected %s in %v", want, args)
+	}
+
+	if _, err := notificationListCommand(newTestBase(), map[string]any{"bucket": "uploads", "output": "yaml"}); err == nil {
+		t.Error("expected an error for an unsupported output")
+	}
+}
+
+func TestNotificationDeleteCommand(t *testing.T) {
+	cmd, err := notificationDeleteCommand(newTestBase(), map[string]any{"bucket": "gs://uploads/", "notification_id": "3"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	args := cmd.Build()
+	if !slices.Equal(args[:5], []string{"storage", "buckets", "notifications", "delete", "projects/_/buckets/uploads/notificationConfigs/3"}) {
+		t.Errorf("unexpected command %v", args)
+	}
+
+	if _, err := notificationDeleteCommand(newTestBase(), map[string]any{"bucket": "uploads"}); err == nil {
+		t.Error("expected an error without a notification_id, which would delete every notification")
+	}
+}
+
+func TestCatCommand_Range(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		want    string
+		wantErr string
+	}{
+		{
+			name: "whole object",
+			args: map[string]any{},
+		},
+		{
+			name: "start and end",
+			args: map[string]any{"start_byte": float64(256), "end_byte": float64(5939)},
+			want: "--range=256-5939",
+		},
+		{
+			name: "start only",
+			args: map[string]any{"start_byte": float64(1024)},
+			want: "--range=1024-",
+		},
+		{
+			name: "end only",
+			args: map[string]any{"end_byte": float64(99)},
+			want: "--range=0-99",
+		},
+		{
+			name: "head",
+			args: map[string]any{"max_bytes": float64(4096)},
+			want: "--range=0-4095",
+		},
+		{
+			name: "max bytes from start",
+			args: map[string]any{"start_byte": float64(100), "max_bytes": float64(50)},
+			want: "--range=100-149",
+		},
+		{
+			name: "max bytes caps end",
+			args: map[string]any{"start_byte": float64(0), "end_byte": float64(9999), "max_bytes": float64(1000)},
+			want: "--range=0-999",
+		},
+		{
+			name: "end before max bytes",
+			args: map[string]any{"end_byte": float64(9), "max_bytes": float64(1000)},
+			want: "--range=0-9",
+		},
+		{
+			name:    "end before start",
+			args:    map[string]any{"start_byte": float64(10), "end_byte": float64(5)},
+			wantErr: "must not be less than start_byte",
+		},
+		{
+			name:    "zero max bytes",
+			args:    map[string]any{"max_bytes": float64(0)},
+			wantErr: "max_bytes must be at least 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.args["url"] = "my-bucket/logs/app.log"
+			cmd, err := catCommand(newTestBase(), tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if !slices.Equal(args[:3], []string{"storage", "cat", "gs://my-bucket/logs/app.log"}) {
+				t.Errorf("unexpected command %v", args)
+			}
+			var ranges []string
+			for _, arg := range args {
+				if strings.HasPrefix(arg, "--range=") {
+					ranges = append(ranges, arg)
+				}
+			}
+			if tt.want == "" && len(ranges) > 0 {
+				t.Errorf("expected no --range, got %v", args)
+			}
+			if tt.want != "" && !slices.Equal(ranges, []string{tt.want}) {
+				t.Errorf("expected %s, got %v", tt.want, args)
+			}
+		})
+	}
+}
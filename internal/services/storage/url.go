@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NormalizeBucketURL returns the canonical gs://bucket URL for a bucket given
+// as a bare name or a gs:// URL, with or without a trailing slash.
+func NormalizeBucketURL(bucket string) (string, error) {
+	name := strings.TrimSuffix(strings.TrimPrefix(bucket, "gs://"), "/")
+	if strings.Contains(name, "/") {
+		return "", fmt.Errorf("%q names an object, not a bucket", bucket)
+	}
+	if err := validateBucketName(name); err != nil {
+		return "", err
+	}
+	return "gs://" + name, nil
+}
+
+// NormalizeObjectURL returns the canonical gs://bucket/object URL for an
+// object given as bucket/object or a gs:// URL. A trailing slash is kept,
+// since it names a folder prefix.
+func NormalizeObjectURL(url string) (string, error) {
+	bucket, object, err := splitObjectURL(url)
+	if err != nil {
+		return "", err
+	}
+	return "gs://" + bucket + "/" + object, nil
+}
+
+// splitObjectURL returns the bucket and object of an object URL accepted by
+// NormalizeObjectURL.
+func splitObjectURL(url string) (bucket, object string, err error) {
+	bucket, object, ok := strings.Cut(strings.TrimPrefix(url, "gs://"), "/")
+	if !ok || object == "" {
+		return "", "", fmt.Errorf("%q is not a gs://bucket/object URL", url)
+	}
+	if err := validateBucketName(bucket); err != nil {
+		return "", "", fmt.Errorf("%q is not a gs://bucket/object URL: %w", url, err)
+	}
+	return bucket, object, nil
+}
+
+// validateBucketName checks the characters of a bucket name, so that local
+// paths such as ./file are not mistaken for bucket/object.
+func validateBucketName(name string) error {
+	if name == "" {
+		return fmt.Errorf("bucket name cannot be empty")
+	}
+	isAlnum := func(c byte) bool { return c >= 'a' && c <= 'z' || c >= '0' && c <= '9' }
+	if !isAlnum(name[0]) || !isAlnum(name[len(name)-1]) {
+		return fmt.Errorf("invalid bucket name %q: must start and end with a lowercase letter or digit", name)
+	}
+	for i := 0; i < len(name); i++ {
+		if c := name[i]; !isAlnum(c) && c != '-' && c != '_' && c != '.' {
+			return fmt.Errorf("invalid bucket name %q: only lowercase letters, digits, '-', '_' and '.' are allowed", name)
+		}
+	}
+	return nil
+}
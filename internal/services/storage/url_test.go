@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"gcloud-go-mcp/internal/executor/executortest"
+	"gcloud-go-mcp/internal/services/servicetest"
+)
+
+func TestNormalizeBucketURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		bucket  string
+		want    string
+		wantErr string
+	}{
+		{name: "bare name", bucket: "my-bucket", want: "gs://my-bucket"},
+		{name: "gs url", bucket: "gs://my-bucket", want: "gs://my-bucket"},
+		{name: "trailing slash", bucket: "gs://my-bucket/", want: "gs://my-bucket"},
+		{name: "bare name with trailing slash", bucket: "my.bucket_1/", want: "gs://my.bucket_1"},
+		{name: "object url", bucket: "gs://my-bucket/logs", wantErr: "names an object"},
+		{name: "empty", bucket: "gs://", wantErr: "cannot be empty"},
+		{name: "uppercase", bucket: "My-Bucket", wantErr: "invalid bucket name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeBucketURL(tt.bucket)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeBucketURL(%q) = %q, want %q", tt.bucket, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeObjectURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		want    string
+		wantErr bool
+	}{
+		{name: "bare path", url: "my-bucket/logs/app.log", want: "gs://my-bucket/logs/app.log"},
+		{name: "gs url", url: "gs://my-bucket/logs/app.log", want: "gs://my-bucket/logs/app.log"},
+		{name: "trailing slash keeps folder", url: "gs://my-bucket/logs/", want: "gs://my-bucket/logs/"},
+		{name: "wildcard", url: "my-bucket/assets/*.css", want: "gs://my-bucket/assets/*.css"},
+		{name: "bucket only", url: "gs://my-bucket", wantErr: true},
+		{name: "bucket with trailing slash", url: "gs://my-bucket/", wantErr: true},
+		{name: "local path", url: "./logs/app.log", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeObjectURL(tt.url)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeObjectURL(%q) = %q, want %q", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToolCall_NormalizesURLs(t *testing.T) {
+	runner := &executortest.Runner{}
+
+	servicetest.CallTool(t, RegisterTools, runner, "gcp_storage_objects_list", map[string]any{
+		"bucket": "gs://my-bucket/",
+		"prefix": "logs/",
+	})
+	if args := runner.LastArgs(); !slices.Equal(args[:3], []string{"storage", "ls", "gs://my-bucket/logs/"}) {
+		t.Errorf("unexpected command %v", args)
+	}
+
+	servicetest.CallTool(t, RegisterTools, runner, "gcp_storage_objects_cat", map[string]any{"url": "my-bucket/logs/app.log"})
+	if args := runner.LastArgs(); !slices.Equal(args[:3], []string{"storage", "cat", "gs://my-bucket/logs/app.log"}) {
+		t.Errorf("unexpected command %v", args)
+	}
+
+	result := servicetest.CallTool(t, RegisterTools, runner, "gcp_storage_buckets_describe", map[string]any{"bucket": "gs://my-bucket/logs"})
+	if !result.IsError {
+		t.Errorf("expected an error for an object URL, got %s", servicetest.Text(result))
+	}
+}
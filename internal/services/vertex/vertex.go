@@ -0,0 +1,198 @@
+// Package vertex provides MCP tools for Vertex AI endpoints and models.
+package vertex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"gcloud-go-mcp/internal/executor"
+	"gcloud-go-mcp/internal/services"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// RegisterTools registers all Vertex AI tools with the MCP server.
+func RegisterTools(server *mcp.Server, base *services.BaseService) {
+	// List endpoints
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_vertex_endpoints_list",
+			Description: "List Vertex AI endpoints in a region",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"region": map[string]any{
+						"type":        "string",
+						"description": "Vertex AI region (uses default if not specified)",
+					},
+					"filter": map[string]any{
+						"type":        "string",
+						"description": "Filter expression",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := listCommand(base, "endpoints", args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// Describe endpoint
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_vertex_endpoints_describe",
+			Description: "Get details of a Vertex AI endpoint, including its deployed models",
+			InputSchema: map[string]any{
+				"type":     "object",
+				"required": []string{"endpoint"},
+				"properties": map[string]any{
+					"endpoint": map[string]any{
+						"type":        "string",
+						"description": "Endpoint ID",
+					},
+					"region": map[string]any{
+						"type":        "string",
+						"description": "Vertex AI region (uses default if not specified)",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"soft_not_found": services.SoftNotFoundProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := endpointDescribeCommand(base, args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.DescribeError(args, result, err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+
+	// List models
+	base.AddTool(server,
+		&mcp.Tool{
+			Name:        "gcp_vertex_models_list",
+			Description: "List models in the Vertex AI Model Registry for a region",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"region": map[string]any{
+						"type":        "string",
+						"description": "Vertex AI region (uses default if not specified)",
+					},
+					"filter": map[string]any{
+						"type":        "string",
+						"description": "Filter expression",
+					},
+					"project": map[string]any{
+						"type":        "string",
+						"description": "GCP project ID",
+					},
+					"output":     services.ListOutputProperty(),
+					"names_only": services.NamesOnlyProperty(),
+				},
+			},
+		},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := parseArgs(req)
+			cmd, err := listCommand(base, "models", args)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+
+			result, err := cmd.Execute(ctx)
+			if err != nil {
+				return services.ToolError(err), nil
+			}
+			return base.CommandResult(result), nil
+		},
+	)
+}
+
+func parseArgs(req *mcp.CallToolRequest) map[string]any {
+	var args map[string]any
+	if req.Params.Arguments != nil {
+		_ = json.Unmarshal(req.Params.Arguments, &args)
+	}
+	if args == nil {
+		args = make(map[string]any)
+	}
+	return args
+}
+
+// csvColumns are the default column projections used when a list tool is
+// called with output=csv, keyed by resource.
+var csvColumns = map[string]string{
+	"endpoints": "name.basename(),displayName,deployedModels.len(),createTime",
+	"models":    "name.basename(),displayName,versionId,createTime",
+}
+
+// requiredRegion returns the region for a Vertex AI command. Unlike most gcloud
+// commands, `gcloud ai` prompts for a region when none is set, so it has to be
+// passed explicitly.
+func requiredRegion(base *services.BaseService, args map[string]any) (string, error) {
+	region := services.GetOptionalString(args, "region", base.Config.Region)
+	if region == "" {
+		return "", fmt.Errorf("region is required (pass region or set GCLOUD_REGION)")
+	}
+	return region, nil
+}
+
+// listCommand builds the `ai <resource> list` command.
+func listCommand(base *services.BaseService, resource string, args map[string]any) (*executor.CommandBuilder, error) {
+	region, err := requiredRegion(base, args)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := base.Executor.Command("ai", resource, "list").
+		WithFlag("region", region).
+		WithFlag("filter", services.GetOptionalString(args, "filter", "")).
+		WithProject(services.GetOptionalString(args, "project", ""))
+
+	if err := services.ApplyListOutput(cmd, args, csvColumns[resource]); err != nil {
+		return nil, err
+	}
+	return cmd, nil
+}
+
+// endpointDescribeCommand builds the `ai endpoints describe` command.
+func endpointDescribeCommand(base *services.BaseService, args map[string]any) (*executor.CommandBuilder, error) {
+	endpoint, err := services.GetRequiredString(args, "endpoint")
+	if err != nil {
+		return nil, err
+	}
+	region, err := requiredRegion(base, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return base.Executor.Command("ai", "endpoints", "describe", endpoint).
+		WithFlag("region", region).
+		WithProject(services.GetOptionalString(args, "project", "")), nil
+}
@@ -0,0 +1,130 @@
+package vertex
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"gcloud-go-mcp/internal/config"
+	"gcloud-go-mcp/internal/executor/executortest"
+	"gcloud-go-mcp/internal/services"
+	"gcloud-go-mcp/internal/services/servicetest"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func newTestBase() *services.BaseService {
+	return services.NewBaseService(servicetest.NewConfig())
+}
+
+func TestRegisterTools(t *testing.T) {
+	server := mcp.NewServer(
+		&mcp.Implementation{
+			Name:    "test-server",
+			Version: "0.0.1",
+		},
+		&mcp.ServerOptions{},
+	)
+
+	// Should not panic
+	RegisterTools(server, newTestBase())
+}
+
+func TestListCommand_Region(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource string
+		region   string
+		args     map[string]any
+		want     []string
+		wantErr  string
+	}{
+		{
+			name:     "endpoints with default region",
+			resource: "endpoints",
+			region:   "us-central1",
+			args:     map[string]any{},
+			want:     []string{"ai", "endpoints", "list", "--region=us-central1"},
+		},
+		{
+			name:     "models with explicit region",
+			resource: "models",
+			region:   "us-central1",
+			args:     map[string]any{"region": "europe-west4", "filter": "displayName:gemma"},
+			want:     []string{"ai", "models", "list", "--region=europe-west4", "--filter=displayName:gemma"},
+		},
+		{
+			name:     "explicit region without default",
+			resource: "endpoints",
+			args:     map[string]any{"region": "asia-northeast1"},
+			want:     []string{"ai", "endpoints", "list", "--region=asia-northeast1"},
+		},
+		{
+			name:     "no region",
+			resource: "endpoints",
+			args:     map[string]any{},
+			wantErr:  "region is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := newTestBase()
+			base.Config.Region = tt.region
+
+			cmd, err := listCommand(base, tt.resource, tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			args := cmd.Build()
+			if !slices.Equal(args[:3], tt.want[:3]) {
+				t.Errorf("expected command %v, got %v", tt.want[:3], args[:3])
+			}
+			if missing := executortest.Missing(args, tt.want[3:]...); len(missing) > 0 {
+				t.Errorf("missing %v in %v", missing, args)
+			}
+		})
+	}
+}
+
+func TestEndpointDescribeCommand(t *testing.T) {
+	base := newTestBase()
+	cmd, err := endpointDescribeCommand(base, map[string]any{"endpoint": "1234567890"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	args := cmd.Build()
+	if !slices.Equal(args[:4], []string{"ai", "endpoints", "describe", "1234567890"}) {
+		t.Errorf("unexpected command %v", args)
+	}
+	if !slices.Contains(args, "--region=us-central1") {
+		t.Errorf("expected --region=us-central1 in %v", args)
+	}
+
+	base.Config.Region = ""
+	if _, err := endpointDescribeCommand(base, map[string]any{"endpoint": "1234567890"}); err == nil {
+		t.Error("expected error when no region is available")
+	}
+	if _, err := endpointDescribeCommand(base, map[string]any{"region": "us-east1"}); err == nil {
+		t.Error("expected error for missing endpoint")
+	}
+}
+
+func TestToolCall_ModelsListNoRegion(t *testing.T) {
+	runner := &executortest.Runner{}
+	cfg := &config.Config{Project: "test-project", GCloudPath: "gcloud", CommandTimeout: time.Minute}
+	result := servicetest.CallToolWithConfig(t, cfg, RegisterTools, runner, "gcp_vertex_models_list", map[string]any{})
+	if !result.IsError {
+		t.Fatalf("expected error, got %s", servicetest.Text(result))
+	}
+	if len(runner.Calls()) != 0 {
+		t.Errorf("expected gcloud not to run, got %v", runner.Calls())
+	}
+}